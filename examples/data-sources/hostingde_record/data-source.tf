@@ -0,0 +1,14 @@
+# Look up the MX record at the zone apex, e.g. to reference its content.
+data "hostingde_record" "mail" {
+  zone_id = hostingde_zone.sample.id
+  name = "example.test"
+  type = "MX"
+}
+
+# Read the first of several round-robin A records.
+data "hostingde_record" "www" {
+  zone_id = hostingde_zone.sample.id
+  name = "www.example.test"
+  type = "A"
+  allow_multiple = true
+}
@@ -0,0 +1,13 @@
+# Count the records of a zone, e.g. to track how close it is to its record limit.
+data "hostingde_record_count" "example" {
+  zone_id = hostingde_zone.example.id
+}
+
+# Count the A records of all zones.
+data "hostingde_record_count" "a_records" {
+  type = "A"
+}
+
+output "example_record_count" {
+  value = data.hostingde_record_count.example.count
+}
@@ -0,0 +1,9 @@
+# Map the records managed by Terraform to their hosting.de record IDs.
+data "hostingde_record_ids" "example" {
+  zone_id           = hostingde_zone.example.id
+  terraform_managed = true
+}
+
+output "www_record_id" {
+  value = data.hostingde_record_ids.example.ids["www.example.test A 192.0.2.1"]
+}
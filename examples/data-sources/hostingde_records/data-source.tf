@@ -0,0 +1,26 @@
+# List all MX records at the zone apex.
+data "hostingde_records" "mx" {
+  zone_id = hostingde_zone.sample.id
+  name = "example.test"
+  type = "MX"
+}
+
+# Fail if the DMARC policy is missing.
+data "hostingde_records" "dmarc" {
+  zone_id = hostingde_zone.sample.id
+  name = "_dmarc.example.test"
+  type = "TXT"
+  fail_on_empty = true
+}
+
+# List the records changed in the last 24 hours, sorted by change date.
+data "hostingde_records" "recent" {
+  zone_id = hostingde_zone.sample.id
+  changed_after = timeadd(timestamp(), "-24h")
+}
+
+# Group the records into RRsets, e.g. to inspect round-robin records.
+data "hostingde_records" "rrsets" {
+  zone_id = hostingde_zone.sample.id
+  group_rrsets = true
+}
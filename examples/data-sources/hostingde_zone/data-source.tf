@@ -0,0 +1,11 @@
+# Look up the zone ID by name instead of hard-coding it.
+data "hostingde_zone" "main" {
+  name = "example.test"
+}
+
+resource "hostingde_record" "www" {
+  zone_id = data.hostingde_zone.main.id
+  name = "www.example.test"
+  type = "A"
+  content = "192.0.2.1"
+}
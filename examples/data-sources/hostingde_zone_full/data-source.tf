@@ -0,0 +1,4 @@
+# Read the zone config and all records of a zone.
+data "hostingde_zone_full" "example" {
+  name = "example.test"
+}
@@ -0,0 +1,8 @@
+# Read the SOA record of a zone.
+data "hostingde_zone_soa" "example" {
+  zone_id = hostingde_zone.example.id
+}
+
+output "soa_record" {
+  value = data.hostingde_zone_soa.example.record
+}
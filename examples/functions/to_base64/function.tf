@@ -0,0 +1,3 @@
+output "tlsa_data_base64" {
+  value = provider::hostingde::to_base64("8f4e1a3c9c2c7b7e8d1f0a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b7c6d")
+}
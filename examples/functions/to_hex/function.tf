@@ -0,0 +1,7 @@
+# Publish the SHA-256 hash of a certificate's public key as TLSA record.
+resource "hostingde_record" "example_tlsa" {
+  zone_id = hostingde_zone.sample.id
+  name = "_443._tcp.www.example.test"
+  type = "TLSA"
+  content = "3 1 1 ${provider::hostingde::to_hex(var.public_key_sha256_base64)}"
+}
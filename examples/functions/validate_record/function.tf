@@ -0,0 +1,19 @@
+variable "mx_priority" {
+  type    = number
+  default = 10
+}
+
+resource "hostingde_record" "example_mx" {
+  zone_id  = hostingde_zone.example.id
+  name     = "example.test"
+  type     = "MX"
+  content  = "mail.example.test"
+  priority = var.mx_priority
+
+  lifecycle {
+    precondition {
+      condition     = provider::hostingde::validate_record("MX", "mail.example.test", 3600, var.mx_priority) == ""
+      error_message = provider::hostingde::validate_record("MX", "mail.example.test", 3600, var.mx_priority)
+    }
+  }
+}
@@ -0,0 +1,46 @@
+# Manage the records of several zones, e.g. from a module output.
+resource "hostingde_multi_zone_records" "example" {
+  zones = {
+    (hostingde_zone.sample.id) = {
+      records = [
+        {
+          name = "www.example.test"
+          type = "A"
+          content = "192.0.2.1"
+        },
+        {
+          name = "example.test"
+          type = "MX"
+          content = "mail.example.test"
+          priority = 10
+        },
+      ]
+    }
+    (hostingde_zone.other.id) = {
+      records = [
+        {
+          name = "www.example2.test"
+          type = "CNAME"
+          content = "www.example.test"
+          ttl = 300
+        },
+      ]
+    }
+  }
+}
+
+# Apex NS records are protected, unless managed explicitly.
+resource "hostingde_multi_zone_records" "delegation" {
+  manage_apex_ns = true
+  zones = {
+    (hostingde_zone.delegated.id) = {
+      records = [
+        {
+          name = "delegated.test"
+          type = "NS"
+          content = "ns1.other-provider.test"
+        },
+      ]
+    }
+  }
+}
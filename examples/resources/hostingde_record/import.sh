@@ -1,3 +1,8 @@
 # DNS record can be imported by specifying the record id.
 # See the README for details how to get the record id.
 terraform import hostingde_record.example $RECORD_ID
+
+# Alternatively, records can be imported by zone id, name and type, followed by
+# the content if several records have the same name and type.
+terraform import hostingde_record.example $ZONE_CONFIG_ID/www.example.com/A
+terraform import hostingde_record.example $ZONE_CONFIG_ID/www.example.com/A/192.0.2.1
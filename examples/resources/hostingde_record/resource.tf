@@ -17,3 +17,61 @@ resource "hostingde_record" "example" {
   priority = 10
   comments = "Example Comment"
 }
+
+# Manage example DNS MX record at the zone apex, using zone file notation.
+resource "hostingde_record" "example_apex_mx" {
+  zone_id = hostingde_zone.sample.id
+  name = "@"
+  type = "MX"
+  content = "mail.example.test"
+  priority = 10
+}
+
+# Manage example DNS record with a name relative to the zone.
+resource "hostingde_record" "example_relative" {
+  zone_id = hostingde_zone.sample.id
+  name = "www"
+  type = "A"
+  content = "192.0.2.1"
+}
+
+# Manage example DNS DS record delegating DNSSEC to a child zone.
+resource "hostingde_record" "example_ds" {
+  zone_id = hostingde_zone.sample.id
+  name = "sub.example.test"
+  type = "DS"
+  ds_key_tag = 2371
+  ds_algorithm = 13
+  ds_digest_type = 2
+  ds_digest = "2BB183AF5F22588179A53B0A98631FAD1A292118C0D8F7A9A2B6D8B6F1D83FAB"
+}
+
+# Manage example DNS TLSA record with base64 encoded certificate data.
+resource "hostingde_record" "example_tlsa" {
+  zone_id = hostingde_zone.sample.id
+  name = "_443._tcp.www.example.test"
+  type = "TLSA"
+  tlsa_usage = 3
+  tlsa_selector = 1
+  tlsa_matching_type = 1
+  tlsa_data_base64 = "jkkAvBYfRDh3xrFXnBD1nDNnPZc9XZpNtqE6e9vg4o0="
+}
+
+# Manage example DNS record in a zone referenced by name. With the provider's
+# auto_create_zone option, the zone is created if it does not exist yet.
+resource "hostingde_record" "example_by_zone_name" {
+  zone_name = "example.test"
+  name = "api"
+  type = "CNAME"
+  content = "www.example.test"
+}
+
+# Manage example DNS TXT record with a DKIM key longer than 255 bytes, split
+# into several character strings when sent to the API.
+resource "hostingde_record" "example_dkim" {
+  zone_id = hostingde_zone.sample.id
+  name = "mail._domainkey.example.test"
+  type = "TXT"
+  content = trimspace(file("${path.module}/mail.dkim.txt"))
+  auto_split = true
+}
@@ -0,0 +1,7 @@
+# Ensure a legacy MX record does not exist.
+resource "hostingde_record_absent" "legacy_mx" {
+  zone_id = hostingde_zone.sample.id
+  name = "example.test"
+  type = "MX"
+  content = "legacy-mail.example.test"
+}
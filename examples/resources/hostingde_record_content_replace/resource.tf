@@ -0,0 +1,7 @@
+# Point all A records from the old to the new web server.
+resource "hostingde_record_content_replace" "web_migration" {
+  zone_id = hostingde_zone.sample.id
+  type = "A"
+  content = "192.0.2.10"
+  new_content = "192.0.2.20"
+}
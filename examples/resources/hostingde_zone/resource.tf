@@ -2,4 +2,14 @@
 resource "hostingde_zone" "sample" {
   name = "example.test"
   type = "NATIVE"
+
+  # Sign the zone, with keys managed by hosting.de.
+  dnssec_mode = "automatic"
+
+  # Let secondary name servers refresh twice a day. Omitted values
+  # default to those of new zones.
+  soa_values = {
+    refresh      = 43200
+    negative_ttl = 300
+  }
 }
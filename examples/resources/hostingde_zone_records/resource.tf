@@ -0,0 +1,17 @@
+# Manage all records of a zone, deleting any record not configured here.
+resource "hostingde_zone_records" "example" {
+  zone_id = hostingde_zone.sample.id
+  records = [
+    {
+      name = "www.example.test"
+      type = "A"
+      content = "192.0.2.1"
+    },
+    {
+      name = "example.test"
+      type = "MX"
+      content = "mail.example.test"
+      priority = 10
+    },
+  ]
+}
@@ -0,0 +1,5 @@
+# Lower the TTL of all records before migrating the zone.
+resource "hostingde_zone_ttl" "pre_migration" {
+  zone_id = hostingde_zone.sample.id
+  ttl = 300
+}
@@ -0,0 +1,54 @@
+package hostingde
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// accountIDKey is the context key of the account ID configured on a resource.
+type accountIDKey struct{}
+
+// withAccountID returns a context whose requests are sent on behalf of the
+// account configured on a resource, if it is set.
+func withAccountID(ctx context.Context, configured types.String) context.Context {
+	if configured.IsNull() || configured.IsUnknown() || configured.ValueString() == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, accountIDKey{}, configured.ValueString())
+}
+
+// resolveAccountID returns the ID of the account a request is sent on behalf
+// of. An account ID set on a resource, see withAccountID, takes precedence
+// over the provider's account_id. An empty ID sends the request on behalf of
+// the account of the API key.
+func (c *Client) resolveAccountID(ctx context.Context) string {
+	if accountId, ok := ctx.Value(accountIDKey{}).(string); ok {
+		return accountId
+	}
+	return c.accountId
+}
+
+// newBaseRequest returns the common fields of a request sent on behalf of the
+// account returned by resolveAccountID. All requests are built with it.
+func (c *Client) newBaseRequest(ctx context.Context) *BaseRequest {
+	return &BaseRequest{AccountId: c.resolveAccountID(ctx)}
+}
+
+// addAccountOverrideWarning warns if the account ID configured on a resource
+// equals the provider's account_id. Overriding the provider's account with a
+// sub-account is intended, but repeating it has no effect and is likely left
+// over from moving the resource between accounts.
+func (c *Client) addAccountOverrideWarning(diags *diag.Diagnostics, attributePath path.Path, configured types.String) {
+	if c.accountId == "" || configured.IsNull() || configured.IsUnknown() || configured.ValueString() != c.accountId {
+		return
+	}
+	diags.AddAttributeWarning(
+		attributePath,
+		"Account ID repeats provider account_id",
+		"Requests are sent on behalf of the provider's account "+c.accountId+" anyway. "+
+			"Remove the attribute, or set it to the account owning the resource if it belongs to another account.",
+	)
+}
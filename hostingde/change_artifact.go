@@ -0,0 +1,153 @@
+package hostingde
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// changeArtifact collects the record changes applied by the provider and
+// writes them as JSON to a file after every change, e.g. for audit and
+// rollback tooling. The file is replaced when the provider is configured, so
+// it holds the changes of a single Terraform run.
+type changeArtifact struct {
+	path string
+
+	mu sync.Mutex
+	// previous holds the records before pending modifications, by ID.
+	previous map[string]DNSRecord
+	changes  []recordChange
+	// err is the last error writing the file, reported once by
+	// addChangeArtifactWarning.
+	err error
+}
+
+// changeArtifactFile is the content of the change artifact.
+type changeArtifactFile struct {
+	Changes []recordChange `json:"changes"`
+}
+
+// recordChange is a single record change of the change artifact. Records are
+// in the form sent to the API. Before is null for added records, after is
+// null for deleted records, and before is also null for modified records not
+// known before the change.
+type recordChange struct {
+	Action              string     `json:"action"`
+	ZoneID              string     `json:"zone_id"`
+	Before              *DNSRecord `json:"before"`
+	After               *DNSRecord `json:"after"`
+	ClientTransactionID string     `json:"client_transaction_id"`
+	ServerTransactionID string     `json:"server_transaction_id"`
+	Time                string     `json:"time"`
+}
+
+// newChangeArtifact returns a change artifact writing to path, which is
+// created or truncated right away to report unusable paths early.
+func newChangeArtifact(path string) (*changeArtifact, error) {
+	a := &changeArtifact{path: path, previous: map[string]DNSRecord{}}
+	if err := a.write(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// notePrevious remembers the records before an update, so modifications can
+// be recorded with their previous state.
+func (a *changeArtifact) notePrevious(previous []DNSRecord) {
+	if a == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, record := range previous {
+		if record.ID != "" {
+			a.previous[record.ID] = record
+		}
+	}
+}
+
+// recordUpdate adds the changes of a successful records update and rewrites
+// the file. Added records are recorded as returned by the API, including
+// their IDs, if the response contains them.
+func (a *changeArtifact) recordUpdate(updateRequest RecordsUpdateRequest, updateResponse *RecordsUpdateResponse) {
+	if a == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	change := func(action string, before, after *DNSRecord) {
+		a.changes = append(a.changes, recordChange{
+			Action:              action,
+			ZoneID:              updateRequest.ZoneConfigId,
+			Before:              before,
+			After:               after,
+			ClientTransactionID: updateResponse.Metadata.ClientTransactionID,
+			ServerTransactionID: updateResponse.Metadata.ServerTransactionID,
+			Time:                time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+	for _, record := range updateRequest.RecordsToDelete {
+		before := record
+		if previous, ok := a.previous[record.ID]; ok {
+			before = previous
+		}
+		change("delete", &before, nil)
+		delete(a.previous, record.ID)
+	}
+	for _, record := range updateRequest.RecordsToModify {
+		var before *DNSRecord
+		if previous, ok := a.previous[record.ID]; ok {
+			before = &previous
+		}
+		after := record
+		if returned, ok := matchReturnedRecord(updateResponse.Response.Records, record); ok {
+			after = returned
+		}
+		change("modify", before, &after)
+		delete(a.previous, record.ID)
+	}
+	for _, record := range updateRequest.RecordsToAdd {
+		after := record
+		if returned, ok := matchReturnedRecord(updateResponse.Response.Records, record); ok {
+			after = returned
+		}
+		change("add", nil, &after)
+	}
+
+	a.err = a.write()
+}
+
+// write replaces the file with all changes recorded so far. The caller must
+// hold mu, unless the artifact is not shared yet.
+func (a *changeArtifact) write() error {
+	changes := a.changes
+	if changes == nil {
+		changes = []recordChange{}
+	}
+	data, err := json.MarshalIndent(changeArtifactFile{Changes: changes}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(a.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing change artifact: %w", err)
+	}
+	return nil
+}
+
+// takeError returns and clears the last error writing the file.
+func (a *changeArtifact) takeError() error {
+	if a == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	err := a.err
+	a.err = nil
+	return err
+}
@@ -0,0 +1,121 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestChangeArtifact(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{
+				Status:   "success",
+				Metadata: Metadata{ClientTransactionID: "client-tx", ServerTransactionID: "server-tx"},
+			}}
+			for _, record := range updateRequest.RecordsToAdd {
+				record.ID = "new-" + record.Name
+				updateResponse.Response.Records = append(updateResponse.Response.Records, record)
+			}
+			updateResponse.Response.Records = append(updateResponse.Response.Records, updateRequest.RecordsToModify...)
+			return updateResponse
+		},
+	})
+
+	path := filepath.Join(t.TempDir(), "changes.json")
+	artifact, err := newChangeArtifact(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.changeArtifact = artifact
+
+	readArtifact := func() changeArtifactFile {
+		t.Helper()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var file changeArtifactFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	if file := readArtifact(); file.Changes == nil || len(file.Changes) != 0 {
+		t.Fatalf("expected an empty artifact once configured, got %+v", file)
+	}
+
+	current := []DNSRecord{
+		{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "2", Name: "mail.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+	}
+	desired := []DNSRecord{
+		{Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 300},
+		{Name: "ftp.example.test", Type: "A", Content: "192.0.2.3", TTL: 3600},
+	}
+	if _, _, err := client.applyRecords(context.Background(), "zone-id", current, desired); err != nil {
+		t.Fatal(err)
+	}
+
+	file := readArtifact()
+	if len(file.Changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", file.Changes)
+	}
+	for _, change := range file.Changes {
+		if change.ZoneID != "zone-id" || change.ClientTransactionID != "client-tx" || change.ServerTransactionID != "server-tx" || change.Time == "" {
+			t.Errorf("expected zone and transaction IDs of the change, got %+v", change)
+		}
+	}
+	deleted, modified, added := file.Changes[0], file.Changes[1], file.Changes[2]
+	if deleted.Action != "delete" || deleted.Before == nil || deleted.Before.ID != "2" || deleted.Before.Content != "192.0.2.2" || deleted.After != nil {
+		t.Errorf("expected deletion of the mail record, got %+v", deleted)
+	}
+	if modified.Action != "modify" || modified.Before == nil || modified.Before.TTL != 3600 || modified.After == nil || modified.After.TTL != 300 || modified.After.ID != "1" {
+		t.Errorf("expected TTL change of the www record, got %+v", modified)
+	}
+	if added.Action != "add" || added.Before != nil || added.After == nil || added.After.ID != "new-ftp.example.test" {
+		t.Errorf("expected addition of the ftp record with its ID, got %+v", added)
+	}
+
+	// Changes are appended to the artifact.
+	if _, _, err := client.applyRecords(context.Background(), "zone-id", nil, desired[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if file := readArtifact(); len(file.Changes) != 4 {
+		t.Errorf("expected the changes of both updates, got %+v", file.Changes)
+	}
+
+	// Write errors are reported as a warning, without failing the change.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.applyRecords(context.Background(), "zone-id", nil, desired[1:]); err != nil {
+		t.Fatal(err)
+	}
+	var diags diag.Diagnostics
+	client.addOperationDiagnostics(nil, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Could not write change artifact" {
+		t.Errorf("expected a warning for the failed write, got %v", diags)
+	}
+	diags = nil
+	client.addOperationDiagnostics(nil, &diags)
+	if diags.WarningsCount() != 0 {
+		t.Errorf("expected the write error to be reported once, got %v", diags)
+	}
+
+	if _, err := newChangeArtifact(filepath.Join(t.TempDir(), "missing", "changes.json")); err == nil {
+		t.Error("expected an error for a path in a missing directory")
+	}
+}
@@ -4,21 +4,332 @@ package hostingde
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Verbosity levels for errors returned by the API.
+const (
+	// errorVerbosityBasic only includes the API response in errors.
+	errorVerbosityBasic = "basic"
+	// errorVerbosityDetailed adds the redacted request body to validation-class (4xx) errors.
+	errorVerbosityDetailed = "detailed"
+	// errorVerbosityRaw adds the redacted request body to all HTTP errors.
+	errorVerbosityRaw = "raw"
+)
+
+// Forms in which internationalized host names in record content are shown.
+const (
+	// idnDisplayACE shows host names in ASCII compatible form, as returned by
+	// the API.
+	idnDisplayACE = "ace"
+	// idnDisplayUnicode shows host names in Unicode.
+	idnDisplayUnicode = "unicode"
 )
 
+// ErrNotFound is returned, wrapped, by client methods if the requested zone
+// or record does not exist. Use errors.Is to check for it.
+var ErrNotFound = errors.New("not found")
+
+// ErrMaintenance is returned, wrapped, by client methods if the API rejects a
+// request because of scheduled maintenance.
+var ErrMaintenance = errors.New("hosting.de API is in maintenance")
+
+// ErrConflict is returned, wrapped, by client methods if the API rejects a
+// change because the zone was modified concurrently, e.g. by another user.
+var ErrConflict = errors.New("zone was modified concurrently")
+
+// Services of the API. Each service has its own endpoint, e.g.
+// https://secure.hosting.de/api/dns/v1/json for the DNS service.
+const (
+	serviceDNS     = "dns"
+	serviceAccount = "account"
+	serviceDomain  = "domain"
+)
+
+// defaultServiceURLs are the production endpoints of the API services.
+var defaultServiceURLs = map[string]string{
+	serviceDNS:     "https://secure.hosting.de/api/dns/v1/json",
+	serviceAccount: "https://secure.hosting.de/api/account/v1/json",
+	serviceDomain:  "https://secure.hosting.de/api/domain/v1/json",
+}
+
+// defaultPageConcurrency is the default number of pages fetched in parallel.
+const defaultPageConcurrency = 4
+
+// defaultRequestTimeout is the default timeout of a single API request.
+const defaultRequestTimeout = 10 * time.Second
+
+// defaultTXTSizeThreshold is the default size in bytes of TXT record data
+// above which records are reported as too large, the size of a plain UDP DNS
+// message.
+const defaultTXTSizeThreshold = 512
+
+// defaultMaxBatchSize is the default maximum number of record changes sent
+// in a single records update.
+const defaultMaxBatchSize = 100
+
+// Defaults for retrying to find a record right after it was created.
+const (
+	defaultReadAfterWriteRetries = 3
+	defaultReadAfterWriteDelay   = time.Second
+)
+
+// Delays before retrying requests rejected by the API's rate limit or
+// failed with a transient server error. The delay doubles on every attempt
+// unless the API sends a Retry-After header.
+const (
+	defaultRateLimitDelay = time.Second
+	maxRateLimitDelay     = 30 * time.Second
+)
+
+// defaultMaxRetries is the default number of retries of rate limited requests
+// and of reads failed with a transient server error.
+const defaultMaxRetries = 3
+
+// maxBlockedRetries is the number of retries of requests blocked by a running
+// zone operation, independent of maxRetries.
+const maxBlockedRetries = 8
+
+// defaultConflictRetries is the default number of retries of record updates
+// rejected because the zone was modified concurrently.
+const defaultConflictRetries = 2
+
+// defaultMaintenanceRetryDelay is the delay between attempts while the API is
+// in maintenance, if waiting for the end of the maintenance is enabled.
+const defaultMaintenanceRetryDelay = 30 * time.Second
+
+// backoffSummary aggregates the delays of retried requests by reason.
+// maintenance is set if a request failed because the API is in maintenance.
+type backoffSummary struct {
+	total       time.Duration
+	reasons     map[string]int
+	maintenance bool
+	// fallbackAuth is set if requests were authenticated with the fallback
+	// auth token.
+	fallbackAuth bool
+}
+
+// operationEvents collects the backoffs of the requests sent for a single
+// operation, like the Create of a resource, so they are reported as
+// diagnostics of that operation only. A nil *operationEvents ignores all
+// events.
+type operationEvents struct {
+	mu      sync.Mutex
+	summary backoffSummary
+}
+
+type operationEventsKey struct{}
+
+// withOperationEvents returns a context collecting the events of the requests
+// sent with it, to be reported by addOperationDiagnostics.
+func withOperationEvents(ctx context.Context) (context.Context, *operationEvents) {
+	events := &operationEvents{}
+	return context.WithValue(ctx, operationEventsKey{}, events), events
+}
+
+// operationEventsFrom returns the events collected for the operation of ctx,
+// or nil if ctx does not collect events.
+func operationEventsFrom(ctx context.Context) *operationEvents {
+	events, _ := ctx.Value(operationEventsKey{}).(*operationEvents)
+	return events
+}
+
+// addBackoff records a delay before retrying a request and its reason.
+func (e *operationEvents) addBackoff(reason string, delay time.Duration) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.summary.reasons == nil {
+		e.summary.reasons = map[string]int{}
+	}
+	e.summary.reasons[reason]++
+	e.summary.total += delay
+}
+
+// setMaintenance records that a request failed because the API is in
+// maintenance.
+func (e *operationEvents) setMaintenance() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.summary.maintenance = true
+}
+
+// setFallbackAuth records that a request was authenticated with the fallback
+// auth token.
+func (e *operationEvents) setFallbackAuth() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.summary.fallbackAuth = true
+}
+
+// merge adds the events of other, e.g. those of a batch of requests sent on
+// behalf of several operations.
+func (e *operationEvents) merge(other *operationEvents) {
+	if e == nil || other == nil || e == other {
+		return
+	}
+	theirs := other.backoffs()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(theirs.reasons) > 0 && e.summary.reasons == nil {
+		e.summary.reasons = map[string]int{}
+	}
+	for reason, count := range theirs.reasons {
+		e.summary.reasons[reason] += count
+	}
+	e.summary.total += theirs.total
+	e.summary.maintenance = e.summary.maintenance || theirs.maintenance
+	e.summary.fallbackAuth = e.summary.fallbackAuth || theirs.fallbackAuth
+}
+
+// backoffs returns a copy of the events collected so far.
+func (e *operationEvents) backoffs() backoffSummary {
+	if e == nil {
+		return backoffSummary{}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	summary := e.summary
+	summary.reasons = make(map[string]int, len(e.summary.reasons))
+	for reason, count := range e.summary.reasons {
+		summary.reasons[reason] = count
+	}
+	return summary
+}
+
 // Client -
 type Client struct {
-	HTTPClient *http.Client
-	accountId  string
-	authToken  string
-	baseURL    string
+	HTTPClient     *http.Client
+	accountId      string
+	authToken      string
+	errorVerbosity string
+
+	// fallbackAuthToken authenticates requests rejected with HTTP 401 when
+	// sent with authToken, so tokens can be rotated without downtime. Once
+	// authToken was rejected, requests are sent with the fallback directly.
+	fallbackAuthToken string
+	authTokenMu       sync.Mutex
+	authTokenRejected bool
+
+	// serviceURLs holds the base URL of each API service.
+	serviceURLs map[string]string
+
+	// pageConcurrency is the maximum number of pages fetched in parallel.
+	pageConcurrency int
+	// commentPrefix marks record comments of records managed by Terraform.
+	commentPrefix string
+	// normalizeRecordTypes lists the record types whose content is normalized.
+	normalizeRecordTypes map[string]bool
+	// normalizationWarning warns when the normalization changes the content
+	// of a record.
+	normalizationWarning bool
+	// idnDisplay is the form of internationalized host names in record
+	// content that does not match the configuration.
+	idnDisplay string
+	// logMutations logs every record and zone change before it is sent.
+	logMutations bool
+	// changeSummary reports the number of changed records per zone after
+	// updating the records of several zones.
+	changeSummary bool
+	// changeArtifact writes the applied record changes to a file, if set.
+	changeArtifact *changeArtifact
+	// autoCreateZone creates missing zones of records configured by zone name.
+	autoCreateZone bool
+	// strictValidation reports likely misconfigurations as errors.
+	strictValidation bool
+	// txtSizeThreshold is the size in bytes of TXT record data above which
+	// records are reported as too large. It is unset if 0.
+	txtSizeThreshold int
+	// defaultRecordType is the type of records without a configured type.
+	defaultRecordType string
+	// defaultTTL is the TTL of records without a configured TTL, taking
+	// precedence over the TTL of the zone. It is unset if 0.
+	defaultTTL int64
+	// truncateComments truncates record comments longer than the API accepts.
+	truncateComments bool
+	// convertSPFToTXT publishes records of the deprecated SPF type as TXT.
+	convertSPFToTXT bool
+	// readAfterWriteRetries is the number of additional attempts to find a
+	// created record, waiting readAfterWriteDelay before each.
+	readAfterWriteRetries int
+	readAfterWriteDelay   time.Duration
+	// deleteGracePeriod is the time to wait before deleting records and zones.
+	deleteGracePeriod time.Duration
+	// zoneRecordLimit is the maximum number of records per zone, if known.
+	zoneRecordLimit int
+	// validateTargetsResolve enables plan-time lookups of record targets.
+	validateTargetsResolve bool
+	resolver               hostResolver
+	// signer signs every request before it is sent.
+	signer requestSigner
+
+	// zoneLocks serializes record updates per zone. Acquiring a lock waits
+	// at most zoneLockTimeout, if set, in addition to the operation's context.
+	zoneLocksMu     sync.Mutex
+	zoneLocks       map[string]chan struct{}
+	zoneLockTimeout time.Duration
+
+	// batches collects record updates per zone and account for batchWindow
+	// before sending them as one request. Batching is disabled if batchWindow
+	// is 0.
+	batchesMu   sync.Mutex
+	batches     map[string]*recordsBatch
+	batchWindow time.Duration
+	// maxBatchSize is the maximum number of record changes per request.
+	maxBatchSize int
+
+	// rateLimitDelay is the initial delay before retrying a rate limited request.
+	rateLimitDelay time.Duration
+	// maxRetries is the number of retries of rate limited requests and of
+	// reads failed with a transient server error.
+	maxRetries int
+	// conflictRetries is the number of retries of record updates rejected
+	// because the zone was modified concurrently. Each retry re-reads the
+	// zone first.
+	conflictRetries int
+	// maintenanceTimeout is the maximum time to wait for the end of an API
+	// maintenance, retrying every maintenanceRetryDelay. Requests are not
+	// retried during maintenance if it is 0.
+	maintenanceTimeout    time.Duration
+	maintenanceRetryDelay time.Duration
+	// pendingTimeout is the maximum time to wait for changes the API
+	// responded to with status pending, polling every pendingPollDelay.
+	// Pending changes are not awaited if it is 0.
+	pendingTimeout   time.Duration
+	pendingPollDelay time.Duration
+
+	// zoneConfigs caches zone configs by zone ID for the lifetime of the
+	// client, until the zone is changed.
+	zoneConfigsMu sync.Mutex
+	zoneConfigs   map[string]*zoneConfigsCacheEntry
+	// zoneRecords caches the records of zones by zone ID to serve record
+	// reads, if zoneReadCache is enabled, until the zone is changed.
+	zoneReadCache bool
+	zoneRecordsMu sync.Mutex
+	zoneRecords   map[string]*zoneRecordsCacheEntry
 }
 
 func NewClient(accountId, authToken, baseUrl *string) *Client {
@@ -35,21 +346,275 @@ func NewClient(accountId, authToken, baseUrl *string) *Client {
 	}
 
 	c := Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		HTTPClient: &http.Client{Timeout: defaultRequestTimeout},
 		accountId:  account,
 		authToken:  token,
-		baseURL:    url,
+		serviceURLs: map[string]string{
+			serviceDNS:     url,
+			serviceAccount: defaultServiceURLs[serviceAccount],
+			serviceDomain:  defaultServiceURLs[serviceDomain],
+		},
+
+		errorVerbosity:        errorVerbosityBasic,
+		pageConcurrency:       defaultPageConcurrency,
+		maxBatchSize:          defaultMaxBatchSize,
+		readAfterWriteRetries: defaultReadAfterWriteRetries,
+		readAfterWriteDelay:   defaultReadAfterWriteDelay,
+		rateLimitDelay:        defaultRateLimitDelay,
+		maxRetries:            defaultMaxRetries,
+		conflictRetries:       defaultConflictRetries,
+		txtSizeThreshold:      defaultTXTSizeThreshold,
+		maintenanceRetryDelay: defaultMaintenanceRetryDelay,
+		pendingTimeout:        defaultPendingTimeout,
+		pendingPollDelay:      defaultPendingPollDelay,
+		resolver:              net.DefaultResolver,
+		signer:                newRequestSigner(""),
+		normalizeRecordTypes:  map[string]bool{"TXT": true},
+		normalizationWarning:  true,
+		idnDisplay:            idnDisplayACE,
 	}
 
 	return &c
 }
 
-func (c *Client) doRequestIter(httpMethod string, uri string, request Request, response interface{}, iteration int) ([]byte, error) {
-	if iteration > 8 {
-		return nil, fmt.Errorf("reached max retry count, status of ZoneConfig in response is still blocked")
+// endpoint returns the URL of an API method of the given service, e.g.
+// "recordsFind" of the DNS service.
+func (c *Client) endpoint(service, method string) string {
+	return c.serviceURLs[service] + "/" + method
+}
+
+// waitDeleteGracePeriod waits for the configured grace period before the
+// described object is deleted, giving operators a chance to cancel the
+// operation. It returns the context's error if the context is done first.
+func (c *Client) waitDeleteGracePeriod(ctx context.Context, description string) error {
+	if c.deleteGracePeriod <= 0 {
+		return nil
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("Deleting %s in %s, cancel the operation to keep it", description, c.deleteGracePeriod))
+
+	timer := time.NewTimer(c.deleteGracePeriod)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// lockZone acquires the lock serializing record updates of a zone. It waits
+// until the lock is released, the context is done or the zone lock timeout
+// elapses, so a hung operation cannot block all other updates of the zone.
+// The returned function releases the lock.
+func (c *Client) lockZone(ctx context.Context, zoneId string) (func(), error) {
+	c.zoneLocksMu.Lock()
+	if c.zoneLocks == nil {
+		c.zoneLocks = map[string]chan struct{}{}
+	}
+	lock, ok := c.zoneLocks[zoneId]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		c.zoneLocks[zoneId] = lock
+	}
+	c.zoneLocksMu.Unlock()
+
+	if c.zoneLockTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.zoneLockTimeout)
+		defer cancel()
+	}
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for the lock of zone %s, another operation on the zone may be stuck: %w", zoneId, ctx.Err())
+	}
+}
+
+// newTransport returns a copy of the default HTTP transport with optional
+// overrides for HTTP/2 and keep-alive behavior. Some proxies and gateways
+// misbehave with HTTP/2 or long-lived connections, so both can be disabled.
+// A zero keepAlive keeps Go's default TCP keep-alive interval.
+func newTransport(disableHTTP2, disableKeepAlives bool, keepAlive time.Duration) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if disableHTTP2 {
+		// A non-nil, empty TLSNextProto map disables HTTP/2 negotiation
+		// https://pkg.go.dev/net/http#hdr-HTTP_2
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	transport.DisableKeepAlives = disableKeepAlives
+
+	if keepAlive != 0 {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: keepAlive,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return transport
+}
+
+// backoff waits for the given delay before a request is retried, recording
+// the delay and its reason in the operation events of ctx. It returns the
+// context's error if the context is done before the delay elapsed.
+func (c *Client) backoff(ctx context.Context, reason string, delay time.Duration) error {
+	operationEventsFrom(ctx).addBackoff(reason, delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// addOperationDiagnostics reports the events collected for an operation and
+// the client's pending errors as diagnostics of that operation.
+func (c *Client) addOperationDiagnostics(events *operationEvents, diags *diag.Diagnostics) {
+	if c == nil {
+		return
+	}
+
+	summary := events.backoffs()
+	c.addMaintenanceError(summary, diags)
+	c.addChangeArtifactWarning(diags)
+	addFallbackAuthWarning(summary, diags)
+	addBackoffWarning(summary, diags)
+}
+
+// addMaintenanceError adds an error telling a maintenance apart from other
+// failures, if the operation failed and the API reported a maintenance.
+func (c *Client) addMaintenanceError(summary backoffSummary, diags *diag.Diagnostics) {
+	if !summary.maintenance || !diags.HasError() {
+		return
+	}
+	detail := "The hosting.de API rejected requests because of scheduled maintenance, so the operation could not be completed. " +
+		"Run the operation again after the maintenance"
+	if c.maintenanceTimeout > 0 {
+		detail += fmt.Sprintf(", or increase maintenance_timeout (currently %s) in the provider configuration.", c.maintenanceTimeout)
+	} else {
+		detail += ", or set maintenance_timeout in the provider configuration to wait for the end of the maintenance."
+	}
+	diags.AddError("hosting.de API is in maintenance", detail)
+}
+
+// addChangeArtifactWarning adds a warning if writing the change artifact
+// failed since the last call.
+func (c *Client) addChangeArtifactWarning(diags *diag.Diagnostics) {
+	if err := c.changeArtifact.takeError(); err != nil {
+		diags.AddWarning(
+			"Could not write change artifact",
+			"The applied changes are missing from the change artifact "+c.changeArtifact.path+": "+err.Error(),
+		)
+	}
+}
+
+// addFallbackAuthWarning adds a warning if requests were authenticated with
+// the fallback auth token.
+func addFallbackAuthWarning(summary backoffSummary, diags *diag.Diagnostics) {
+	if !summary.fallbackAuth {
+		return
+	}
+	diags.AddWarning(
+		"hosting.de API auth token rejected",
+		"The hosting.de API rejected the auth_token with HTTP 401, so requests were authenticated with the fallback_auth_token. "+
+			"Check whether the auth_token was revoked or expired, and replace it to complete the token rotation.",
+	)
+}
+
+// addBackoffWarning adds a single warning summarizing the retried requests,
+// so users know why an operation was slow.
+func addBackoffWarning(summary backoffSummary, diags *diag.Diagnostics) {
+	if len(summary.reasons) == 0 {
+		return
+	}
+	var reasons []string
+	for reason, count := range summary.reasons {
+		reasons = append(reasons, fmt.Sprintf("%s (%d times)", reason, count))
+	}
+	sort.Strings(reasons)
+	diags.AddWarning(
+		"hosting.de API requests delayed",
+		fmt.Sprintf("Waited %s in total before retrying requests: %s.", summary.total, strings.Join(reasons, ", ")),
+	)
+}
+
+// retryAfter returns the delay before retrying a request rejected by the
+// rate limit, preferring the Retry-After header sent by the API.
+func (c *Client) retryAfter(header http.Header, iteration int) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(time.Until(date), 0)
+		}
+	}
+	return min(c.rateLimitDelay<<iteration, maxRateLimitDelay)
+}
+
+// requestAuthToken returns the auth token to send requests with: the fallback
+// auth token once the API rejected the primary one, the primary otherwise.
+func (c *Client) requestAuthToken() string {
+	c.authTokenMu.Lock()
+	defer c.authTokenMu.Unlock()
+	if c.authTokenRejected && c.fallbackAuthToken != "" {
+		return c.fallbackAuthToken
+	}
+	return c.authToken
+}
+
+// rejectAuthToken records that the API rejected the primary auth token, so
+// later requests are sent with the fallback auth token.
+func (c *Client) rejectAuthToken(ctx context.Context) {
+	c.authTokenMu.Lock()
+	defer c.authTokenMu.Unlock()
+	if !c.authTokenRejected {
+		tflog.Warn(ctx, "hosting.de API rejected the primary auth token, using the fallback auth token")
+	}
+	c.authTokenRejected = true
+}
+
+// isReadRequest reports whether the request to uri only reads data, so it can
+// be retried safely after a server error.
+func isReadRequest(uri string) bool {
+	return strings.HasSuffix(uri, "Find")
+}
+
+// retryStatus returns the backoff reason if a response with the given status
+// may be retried. Rate limited requests were not processed by the API and are
+// always retried. Transient server errors are only retried for reads, since
+// a change may have been applied before the error.
+func retryStatus(uri string, statusCode int) (string, bool) {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return "rate limited (HTTP 429)", true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if isReadRequest(uri) {
+			return fmt.Sprintf("server error (HTTP %d)", statusCode), true
+		}
+	}
+	return "", false
+}
+
+// doRequestIter sends a request, retrying it if it is rate limited, failed
+// with a transient server error or blocked by a running zone operation.
+// iteration counts the retries limited by maxRetries, blocked the retries
+// limited by maxBlockedRetries.
+func (c *Client) doRequestIter(ctx context.Context, httpMethod string, uri string, request Request, response interface{}, iteration, blocked int) ([]byte, error) {
+	if blocked > maxBlockedRetries {
+		return nil, fmt.Errorf("reached max retry count after %d attempts, the request is still blocked", blocked)
 	}
 	if request.getAuthToken() == "" {
-		request.setAuthToken(c.authToken)
+		request.setAuthToken(c.requestAuthToken())
 	}
 	if request.getAccountId() == "" {
 		request.setAccountId(c.accountId)
@@ -60,14 +625,18 @@ func (c *Client) doRequestIter(httpMethod string, uri string, request Request, r
 		return nil, err
 	}
 
-	req, err := http.NewRequest(httpMethod, uri, bytes.NewReader(rawBody))
+	req, err := http.NewRequestWithContext(ctx, httpMethod, uri, bytes.NewReader(rawBody))
 	if err != nil {
 		return nil, err
 	}
 
+	if err := c.signer.signRequest(req, rawBody); err != nil {
+		return nil, fmt.Errorf("error signing request: %v", err)
+	}
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error querying API: %v", err)
+		return nil, fmt.Errorf("error querying API: %w", err)
 	}
 
 	defer resp.Body.Close()
@@ -77,6 +646,38 @@ func (c *Client) doRequestIter(httpMethod string, uri string, request Request, r
 		return nil, errors.New(toErrorWithNewlines(uri, body))
 	}
 
+	if reason, ok := retryStatus(uri, resp.StatusCode); ok {
+		if iteration < c.maxRetries {
+			if err := c.backoff(ctx, reason, c.retryAfter(resp.Header, iteration)); err != nil {
+				return nil, err
+			}
+			return c.doRequestIter(ctx, httpMethod, uri, request, response, iteration+1, blocked)
+		}
+		return nil, fmt.Errorf("giving up after %d attempts: %w", iteration+1, c.statusError(uri, resp.StatusCode, rawBody, body))
+	}
+
+	// Retry requests rejected with the primary auth token with the fallback.
+	fallback := c.fallbackAuthToken != "" && request.getAuthToken() == c.fallbackAuthToken
+	if resp.StatusCode == http.StatusUnauthorized && c.fallbackAuthToken != "" && !fallback && request.getAuthToken() == c.authToken {
+		c.rejectAuthToken(ctx)
+		request.setAuthToken(c.fallbackAuthToken)
+		return c.doRequestIter(ctx, httpMethod, uri, request, response, iteration, blocked)
+	}
+	if resp.StatusCode == http.StatusUnauthorized && fallback {
+		return nil, fmt.Errorf("primary and fallback auth tokens were rejected: %w", c.statusError(uri, resp.StatusCode, rawBody, body))
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, c.statusError(uri, resp.StatusCode, rawBody, body)
+	}
+
+	if fallback {
+		tflog.Debug(ctx, "hosting.de API request authenticated with the fallback auth token")
+		operationEventsFrom(ctx).setFallbackAuth()
+	} else if c.fallbackAuthToken != "" {
+		tflog.Debug(ctx, "hosting.de API request authenticated with the primary auth token")
+	}
+
 	err = json.Unmarshal(body, response)
 	if err != nil {
 		return nil, fmt.Errorf("%v: %s", err, toErrorWithNewlines(uri, body))
@@ -84,6 +685,8 @@ func (c *Client) doRequestIter(httpMethod string, uri string, request Request, r
 
 	// Sometimes the API returns an undocumented blocked status
 	var br *BaseResponse
+	// empty is set if a find request for a single object returned no data.
+	var empty bool
 	switch r := response.(type) {
 	default:
 		return nil, fmt.Errorf("error: invalid response type: %T", r)
@@ -94,14 +697,13 @@ func (c *Client) doRequestIter(httpMethod string, uri string, request Request, r
 	case *ZoneDeleteResponse:
 		br = &r.BaseResponse
 	case *ZoneConfigsFindResponse:
-		if len(r.Response.Data) == 0 {
-			return nil, fmt.Errorf("%v: uri: %s %s", err, uri, response)
-		}
+		empty = len(r.Response.Data) == 0
 		br = &r.BaseResponse
 	case *ZonesFindResponse:
-		if len(r.Response.Data) == 0 {
-			return nil, fmt.Errorf("%v: uri: %s %s", err, uri, response)
-		}
+		empty = len(r.Response.Data) == 0
+		br = &r.BaseResponse
+	case *RecordTemplatesFindResponse:
+		empty = len(r.Response.Data) == 0
 		br = &r.BaseResponse
 	case *RecordsFindResponse:
 		br = &r.BaseResponse
@@ -109,31 +711,282 @@ func (c *Client) doRequestIter(httpMethod string, uri string, request Request, r
 		br = &r.BaseResponse
 	}
 
-	iteration++
-
 	// The API returns two status strings:
 	// https://www.hosting.de/api/#responses
 	// https://www.hosting.de/api/#the-zoneconfig-object
 	// If the first is an error, we check if it's because the resource is blocked
 	if br.Status == "error" {
-		var blocked bool
+		var isBlocked bool
 		for _, err := range br.Errors {
 			if err.Value == "blocked" {
-				blocked = true
+				isBlocked = true
 			}
 		}
-		if blocked {
-			fmt.Printf("Request blocked, triggering new request: %d\n", iteration)
-			time.Sleep(1 * time.Second)
-			return c.doRequestIter(httpMethod, uri, request, response, iteration)
+		if isBlocked {
+			if err := c.backoff(ctx, "blocked by a running zone operation", time.Second); err != nil {
+				return nil, err
+			}
+			return c.doRequestIter(ctx, httpMethod, uri, request, response, iteration, blocked+1)
+		}
+		for _, apiError := range br.Errors {
+			if isMaintenanceAPIError(apiError) {
+				return nil, fmt.Errorf("%w: %s", ErrMaintenance, toErrorWithNewlines(uri, body))
+			}
 		}
 	}
 
+	// An empty result is only reported as not found once the response is
+	// known not to be blocked or in maintenance.
+	if empty {
+		return nil, notFoundError(uri, body)
+	}
+
 	return body, err
 }
 
-func (c *Client) doRequest(httpMethod string, uri string, request Request, response interface{}) ([]byte, error) {
-	return c.doRequestIter(httpMethod, uri, request, response, 0)
+// doRequest sends a request to the API. Requests rejected because the API is
+// in maintenance are retried until the maintenance timeout elapses. The
+// request and all retries are aborted once ctx is done.
+func (c *Client) doRequest(ctx context.Context, httpMethod string, uri string, request Request, response interface{}) ([]byte, error) {
+	deadline := time.Now().Add(c.maintenanceTimeout)
+	for {
+		body, err := c.doRequestIter(ctx, httpMethod, uri, request, response, 0, 0)
+		if !errors.Is(err, ErrMaintenance) {
+			return body, err
+		}
+		operationEventsFrom(ctx).setMaintenance()
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return body, err
+		}
+		if err := c.backoff(ctx, "API in maintenance", min(c.maintenanceRetryDelay, remaining)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// statusError builds the error for a non-successful HTTP response. Depending
+// on the configured error verbosity, the request body is included with the
+// auth token redacted, so users can see exactly what the provider sent.
+func (c *Client) statusError(uri string, statusCode int, requestBody, responseBody []byte) error {
+	msg := toErrorWithNewlines(uri, responseBody)
+
+	switch c.errorVerbosity {
+	case errorVerbosityDetailed:
+		if statusCode < http.StatusInternalServerError {
+			msg += "\nRequest body was: " + redactRequestBody(requestBody)
+		}
+	case errorVerbosityRaw:
+		msg += "\nRequest body was: " + redactRequestBody(requestBody)
+	}
+
+	if statusCode == http.StatusNotFound {
+		return fmt.Errorf("unexpected HTTP status %d: %w: %s", statusCode, ErrNotFound, msg)
+	}
+	if statusCode == http.StatusServiceUnavailable && isMaintenanceResponse(responseBody) {
+		return fmt.Errorf("unexpected HTTP status %d: %w: %s", statusCode, ErrMaintenance, msg)
+	}
+	if statusCode == http.StatusConflict {
+		return fmt.Errorf("unexpected HTTP status %d: %w: %s", statusCode, ErrConflict, msg)
+	}
+	return fmt.Errorf("unexpected HTTP status %d: %s", statusCode, msg)
+}
+
+// notFoundError returns ErrNotFound wrapped with the request URI and the raw
+// response.
+func notFoundError(uri string, rawResp []byte) error {
+	return fmt.Errorf("%w: %s", ErrNotFound, toErrorWithNewlines(uri, rawResp))
+}
+
+// responseError builds the error for an API response with an unsuccessful
+// status. If the API reports that the requested object does not exist, the
+// error wraps ErrNotFound, and if the zone was modified concurrently, it
+// wraps ErrConflict. The error is a *rejectedError, as the API did not apply
+// the request.
+func responseError(uri string, rawResp []byte, response BaseResponse) error {
+	for _, apiError := range response.Errors {
+		if isNotFoundAPIError(apiError) {
+			return &rejectedError{err: notFoundError(uri, rawResp)}
+		}
+		if isRecordLimitAPIError(apiError) {
+			return &rejectedError{err: &recordLimitError{
+				limit: apiErrorLimit(apiError),
+				err:   errors.New(toErrorWithNewlines(uri, rawResp)),
+			}}
+		}
+		if isConflictAPIError(apiError) {
+			return &rejectedError{err: fmt.Errorf("%w: %s", ErrConflict, toErrorWithNewlines(uri, rawResp))}
+		}
+	}
+	msg := toErrorWithNewlines(uri, rawResp)
+	if err := checkResponseErrors(response); err != nil {
+		msg = err.Error() + "\n" + msg
+	}
+	return &rejectedError{err: errors.New(msg)}
+}
+
+// rejectedError is returned if the API rejected a request with an error
+// status, e.g. because of a validation error, so none of its changes were
+// applied.
+type rejectedError struct {
+	err error
+}
+
+func (e *rejectedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *rejectedError) Unwrap() error {
+	return e.err
+}
+
+// checkResponseErrors returns an error with the messages of the API errors of
+// a response if its status is neither success nor pending, or if it reports
+// errors. It returns nil for successful responses.
+func checkResponseErrors(response BaseResponse) error {
+	if (response.Status == "success" || response.Status == "pending") && len(response.Errors) == 0 {
+		return nil
+	}
+	if len(response.Errors) == 0 {
+		return fmt.Errorf("the hosting.de API responded with status %q", response.Status)
+	}
+
+	messages := make([]string, 0, len(response.Errors))
+	for _, apiError := range response.Errors {
+		messages = append(messages, formatAPIError(apiError))
+	}
+	return fmt.Errorf("the hosting.de API rejected the request: %s", strings.Join(messages, "; "))
+}
+
+// formatAPIError formats an API error as its text followed by its code and
+// details, e.g. "Invalid record content (code 10205, content: 192.0.2.300)".
+func formatAPIError(apiError APIError) string {
+	text := apiError.Text
+	if text == "" {
+		text = apiError.Value
+	}
+	if text == "" {
+		text = "unknown error"
+	}
+
+	var fields []string
+	if apiError.Code != 0 {
+		fields = append(fields, fmt.Sprintf("code %d", apiError.Code))
+	}
+	for _, detail := range apiError.Details {
+		fields = append(fields, detail.Key+": "+detail.Value)
+	}
+	if len(fields) == 0 {
+		return text
+	}
+	return text + " (" + strings.Join(fields, ", ") + ")"
+}
+
+// recordLimitError is returned if an update would exceed the maximum number of
+// records of a zone. limit is 0 if the API does not report the limit.
+type recordLimitError struct {
+	limit int
+	err   error
+}
+
+func (e *recordLimitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *recordLimitError) Unwrap() error {
+	return e.err
+}
+
+// isMaintenanceAPIError reports whether an API error is about the API being
+// in maintenance.
+func isMaintenanceAPIError(apiError APIError) bool {
+	switch apiError.Value {
+	case "maintenance", "apiMaintenance", "inMaintenance":
+		return true
+	}
+	return strings.Contains(strings.ToLower(apiError.Text), "maintenance")
+}
+
+// isMaintenanceResponse reports whether a response body reports that the API
+// is in maintenance. A bare HTTP 503, e.g. from an overloaded gateway, does not
+// tell whether a change was applied, so only the API's maintenance error
+// counts.
+func isMaintenanceResponse(body []byte) bool {
+	var response BaseResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return false
+	}
+	for _, apiError := range response.Errors {
+		if isMaintenanceAPIError(apiError) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRecordLimitAPIError reports whether an API error is about a zone having
+// reached its maximum number of records.
+func isRecordLimitAPIError(apiError APIError) bool {
+	switch apiError.Value {
+	case "recordLimitExceeded", "record_limit_exceeded", "tooManyRecords":
+		return true
+	}
+	text := strings.ToLower(apiError.Text)
+	return strings.Contains(text, "record limit") || strings.Contains(text, "too many records") ||
+		strings.Contains(text, "maximum number of records")
+}
+
+// isConflictAPIError reports whether an API error is about a change based on
+// an outdated state of the zone, e.g. a record modified or deleted since it
+// was read. Only the error's value is checked: validation errors like "CNAME
+// record conflicts with existing records" never succeed when retried.
+func isConflictAPIError(apiError APIError) bool {
+	switch apiError.Value {
+	case "conflict", "concurrentModification", "objectModified":
+		return true
+	}
+	return false
+}
+
+// apiErrorLimit returns the limit reported in the details of an API error, or
+// 0 if there is none.
+func apiErrorLimit(apiError APIError) int {
+	for _, detail := range apiError.Details {
+		switch detail.Key {
+		case "limit", "maximum", "maxRecords":
+			if limit, err := strconv.Atoi(detail.Value); err == nil {
+				return limit
+			}
+		}
+	}
+	return 0
+}
+
+// isNotFoundAPIError reports whether an API error is about a missing object.
+// Only the error's value is checked: texts like "referenced template does not
+// exist" are about other objects than the requested one.
+func isNotFoundAPIError(apiError APIError) bool {
+	return apiError.Value == "notFound" || apiError.Value == "not_found"
+}
+
+// redactRequestBody returns the marshaled request with the auth token replaced.
+func redactRequestBody(rawBody []byte) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(rawBody, &fields); err != nil {
+		return "<unable to redact request body>"
+	}
+
+	if _, ok := fields["authToken"]; ok {
+		fields["authToken"] = json.RawMessage(`"REDACTED"`)
+	}
+
+	redacted, err := json.Marshal(fields)
+	if err != nil {
+		return "<unable to redact request body>"
+	}
+
+	return string(redacted)
 }
 
 func toErrorWithNewlines(uri string, rawBody []byte) string {
@@ -0,0 +1,629 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// newTestClient returns a client for a test server that dispatches requests
+// by API method, e.g. "recordsFind", and encodes the handler result as JSON.
+func newTestClient(t *testing.T, handlers map[string]func(body []byte) any) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		handler, ok := handlers[method]
+		if !ok {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := json.NewEncoder(w).Encode(handler(body)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	return NewClient(nil, &token, &server.URL)
+}
+
+func TestNewTransport(t *testing.T) {
+	transport := newTransport(false, false, 0)
+	if transport.TLSNextProto != nil {
+		t.Errorf("expected default TLSNextProto, got %v", transport.TLSNextProto)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Errorf("expected HTTP/2 to be attempted by default")
+	}
+	if transport.DisableKeepAlives {
+		t.Errorf("expected keep-alives to be enabled by default")
+	}
+
+	transport = newTransport(true, true, 15*time.Second)
+	if transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Errorf("expected empty, non-nil TLSNextProto to disable HTTP/2, got %v", transport.TLSNextProto)
+	}
+	if transport.ForceAttemptHTTP2 {
+		t.Errorf("expected HTTP/2 not to be attempted")
+	}
+	if !transport.DisableKeepAlives {
+		t.Errorf("expected keep-alives to be disabled")
+	}
+	if transport.DialContext == nil {
+		t.Errorf("expected custom dialer for keep-alive interval")
+	}
+}
+
+func TestClientErrorVerbosity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":"error","errors":[{"code":10205,"text":"Invalid content"}]}`))
+	}))
+	defer server.Close()
+
+	token := "secret-token"
+	request := RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: "zone-id",
+		RecordsToAdd: []DNSRecord{{Name: "test.example.test", Type: "TXT", Content: "hello"}},
+	}
+
+	for verbosity, wantBody := range map[string]bool{
+		errorVerbosityBasic:    false,
+		errorVerbosityDetailed: true,
+		errorVerbosityRaw:      true,
+	} {
+		client := NewClient(nil, &token, &server.URL)
+		client.errorVerbosity = verbosity
+
+		_, err := client.updateRecords(context.Background(), request)
+		if err == nil {
+			t.Fatalf("%s: expected error for HTTP 400", verbosity)
+		}
+		if strings.Contains(err.Error(), token) {
+			t.Errorf("%s: auth token leaked into error: %s", verbosity, err)
+		}
+		if got := strings.Contains(err.Error(), `"authToken":"REDACTED"`); got != wantBody {
+			t.Errorf("%s: expected redacted body in error to be %t, got: %s", verbosity, wantBody, err)
+		}
+		if got := strings.Contains(err.Error(), `"content":"hello"`); got != wantBody {
+			t.Errorf("%s: expected record content in error to be %t, got: %s", verbosity, wantBody, err)
+		}
+		// Reset the token, as the client sets it on the shared request.
+		request.setAuthToken("")
+	}
+}
+
+func TestLockZone(t *testing.T) {
+	client := NewClient(nil, nil, nil)
+	client.zoneLockTimeout = 10 * time.Millisecond
+	ctx := context.Background()
+
+	unlock, err := client.lockZone(ctx, "zone-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Other zones are not blocked by the held lock.
+	unlockOther, err := client.lockZone(ctx, "other-zone-id")
+	if err != nil {
+		t.Fatalf("expected lock of another zone to be acquired, got %v", err)
+	}
+	unlockOther()
+
+	if _, err := client.lockZone(ctx, "zone-id"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected waiting for the held lock to time out, got %v", err)
+	}
+
+	unlock()
+	unlock, err = client.lockZone(ctx, "zone-id")
+	if err != nil {
+		t.Fatalf("expected released lock to be acquired, got %v", err)
+	}
+	unlock()
+}
+
+func TestErrNotFound(t *testing.T) {
+	client := NewClient(nil, nil, nil)
+	if err := client.statusError("uri", http.StatusNotFound, nil, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected HTTP 404 to be classified as not found, got %v", err)
+	}
+	if err := client.statusError("uri", http.StatusInternalServerError, nil, nil); errors.Is(err, ErrNotFound) {
+		t.Errorf("expected HTTP 500 not to be classified as not found, got %v", err)
+	}
+
+	for _, apiError := range []APIError{
+		{Value: "notFound"},
+		{Value: "not_found"},
+	} {
+		if err := responseError("uri", nil, BaseResponse{Errors: []APIError{apiError}}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected %+v to be classified as not found, got %v", apiError, err)
+		}
+	}
+	// Texts may refer to other objects than the requested one.
+	for _, apiError := range []APIError{
+		{Text: "Invalid record content"},
+		{Text: "Referenced template does not exist"},
+		{Text: "Target zone not found"},
+	} {
+		if err := responseError("uri", nil, BaseResponse{Errors: []APIError{apiError}}); errors.Is(err, ErrNotFound) {
+			t.Errorf("expected %+v not to be classified as not found, got %v", apiError, err)
+		}
+	}
+	if !errors.Is(errRecordNotFound, ErrNotFound) {
+		t.Errorf("expected errRecordNotFound to wrap ErrNotFound")
+	}
+
+	// Empty data on lookups by ID.
+	client = newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			return RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			return ZoneConfigsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+		"zonesFind": func(_ []byte) any {
+			return ZonesFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+		"recordsUpdate": func(_ []byte) any {
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{
+				Status: "error",
+				Errors: []APIError{{Code: 1, Value: "notFound", Text: "Zone not found"}},
+			}}
+		},
+	})
+	if _, err := client.getRecord(context.Background(), "record-id"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected missing record to be classified as not found, got %v", err)
+	}
+	if _, err := client.getZoneConfig(context.Background(), "zone-id"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected missing zone config to be classified as not found, got %v", err)
+	}
+	if _, err := client.getZone(context.Background(), "zone-id", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected missing zone to be classified as not found, got %v", err)
+	}
+	if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}, ZoneConfigId: "zone-id"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected update of missing zone to be classified as not found, got %v", err)
+	}
+}
+
+func TestErrConflict(t *testing.T) {
+	client := NewClient(nil, nil, nil)
+	if err := client.statusError("uri", http.StatusConflict, nil, nil); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected HTTP 409 to be classified as a conflict, got %v", err)
+	}
+
+	for _, apiError := range []APIError{
+		{Value: "conflict"},
+		{Value: "concurrentModification"},
+		{Value: "objectModified"},
+	} {
+		if err := responseError("uri", nil, BaseResponse{Errors: []APIError{apiError}}); !errors.Is(err, ErrConflict) {
+			t.Errorf("expected %+v to be classified as a conflict, got %v", apiError, err)
+		}
+	}
+	// Validation errors are not retried.
+	if err := responseError("uri", nil, BaseResponse{Errors: []APIError{{Text: "CNAME record conflicts with existing records"}}}); errors.Is(err, ErrConflict) {
+		t.Errorf("expected validation error not to be classified as a conflict, got %v", err)
+	}
+}
+
+func TestCheckResponseErrors(t *testing.T) {
+	for _, status := range []string{"success", "pending"} {
+		if err := checkResponseErrors(BaseResponse{Status: status}); err != nil {
+			t.Errorf("expected status %s without errors to succeed, got %v", status, err)
+		}
+	}
+
+	for _, test := range []struct {
+		response BaseResponse
+		expected string
+	}{
+		{
+			BaseResponse{Status: "error"},
+			`the hosting.de API responded with status "error"`,
+		},
+		{
+			BaseResponse{Status: "error", Errors: []APIError{{
+				Code:    10205,
+				Text:    "Invalid record content",
+				Details: []APIErrorDetail{{Key: "content", Value: "192.0.2.300"}, {Key: "type", Value: "A"}},
+			}}},
+			"the hosting.de API rejected the request: Invalid record content (code 10205, content: 192.0.2.300, type: A)",
+		},
+		{
+			BaseResponse{Status: "success", Errors: []APIError{{Value: "invalidTtl"}, {Text: "Invalid priority"}}},
+			"the hosting.de API rejected the request: invalidTtl; Invalid priority",
+		},
+	} {
+		err := checkResponseErrors(test.response)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("expected %q, got %v", test.expected, err)
+		}
+	}
+
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(_ []byte) any {
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{
+				Status: "error",
+				Errors: []APIError{{Code: 10205, Text: "Invalid record content"}},
+			}}
+		},
+	})
+	_, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}, ZoneConfigId: "zone-id"})
+	if err == nil || !strings.HasPrefix(err.Error(), "the hosting.de API rejected the request: Invalid record content (code 10205)\n") {
+		t.Errorf("expected the API error message followed by the raw response, got %v", err)
+	}
+}
+
+func TestBackoffWarning(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// The first two attempts are rate limited.
+		requests++
+		if requests <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		findResponse := ZoneConfigsFindResponse{}
+		findResponse.Status = "success"
+		findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test"}}
+		if err := json.NewEncoder(w).Encode(findResponse); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	client := NewClient(nil, &token, &server.URL)
+	client.rateLimitDelay = 10 * time.Millisecond
+
+	ctx, events := withOperationEvents(context.Background())
+	if _, err := client.getZoneConfig(ctx, "zone-id"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("expected rate limited requests to be retried, got %d requests", requests)
+	}
+
+	var diags diag.Diagnostics
+	client.addOperationDiagnostics(events, &diags)
+	if len(diags.Warnings()) != 1 {
+		t.Fatalf("expected a single backoff warning, got %v", diags)
+	}
+	if detail := diags.Warnings()[0].Detail(); !strings.Contains(detail, "30ms") || !strings.Contains(detail, "rate limited (HTTP 429) (2 times)") {
+		t.Errorf("expected summary of both backoffs, got %q", detail)
+	}
+
+	// Backoffs are reported for the operation whose requests were retried
+	// only.
+	_, otherEvents := withOperationEvents(context.Background())
+	diags = nil
+	client.addOperationDiagnostics(otherEvents, &diags)
+	if len(diags) != 0 {
+		t.Errorf("expected no warning for another operation, got %v", diags)
+	}
+
+	if delay := client.retryAfter(http.Header{"Retry-After": []string{"5"}}, 0); delay != 5*time.Second {
+		t.Errorf("expected Retry-After header to be used, got %s", delay)
+	}
+}
+
+func TestRetryServerErrors(t *testing.T) {
+	requests, failures := 0, 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		if requests <= failures {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"status":"success","response":{"data":[{"id":"zone-id"}]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	client := NewClient(nil, &token, &server.URL)
+	client.rateLimitDelay = time.Millisecond
+
+	// Reads are retried after transient server errors.
+	if _, err := client.getZoneConfig(context.Background(), "zone-id"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("expected read to be retried, got %d requests", requests)
+	}
+
+	// Changes are not retried, since they may have been applied.
+	requests = 0
+	if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}, ZoneConfigId: "zone-id"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if requests != 1 {
+		t.Errorf("expected update not to be retried, got %d requests", requests)
+	}
+
+	// The error reports the attempts once the retries are exhausted.
+	requests, failures = 0, 100
+	client.zoneConfigs = nil
+	client.maxRetries = 1
+	_, err := client.getZoneConfig(context.Background(), "zone-id")
+	if err == nil || !strings.HasPrefix(err.Error(), "giving up after 2 attempts: unexpected HTTP status 502") {
+		t.Errorf("expected error with the number of attempts, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+
+	// More retries than those of blocked requests are allowed.
+	requests, failures = 0, 10
+	client.zoneConfigs = nil
+	client.maxRetries = failures
+	client.rateLimitDelay = time.Microsecond
+	if _, err := client.getZoneConfig(context.Background(), "zone-id"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != failures+1 {
+		t.Errorf("expected %d requests, got %d", failures+1, requests)
+	}
+}
+
+func TestAuthTokenFallback(t *testing.T) {
+	valid := map[string]bool{}
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request BaseRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatal(err)
+		}
+		tokens = append(tokens, request.AuthToken)
+		if !valid[request.AuthToken] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status":"success","response":{"data":[{"id":"zone-id"}]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	newClient := func() *Client {
+		token := "primary"
+		client := NewClient(nil, &token, &server.URL)
+		client.fallbackAuthToken = "fallback"
+		return client
+	}
+	zoneConfig := func(client *Client) (diag.Diagnostics, error) {
+		tokens = nil
+		client.zoneConfigs = nil
+		var diags diag.Diagnostics
+		ctx, events := withOperationEvents(context.Background())
+		_, err := client.getZoneConfig(ctx, "zone-id")
+		client.addOperationDiagnostics(events, &diags)
+		return diags, err
+	}
+
+	// The primary token is used while it is accepted, without a warning.
+	valid = map[string]bool{"primary": true, "fallback": true}
+	client := newClient()
+	diags, err := zoneConfig(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(tokens, ",") != "primary" || diags.WarningsCount() != 0 {
+		t.Errorf("expected a single request with the primary token, got %v and %v", tokens, diags)
+	}
+
+	// A request rejected with the primary token is retried with the fallback,
+	// which is used directly for later requests.
+	valid = map[string]bool{"fallback": true}
+	diags, err = zoneConfig(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(tokens, ",") != "primary,fallback" {
+		t.Errorf("expected the fallback token after the primary token, got %v", tokens)
+	}
+	if diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "hosting.de API auth token rejected" {
+		t.Errorf("expected a warning about the rejected primary token, got %v", diags)
+	}
+	diags, err = zoneConfig(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(tokens, ",") != "fallback" || diags.WarningsCount() != 1 {
+		t.Errorf("expected the fallback token to be used directly with a warning, got %v and %v", tokens, diags)
+	}
+
+	// An error reports that both tokens were rejected.
+	valid = map[string]bool{}
+	_, err = zoneConfig(newClient())
+	if err == nil || !strings.HasPrefix(err.Error(), "primary and fallback auth tokens were rejected: unexpected HTTP status 401") {
+		t.Errorf("expected an error for both rejected tokens, got %v", err)
+	}
+	if strings.Join(tokens, ",") != "primary,fallback" {
+		t.Errorf("expected both tokens to be tried, got %v", tokens)
+	}
+}
+
+func TestRequestContext(t *testing.T) {
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/recordsFind" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		<-stalled
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(stalled) })
+
+	token := "test-token"
+	client := NewClient(nil, &token, &server.URL)
+
+	// A stalled request is aborted once the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.getZoneConfig(ctx, "zone-id"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected request to be aborted with the context, got %v", err)
+	}
+
+	// Waiting before a retry is aborted as well.
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.getRecord(ctx, "record-id"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected retry to be aborted with the context, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= defaultRateLimitDelay {
+		t.Errorf("expected retry delay to be aborted, waited %s", elapsed)
+	}
+}
+
+func TestClientServiceEndpoints(t *testing.T) {
+	client := NewClient(nil, nil, nil)
+	for _, service := range []string{serviceAccount, serviceDomain} {
+		if client.serviceURLs[service] != defaultServiceURLs[service] {
+			t.Errorf("expected %s service to default to %s, got %s", service, defaultServiceURLs[service], client.serviceURLs[service])
+		}
+	}
+
+	requests := map[string]string{}
+	newServer := func(service string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests[service] = r.URL.Path
+			w.Write([]byte(`{"status":"success"}`))
+		}))
+		t.Cleanup(server.Close)
+		return server.URL + "/api/" + service + "/v1/json"
+	}
+
+	token := "test-token"
+	dnsURL := newServer(serviceDNS)
+	client = NewClient(nil, &token, &dnsURL)
+	client.serviceURLs[serviceAccount] = newServer(serviceAccount)
+	client.serviceURLs[serviceDomain] = newServer(serviceDomain)
+
+	for service, method := range map[string]string{
+		serviceDNS:     "zoneDelete",
+		serviceAccount: "accountsFind",
+		serviceDomain:  "domainsFind",
+	} {
+		if _, err := client.doRequest(context.Background(), http.MethodPost, client.endpoint(service, method), &BaseRequest{}, &ZoneDeleteResponse{}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", service, err)
+		}
+		if expected := "/api/" + service + "/v1/json/" + method; requests[service] != expected {
+			t.Errorf("expected %s service to request %s, got %q", service, expected, requests[service])
+		}
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	requests, maintenanceRequests := 0, 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// The first attempts fall into a maintenance, reported by an API error
+		// with HTTP 503 and with a successful status in turns.
+		requests++
+		if requests <= maintenanceRequests {
+			if requests%2 == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "error"
+			findResponse.Errors = []APIError{{Code: 10000, Value: "maintenance", Text: "The system is in maintenance."}}
+			if err := json.NewEncoder(w).Encode(findResponse); err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+
+		findResponse := ZoneConfigsFindResponse{}
+		findResponse.Status = "success"
+		findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test"}}
+		if err := json.NewEncoder(w).Encode(findResponse); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	client := NewClient(nil, &token, &server.URL)
+	// HTTP 503 is not retried as a transient server error, so only the
+	// maintenance handling is tested.
+	client.maxRetries = 0
+	client.maintenanceRetryDelay = 10 * time.Millisecond
+
+	// Without a maintenance timeout, the request fails with a distinct error.
+	ctx, events := withOperationEvents(context.Background())
+	_, err := client.getZoneConfig(ctx, "zone-id")
+	if !errors.Is(err, ErrMaintenance) {
+		t.Fatalf("expected maintenance error, got %v", err)
+	}
+	diags := diag.Diagnostics{}
+	diags.AddError("Error Reading hosting.de DNS zone", err.Error())
+	client.addOperationDiagnostics(events, &diags)
+	if len(diags.Errors()) != 2 || diags.Errors()[1].Summary() != "hosting.de API is in maintenance" {
+		t.Errorf("expected maintenance diagnostic, got %v", diags)
+	}
+
+	// With a maintenance timeout, the request is retried until it succeeds.
+	client.maintenanceTimeout = time.Second
+	ctx, events = withOperationEvents(context.Background())
+	if _, err := client.getZoneConfig(ctx, "zone-id"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("expected request to be retried after the maintenance, got %d requests", requests)
+	}
+	diags = nil
+	client.addOperationDiagnostics(events, &diags)
+	if diags.HasError() || len(diags.Warnings()) != 1 || !strings.Contains(diags.Warnings()[0].Detail(), "API in maintenance (1 times)") {
+		t.Errorf("expected only a backoff warning for the successful request, got %v", diags)
+	}
+
+	// The retries stop once the timeout elapses.
+	requests, maintenanceRequests = 0, 100
+	client.zoneConfigs = nil
+	client.maintenanceTimeout = 25 * time.Millisecond
+	if _, err := client.getZoneConfig(context.Background(), "zone-id"); !errors.Is(err, ErrMaintenance) {
+		t.Errorf("expected maintenance error after the timeout, got %v", err)
+	}
+}
+
+func TestServiceUnavailableWriteNotResent(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("Service Unavailable"))
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	client := NewClient(nil, &token, &server.URL)
+	client.maintenanceTimeout = time.Second
+	client.maintenanceRetryDelay = 10 * time.Millisecond
+
+	// A bare HTTP 503 does not tell whether the records were changed, so the
+	// update is neither retried as a server error nor during a maintenance.
+	_, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}, ZoneConfigId: "zone-id"})
+	if err == nil || errors.Is(err, ErrMaintenance) {
+		t.Errorf("expected a server error other than maintenance, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected the update to be sent once, got %d requests", requests)
+	}
+}
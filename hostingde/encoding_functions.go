@@ -0,0 +1,111 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &toHexFunction{}
+	_ function.Function = &toBase64Function{}
+)
+
+// NewToHexFunction is a helper function to simplify the provider implementation.
+func NewToHexFunction() function.Function {
+	return &toHexFunction{}
+}
+
+// toHexFunction converts base64 encoded data to hex, as used in the content
+// of TLSA and SSHFP records.
+type toHexFunction struct{}
+
+// Metadata returns the function name.
+func (f *toHexFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "to_hex"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *toHexFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert base64 encoded data to hex",
+		MarkdownDescription: "Converts standard base64 encoded data, e.g. a certificate hash or SSH key fingerprint, " +
+			"to the lower case hex encoding expected in the content of `TLSA` and `SSHFP` records. " +
+			"Whitespace in the input is ignored. Fails if the input is not valid base64.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base64",
+				Description: "Base64 encoded data.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the data.
+func (f *toHexFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	data, err := decodeBase64(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, hex.EncodeToString(data)))
+}
+
+// NewToBase64Function is a helper function to simplify the provider implementation.
+func NewToBase64Function() function.Function {
+	return &toBase64Function{}
+}
+
+// toBase64Function converts hex encoded data to base64, as used in the
+// content of OPENPGPKEY and CERT records.
+type toBase64Function struct{}
+
+// Metadata returns the function name.
+func (f *toBase64Function) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "to_base64"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *toBase64Function) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert hex encoded data to base64",
+		MarkdownDescription: "Converts hex encoded data to the standard base64 encoding expected in the content of " +
+			"`OPENPGPKEY` and `CERT` records. Whitespace in the input is ignored. Fails if the input is not valid hex.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "hex",
+				Description: "Hex encoded data.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the data.
+func (f *toBase64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	data, err := hex.DecodeString(strings.Join(strings.Fields(value), ""))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "value must be hex encoded: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, base64.StdEncoding.EncodeToString(data)))
+}
@@ -0,0 +1,55 @@
+package hostingde
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runStringFunction runs a function taking and returning a single string.
+func runStringFunction(t *testing.T, f function.Function, value string) (string, *function.FuncError) {
+	t.Helper()
+
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(value)}),
+	}, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func TestEncodingFunctions(t *testing.T) {
+	digest := sha256.Sum256([]byte("example certificate"))
+	encoded := base64.StdEncoding.EncodeToString(digest[:])
+
+	hexDigest, err := runStringFunction(t, NewToHexFunction(), encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hexDigest != hex.EncodeToString(digest[:]) {
+		t.Errorf("unexpected hex encoding %s", hexDigest)
+	}
+
+	roundTrip, err := runStringFunction(t, NewToBase64Function(), hexDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roundTrip != encoded {
+		t.Errorf("expected %s after round trip, got %s", encoded, roundTrip)
+	}
+
+	if _, err := runStringFunction(t, NewToHexFunction(), "not base64!"); err == nil {
+		t.Errorf("expected invalid base64 to be rejected")
+	}
+	if _, err := runStringFunction(t, NewToBase64Function(), "xyz"); err == nil {
+		t.Errorf("expected invalid hex to be rejected")
+	}
+}
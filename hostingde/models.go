@@ -90,6 +90,29 @@ type DNSRecord struct {
 	LastChangeDate   string `json:"lastChangeDate,omitempty"`
 }
 
+// recordTypeHasPriority reports whether records of a type have a priority.
+// NULLMX records are MX records with priority 0.
+func recordTypeHasPriority(recordType string) bool {
+	switch recordType {
+	case "MX", "SRV", "NULLMX":
+		return true
+	}
+	return false
+}
+
+// MarshalJSON omits the priority of records whose type has none, so the API
+// does not store a priority of 0 for them.
+func (r DNSRecord) MarshalJSON() ([]byte, error) {
+	type record DNSRecord
+	if recordTypeHasPriority(r.Type) {
+		return json.Marshal(record(r))
+	}
+	return json.Marshal(struct {
+		record
+		Priority *int `json:"priority,omitempty"`
+	}{record: record(r)})
+}
+
 // Zone The Zone Object.
 // https://www.hosting.de/api/?json#the-zone-object
 type Zone struct {
@@ -216,6 +239,42 @@ type RecordsFindResponse struct {
 	} `json:"response"`
 }
 
+// RecordTemplate is a record of a DNS template.
+// https://www.hosting.de/api/?json#the-record-template-object
+type RecordTemplate struct {
+	ID         string `json:"id"`
+	TemplateID string `json:"templateId"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Content    string `json:"content"`
+	TTL        int    `json:"ttl"`
+	Priority   int    `json:"priority"`
+}
+
+// RecordTemplatesFindRequest represents a API recordTemplatesFind request.
+// https://www.hosting.de/api/?json#listing-record-templates
+type RecordTemplatesFindRequest struct {
+	*BaseRequest
+	Filter FilterOrChain `json:"filter"`
+	Limit  int           `json:"limit"`
+	Page   int           `json:"page"`
+	Sort   *Sort         `json:"sort,omitempty"`
+}
+
+// RecordTemplatesFindResponse represents the API response for recordTemplatesFind.
+// https://www.hosting.de/api/?json#listing-record-templates
+type RecordTemplatesFindResponse struct {
+	BaseResponse
+	Response struct {
+		Limit        int              `json:"limit"`
+		Page         int              `json:"page"`
+		TotalEntries int              `json:"totalEntries"`
+		TotalPages   int              `json:"totalPages"`
+		Type         string           `json:"type"`
+		Data         []RecordTemplate `json:"data"`
+	} `json:"response"`
+}
+
 // RecordsUpdateRequest represents a API RecordsUpdate request.
 // https://www.hosting.de/api/?json#updating-records-in-a-zone
 type RecordsUpdateRequest struct {
@@ -0,0 +1,462 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &multiZoneRecordsResource{}
+	_ resource.ResourceWithConfigure      = &multiZoneRecordsResource{}
+	_ resource.ResourceWithValidateConfig = &multiZoneRecordsResource{}
+)
+
+// NewMultiZoneRecordsResource is a helper function to simplify the provider implementation.
+func NewMultiZoneRecordsResource() resource.Resource {
+	return &multiZoneRecordsResource{}
+}
+
+// multiZoneRecordsResource manages the records of several zones with one
+// batch update per zone.
+type multiZoneRecordsResource struct {
+	client *Client
+}
+
+// multiZoneRecordsResourceModel maps the multi_zone_records resource schema data.
+type multiZoneRecordsResourceModel struct {
+	ID           types.String                    `tfsdk:"id"`
+	ManageApexNS types.Bool                      `tfsdk:"manage_apex_ns"`
+	Zones        map[string]zoneRecordsListModel `tfsdk:"zones"`
+}
+
+// zoneRecordsListModel maps the records of a single zone.
+type zoneRecordsListModel struct {
+	Records []recordSetRecordModel `tfsdk:"records"`
+}
+
+// recordSetRecordModel maps a record managed as part of a set of records.
+type recordSetRecordModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Comments types.String `tfsdk:"comments"`
+}
+
+// recordSetRecordAttributes returns the schema attributes of a record managed
+// as part of a set of records.
+func recordSetRecordAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "DNS record ID.",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Name of the record. Example: mail.example.com.",
+			Required:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "Type of the DNS record.",
+			Required:    true,
+		},
+		"content": schema.StringAttribute{
+			Description: "Content of the DNS record.",
+			Required:    true,
+		},
+		"ttl": schema.Int64Attribute{
+			Description: "TTL of the DNS record in seconds. Defaults to 3600.",
+			Optional:    true,
+			Computed:    true,
+			Default:     int64default.StaticInt64(3600),
+		},
+		"priority": schema.Int64Attribute{
+			Description: "Priority of MX and SRV records. Defaults to 0.",
+			Optional:    true,
+			Computed:    true,
+			Default:     int64default.StaticInt64(0),
+		},
+		"comments": schema.StringAttribute{
+			Description: "Comment to the record. Defaults to an empty comment.",
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(""),
+		},
+	}
+}
+
+// apiRecords converts records of a set to API records of a zone.
+func (c *Client) apiRecords(zoneId string, records []recordSetRecordModel) []DNSRecord {
+	apiRecords := make([]DNSRecord, 0, len(records))
+	for _, record := range records {
+		apiRecords = append(apiRecords, DNSRecord{
+			ID:       record.ID.ValueString(),
+			ZoneID:   zoneId,
+			Name:     record.Name.ValueString(),
+			Type:     c.apiRecordType(record.Type.ValueString()),
+			Content:  c.apiContent(record.Type.ValueString(), record.Content.ValueString()),
+			TTL:      int(record.TTL.ValueInt64()),
+			Priority: int(record.Priority.ValueInt64()),
+			Comments: withCommentPrefix(c.commentPrefix, record.Comments.ValueString()),
+		})
+	}
+	return apiRecords
+}
+
+// newRecordSetRecords maps API records to records of a set. The configured
+// records are used to keep the SPF type of records published as TXT and the
+// formatting of their content.
+func (c *Client) newRecordSetRecords(records []DNSRecord, configured []recordSetRecordModel) []recordSetRecordModel {
+	setRecords := make([]recordSetRecordModel, 0, len(records))
+	for i, record := range records {
+		recordType := record.Type
+		content := types.StringValue(c.normalizeContent(record.Type, record.Content))
+		if i < len(configured) {
+			recordType = stateRecordType(configured[i].Type.ValueString(), record.Type)
+			content = stateContent(record.Type, configured[i].Content, content.ValueString())
+		}
+		comments, _ := splitCommentPrefix(c.commentPrefix, record.Comments)
+		setRecords = append(setRecords, recordSetRecordModel{
+			ID:       types.StringValue(record.ID),
+			Name:     types.StringValue(record.Name),
+			Type:     types.StringValue(recordType),
+			Content:  content,
+			TTL:      types.Int64Value(int64(record.TTL)),
+			Priority: types.Int64Value(int64(record.Priority)),
+			Comments: types.StringValue(comments),
+		})
+	}
+	return setRecords
+}
+
+// refreshRecordSet returns the stored versions of the records of a set,
+// dropping records that no longer exist.
+func (c *Client) refreshRecordSet(ctx context.Context, zoneId string, records []recordSetRecordModel) ([]recordSetRecordModel, error) {
+	stored, err := c.listAllRecords(ctx, RecordsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter:      recordsFilter(zoneId, "", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var found []DNSRecord
+	var configured []recordSetRecordModel
+	for _, record := range records {
+		index := slices.IndexFunc(stored, func(storedRecord DNSRecord) bool {
+			return storedRecord.ID == record.ID.ValueString()
+		})
+		if index < 0 {
+			continue
+		}
+		found = append(found, stored[index])
+		configured = append(configured, record)
+	}
+
+	return c.newRecordSetRecords(found, configured), nil
+}
+
+// Metadata returns the resource type name.
+func (r *multiZoneRecordsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_multi_zone_records"
+}
+
+// Schema defines the schema for the resource.
+func (r *multiZoneRecordsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the records of several zones, given as a map of zone ID to records. " +
+			"The records of each zone are updated with a single batch call: records are matched by name, type and content, " +
+			"matched records with other TTL, priority or comments are modified, and records removed from the configuration are deleted. " +
+			"Only records created by this resource are changed, other records of the zones are left untouched. " +
+			"Zones are updated independently: if the update of a zone fails, the other zones are still updated, " +
+			"and the state keeps the previous records of the failed zone, so the next apply retries it. " +
+			"Names with a CNAME record and other records, or with several CNAME records, are rejected when validating the configuration, " +
+			"as are names with a NULLMX record and MX records. " +
+			"The SOA record and the NS records at the zone apex define the authority of a zone and are maintained by hosting.de: " +
+			"they are never deleted by this resource, and configuring them is an error, unless manage_apex_ns allows managing the apex NS records.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the resource.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"manage_apex_ns": schema.BoolAttribute{
+				Description: "Manage NS records at the zone apex, e.g. for advanced delegation setups. " +
+					"Defaults to false, rejecting apex NS records in the configuration. Apex NS records are never deleted unless this is set, " +
+					"and the SOA record is never managed.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"zones": schema.MapNestedAttribute{
+				Description: "Records to manage, keyed by zone ID.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"records": schema.ListNestedAttribute{
+							Description: "Records of the zone.",
+							Required:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: recordSetRecordAttributes(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that no name of a zone has a CNAME record together
+// with other records, which the API rejects, or a NULLMX record together with
+// MX records, and that no SOA record is configured.
+func (r *multiZoneRecordsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var zones types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zones"), &zones)...)
+	if resp.Diagnostics.HasError() || zones.IsNull() || zones.IsUnknown() {
+		return
+	}
+
+	zoneIds := make([]string, 0, len(zones.Elements()))
+	for zoneId := range zones.Elements() {
+		zoneIds = append(zoneIds, zoneId)
+	}
+	sort.Strings(zoneIds)
+	for _, zoneId := range zoneIds {
+		zone, ok := zones.Elements()[zoneId].(types.Object)
+		if !ok || zone.IsNull() || zone.IsUnknown() {
+			continue
+		}
+		records, ok := zone.Attributes()["records"].(types.List)
+		if !ok || records.IsNull() || records.IsUnknown() {
+			continue
+		}
+		recordsPath := path.Root("zones").AtMapKey(zoneId).AtName("records")
+		resp.Diagnostics.Append(validateCNAMECoexistence(records, recordsPath)...)
+		resp.Diagnostics.Append(validateNullMXExclusivity(records, recordsPath)...)
+		resp.Diagnostics.Append(validateNoSOARecords(records, recordsPath)...)
+	}
+}
+
+// Create creates the records of all zones.
+func (r *multiZoneRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Retrieve values from plan
+	var plan multiZoneRecordsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := multiZoneRecordsResourceModel{
+		ID:           types.StringValue("multi_zone_records"),
+		ManageApexNS: plan.ManageApexNS,
+		Zones:        map[string]zoneRecordsListModel{},
+	}
+	r.applyZones(ctx, &resp.Diagnostics, state.Zones, nil, plan.Zones, plan.ManageApexNS.ValueBool())
+
+	// Set state to the records of all successfully updated zones
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *multiZoneRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Get current state
+	var state multiZoneRecordsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for zoneId, zone := range state.Zones {
+		records, err := r.client.refreshRecordSet(ctx, zoneId, zone.Records)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS records",
+				"Could not read hosting.de DNS records of zone ID "+zoneId+": "+err.Error(),
+			)
+			return
+		}
+		state.Zones[zoneId] = zoneRecordsListModel{Records: records}
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update applies the changed records of each zone.
+func (r *multiZoneRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var plan, state multiZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := state.Zones
+	state.ManageApexNS = plan.ManageApexNS
+	state.Zones = map[string]zoneRecordsListModel{}
+	r.applyZones(ctx, &resp.Diagnostics, state.Zones, previous, plan.Zones, plan.ManageApexNS.ValueBool())
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the records of all zones.
+func (r *multiZoneRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Retrieve values from state
+	var state multiZoneRecordsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remaining := map[string]zoneRecordsListModel{}
+	r.applyZones(ctx, &resp.Diagnostics, remaining, state.Zones, nil, state.ManageApexNS.ValueBool())
+	if resp.Diagnostics.HasError() {
+		// Keep the records of zones that could not be updated.
+		state.Zones = remaining
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
+
+// applyZone turns the previous into the planned records of a single zone
+// while holding the zone's lock, so other record updates of the zone cannot
+// interleave with the update.
+func (r *multiZoneRecordsResource) applyZone(ctx context.Context, zoneId string, previous, planned []recordSetRecordModel, manageApexNS bool) ([]DNSRecord, recordChanges, error) {
+	unlock, err := r.client.lockZone(ctx, zoneId)
+	if err != nil {
+		return nil, recordChanges{}, err
+	}
+	defer unlock()
+
+	desired := r.client.apiRecords(zoneId, planned)
+	current, err := r.client.withoutProtectedApexRecords(ctx, zoneId, r.client.apiRecords(zoneId, previous), desired, manageApexNS)
+	if err != nil {
+		return nil, recordChanges{}, err
+	}
+	return r.client.applyRecordsRetrying(ctx, zoneId, current, desired, func(ctx context.Context) ([]DNSRecord, error) {
+		stored, err := r.client.listUserRecords(ctx, zoneId)
+		if err != nil {
+			return nil, err
+		}
+		return r.client.withoutProtectedApexRecords(ctx, zoneId, managedRecords(stored, current, desired), desired, manageApexNS)
+	})
+}
+
+// applyZones turns the previous into the planned records of each zone and
+// stores the resulting records in result. Zones are updated independently:
+// if a zone fails, an error is reported and its previous records are kept.
+// Protected apex records are never changed, see isProtectedApexRecord.
+func (r *multiZoneRecordsResource) applyZones(ctx context.Context, diags *diag.Diagnostics, result, previous, planned map[string]zoneRecordsListModel, manageApexNS bool) {
+	var zoneIds []string
+	for zoneId := range previous {
+		zoneIds = append(zoneIds, zoneId)
+	}
+	for zoneId := range planned {
+		if _, ok := previous[zoneId]; !ok {
+			zoneIds = append(zoneIds, zoneId)
+		}
+	}
+	sort.Strings(zoneIds)
+
+	// Changes of successfully updated zones, summarized if change_summary is
+	// enabled.
+	var summary []string
+	var total recordChanges
+	for _, zoneId := range zoneIds {
+		previousRecords := previous[zoneId].Records
+		plannedZone, keep := planned[zoneId]
+
+		applied, changes, err := r.applyZone(ctx, zoneId, previousRecords, plannedZone.Records, manageApexNS)
+		if errors.Is(err, errProtectedApexRecord) {
+			diags.AddAttributeError(
+				path.Root("zones").AtMapKey(zoneId),
+				"Protected hosting.de DNS record",
+				"Could not update records of zone ID "+zoneId+": "+err.Error()+". "+
+					"Remove the record from the configuration, or set manage_apex_ns to manage NS records at the zone apex.",
+			)
+		} else if detail, ok := r.client.recordLimitDetail(ctx, zoneId, err); ok {
+			diags.AddAttributeError(path.Root("zones").AtMapKey(zoneId), "hosting.de DNS zone record limit reached", detail)
+		} else if detail, ok := r.client.zoneRestorableErrorDetail(ctx, zoneId, err); ok {
+			diags.AddAttributeError(path.Root("zones").AtMapKey(zoneId), "hosting.de DNS zone is deleted", detail)
+		} else if err != nil {
+			diags.AddAttributeError(
+				path.Root("zones").AtMapKey(zoneId),
+				"Error updating records",
+				"Could not update records of zone ID "+zoneId+", unexpected error: "+err.Error(),
+			)
+		}
+		if err != nil {
+			if len(previousRecords) > 0 {
+				result[zoneId] = zoneRecordsListModel{Records: previousRecords}
+			}
+			continue
+		}
+
+		if keep {
+			result[zoneId] = zoneRecordsListModel{
+				Records: r.client.newRecordSetRecords(applied, plannedZone.Records),
+			}
+		}
+		if changes != (recordChanges{}) {
+			summary = append(summary, fmt.Sprintf("%s: %d added, %d modified, %d deleted",
+				zoneId, changes.added, changes.modified, changes.deleted))
+			total.added += changes.added
+			total.modified += changes.modified
+			total.deleted += changes.deleted
+		}
+	}
+
+	if r.client.changeSummary && len(summary) > 0 {
+		diags.AddWarning(
+			"hosting.de DNS change summary",
+			fmt.Sprintf("Changed records in %d zones (%d added, %d modified, %d deleted):\n%s",
+				len(summary), total.added, total.modified, total.deleted, strings.Join(summary, "\n")),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *multiZoneRecordsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
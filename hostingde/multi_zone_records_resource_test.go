@@ -0,0 +1,353 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDiffRecords(t *testing.T) {
+	current := []DNSRecord{
+		{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "2", Name: "mail.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		{ID: "3", Name: "example.test", Type: "TXT", Content: `"hello"`, TTL: 3600},
+	}
+	desired := []DNSRecord{
+		{Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 300},
+		{Name: "example.test", Type: "TXT", Content: "hello", TTL: 3600},
+		{Name: "ftp.example.test", Type: "A", Content: "192.0.2.3", TTL: 3600},
+	}
+
+	toAdd, toModify, toDelete := diffRecords(current, desired)
+	if len(toAdd) != 1 || toAdd[0].Name != "ftp.example.test" {
+		t.Errorf("expected ftp record to be added, got %v", toAdd)
+	}
+	if len(toModify) != 1 || toModify[0].ID != "1" || toModify[0].TTL != 300 {
+		t.Errorf("expected www record to be modified, got %v", toModify)
+	}
+	if len(toDelete) != 1 || toDelete[0].ID != "2" {
+		t.Errorf("expected mail record to be deleted, got %v", toDelete)
+	}
+}
+
+func TestMultiZoneRecordsResource(t *testing.T) {
+	updates := map[string]RecordsUpdateRequest{}
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := updates[updateRequest.ZoneConfigId]; ok {
+				t.Errorf("expected a single update for zone %s", updateRequest.ZoneConfigId)
+			}
+			updates[updateRequest.ZoneConfigId] = updateRequest
+
+			if updateRequest.ZoneConfigId == "zone-fail" {
+				return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "error"}}
+			}
+
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			for i, record := range updateRequest.RecordsToAdd {
+				record.ID = updateRequest.ZoneConfigId + "-" + string(rune('a'+i))
+				updateResponse.Response.Records = append(updateResponse.Response.Records, record)
+			}
+			return updateResponse
+		},
+		"zoneConfigsFind": func(body []byte) any {
+			var findRequest ZoneConfigsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: findRequest.Filter.Filter.Value, Status: "active"}}
+			return findResponse
+		},
+	})
+
+	client.changeSummary = true
+
+	ctx := context.Background()
+	r := &multiZoneRecordsResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	record := func(name, content string) recordSetRecordModel {
+		return recordSetRecordModel{
+			ID:       types.StringUnknown(),
+			Name:     types.StringValue(name),
+			Type:     types.StringValue("A"),
+			Content:  types.StringValue(content),
+			TTL:      types.Int64Value(3600),
+			Priority: types.Int64Value(0),
+			Comments: types.StringValue(""),
+		}
+	}
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id": types.StringUnknown(),
+		"zones": map[string]zoneRecordsListModel{
+			"zone-one":  {Records: []recordSetRecordModel{record("www.one.test", "192.0.2.1"), record("mail.one.test", "192.0.2.2")}},
+			"zone-two":  {Records: []recordSetRecordModel{record("www.two.test", "192.0.2.3")}},
+			"zone-fail": {Records: []recordSetRecordModel{record("www.fail.test", "192.0.2.4")}},
+		},
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+
+	if len(updates) != 3 || len(updates["zone-one"].RecordsToAdd) != 2 || len(updates["zone-two"].RecordsToAdd) != 1 {
+		t.Fatalf("expected one batch update per zone, got %v", updates)
+	}
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected an error for the failed zone only, got %v", resp.Diagnostics)
+	}
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || warnings[0].Summary() != "hosting.de DNS change summary" {
+		t.Fatalf("expected a change summary warning, got %v", warnings)
+	}
+	if detail := warnings[0].Detail(); detail != "Changed records in 2 zones (3 added, 0 modified, 0 deleted):\n"+
+		"zone-one: 2 added, 0 modified, 0 deleted\nzone-two: 1 added, 0 modified, 0 deleted" {
+		t.Errorf("unexpected change summary %q", detail)
+	}
+
+	var state multiZoneRecordsResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if _, ok := state.Zones["zone-fail"]; ok {
+		t.Errorf("expected failed zone not to be stored in state")
+	}
+	one := state.Zones["zone-one"].Records
+	if len(one) != 2 || one[0].ID.ValueString() != "zone-one-a" || one[1].ID.ValueString() != "zone-one-b" {
+		t.Errorf("expected records of zone-one to be stored with their IDs, got %v", one)
+	}
+	two := state.Zones["zone-two"].Records
+	if len(two) != 1 || two[0].ID.ValueString() != "zone-two-a" {
+		t.Errorf("expected record of zone-two to be stored with its ID, got %v", two)
+	}
+
+	// Zones locked by another record update are not updated.
+	client.zoneLockTimeout = 10 * time.Millisecond
+	unlock, err := client.lockZone(ctx, "zone-two")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+	clear(updates)
+	resp = &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if _, ok := updates["zone-two"]; ok || len(updates) != 2 {
+		t.Errorf("expected no update of the locked zone, got %v", updates)
+	}
+	if resp.Diagnostics.ErrorsCount() != 2 || !strings.Contains(resp.Diagnostics.Errors()[1].Detail(), "lock of zone zone-two") {
+		t.Errorf("expected an error for the locked zone, got %v", resp.Diagnostics)
+	}
+}
+
+// validateMultiZoneRecords validates the config of a multi_zone_records
+// resource with the given records of a single zone.
+func validateMultiZoneRecords(t *testing.T, records ...recordSetRecordModel) *fwresource.ValidateConfigResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	r := &multiZoneRecordsResource{}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	config := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := config.Set(ctx, &multiZoneRecordsResourceModel{
+		ID:           types.StringNull(),
+		ManageApexNS: types.BoolNull(),
+		Zones: map[string]zoneRecordsListModel{
+			"zone-id": {Records: records},
+		},
+	}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	resp := &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, resp)
+	return resp
+}
+
+// testRecordSetRecord returns a record with the given name, type and content.
+func testRecordSetRecord(name, recordType, content string) recordSetRecordModel {
+	return recordSetRecordModel{
+		ID:       types.StringNull(),
+		Name:     types.StringValue(name),
+		Type:     types.StringValue(recordType),
+		Content:  types.StringValue(content),
+		TTL:      types.Int64Value(3600),
+		Priority: types.Int64Value(0),
+		Comments: types.StringValue(""),
+	}
+}
+
+func TestMultiZoneRecordsResourceCNAMEConflicts(t *testing.T) {
+	record := testRecordSetRecord
+	validate := func(records ...recordSetRecordModel) *fwresource.ValidateConfigResponse {
+		t.Helper()
+		return validateMultiZoneRecords(t, records...)
+	}
+
+	resp := validate(
+		record("www.example.test", "CNAME", "example.test"),
+		record("mail.example.test", "A", "192.0.2.1"),
+		record("WWW.example.test.", "A", "192.0.2.2"),
+	)
+	if len(resp.Diagnostics.Errors()) != 1 || resp.Diagnostics.Errors()[0].Summary() != "CNAME record conflicts with other records" ||
+		!strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "www.example.test") {
+		t.Errorf("expected CNAME and A conflict at www.example.test, got %v", resp.Diagnostics)
+	}
+
+	resp = validate(
+		record("www.example.test", "CNAME", "example.test"),
+		record("www.example.test", "CNAME", "example.org"),
+	)
+	if len(resp.Diagnostics.Errors()) != 1 || resp.Diagnostics.Errors()[0].Summary() != "Duplicate CNAME record" {
+		t.Errorf("expected duplicate CNAME error, got %v", resp.Diagnostics)
+	}
+
+	resp = validate(
+		record("www.example.test", "CNAME", "example.test"),
+		record("example.test", "A", "192.0.2.1"),
+		record("example.test", "MX", "mail.example.test"),
+	)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected records at different names to be valid, got %v", resp.Diagnostics)
+	}
+}
+
+func TestMultiZoneRecordsResourceNullMXConflicts(t *testing.T) {
+	record := testRecordSetRecord
+
+	resp := validateMultiZoneRecords(t,
+		record("example.test", "NULLMX", "."),
+		record("www.example.test", "MX", "mail.example.test"),
+		record("Example.test.", "MX", "mail.example.test"),
+	)
+	if len(resp.Diagnostics.Errors()) != 1 || resp.Diagnostics.Errors()[0].Summary() != "NULLMX record conflicts with MX records" ||
+		!strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "example.test has a NULLMX record and 1 MX records") {
+		t.Errorf("expected NULLMX and MX conflict at example.test, got %v", resp.Diagnostics)
+	}
+
+	resp = validateMultiZoneRecords(t,
+		record("example.test", "NULLMX", "."),
+		record("www.example.test", "MX", "mail.example.test"),
+		record("example.test", "TXT", "v=spf1 -all"),
+	)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected NULLMX and MX records at different names to be valid, got %v", resp.Diagnostics)
+	}
+}
+
+func TestMultiZoneRecordsResourceApexProtection(t *testing.T) {
+	var updates []RecordsUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "www", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updates = append(updates, updateRequest)
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+
+	ctx := context.Background()
+	r := &multiZoneRecordsResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	record := func(id, name, recordType, content string) recordSetRecordModel {
+		setRecord := testRecordSetRecord(name, recordType, content)
+		setRecord.ID = types.StringValue(id)
+		return setRecord
+	}
+	// The previous records include apex records, e.g. from an earlier
+	// version of the provider.
+	previous := []recordSetRecordModel{
+		record("soa", "example.test", "SOA", "ns1.example.test. hostmaster.example.test. 1 86400 7200 3600000 3600"),
+		record("ns", "Example.test.", "NS", "ns1.example.test"),
+		record("delegation", "sub.example.test", "NS", "ns.other.test"),
+		record("www", "www.example.test", "A", "192.0.2.1"),
+	}
+
+	update := func(manageApexNS bool, planned ...recordSetRecordModel) *fwresource.UpdateResponse {
+		t.Helper()
+
+		updates = nil
+		prior := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"id":             "multi_zone_records",
+			"manage_apex_ns": manageApexNS,
+			"zones":          map[string]zoneRecordsListModel{"zone-id": {Records: previous}},
+		})
+		plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"id":             "multi_zone_records",
+			"manage_apex_ns": manageApexNS,
+			"zones":          map[string]zoneRecordsListModel{"zone-id": {Records: planned}},
+		})
+		resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}}
+		r.Update(ctx, fwresource.UpdateRequest{
+			Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+			State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw},
+		}, resp)
+		return resp
+	}
+	deleted := func() []string {
+		var ids []string
+		for _, updateRequest := range updates {
+			for _, record := range updateRequest.RecordsToDelete {
+				ids = append(ids, record.ID)
+			}
+		}
+		return ids
+	}
+
+	// Removing all records never deletes the apex SOA and NS records.
+	if resp := update(false, record("www", "www.example.test", "A", "192.0.2.1")); resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if ids := strings.Join(deleted(), ","); ids != "delegation" {
+		t.Errorf("expected only the delegation to be deleted, got %s", ids)
+	}
+
+	// Apex NS records are deleted if managed explicitly, the SOA record never.
+	if resp := update(true); resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if ids := strings.Join(deleted(), ","); ids != "ns,delegation,www" {
+		t.Errorf("expected managed apex NS records to be deleted, got %s", ids)
+	}
+
+	// Configuring apex NS records requires manage_apex_ns.
+	resp := update(false, record("ns", "example.test", "NS", "ns2.example.test"))
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Protected hosting.de DNS record" {
+		t.Errorf("expected an error for a configured apex NS record, got %v", resp.Diagnostics)
+	}
+	if len(updates) != 0 {
+		t.Errorf("expected no update with a protected record, got %v", updates)
+	}
+
+	if resp := validateMultiZoneRecords(t, testRecordSetRecord("example.test", "SOA", "ns1.example.test. hostmaster.example.test. 1 86400 7200 3600000 3600")); !resp.Diagnostics.HasError() {
+		t.Errorf("expected SOA records to be rejected, got %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,89 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logRecordMutations logs each record change of an update request at INFO
+// level before it is sent, if the log_mutations option is enabled. Modified
+// records are compared to the record with the same ID in previous, if any, to
+// log the changed fields. The previous records are also kept for the change
+// artifact.
+func (c *Client) logRecordMutations(ctx context.Context, updateRequest RecordsUpdateRequest, previous []DNSRecord) {
+	c.changeArtifact.notePrevious(previous)
+	if !c.logMutations {
+		return
+	}
+
+	for _, record := range updateRequest.RecordsToDelete {
+		tflog.Info(ctx, "Deleting DNS record", mutationFields(updateRequest.ZoneConfigId, record))
+	}
+	for _, record := range updateRequest.RecordsToModify {
+		fields := mutationFields(updateRequest.ZoneConfigId, record)
+		for _, previousRecord := range previous {
+			if previousRecord.ID == record.ID {
+				fields["changes"] = strings.Join(recordFieldChanges(previousRecord, record), ", ")
+				break
+			}
+		}
+		tflog.Info(ctx, "Modifying DNS record", fields)
+	}
+	for _, record := range updateRequest.RecordsToAdd {
+		tflog.Info(ctx, "Adding DNS record", mutationFields(updateRequest.ZoneConfigId, record))
+	}
+}
+
+// logZoneMutation logs a zone change at INFO level before it is sent, if the
+// log_mutations option is enabled.
+func (c *Client) logZoneMutation(ctx context.Context, message string, zoneConfig ZoneConfig) {
+	if !c.logMutations {
+		return
+	}
+
+	tflog.Info(ctx, message, map[string]any{
+		"zone_id":   zoneConfig.ID,
+		"zone_name": zoneConfig.Name,
+		"zone_type": zoneConfig.Type,
+		"email":     zoneConfig.EMailAddress,
+	})
+}
+
+// mutationFields returns the log fields identifying a changed record.
+func mutationFields(zoneId string, record DNSRecord) map[string]any {
+	return map[string]any{
+		"zone_id":   zoneId,
+		"record_id": record.ID,
+		"name":      record.Name,
+		"type":      record.Type,
+		"content":   record.Content,
+		"ttl":       record.TTL,
+		"priority":  record.Priority,
+		"comments":  record.Comments,
+	}
+}
+
+// recordFieldChanges describes the fields differing between two versions of
+// a record, e.g. "ttl: 3600 -> 300".
+func recordFieldChanges(previous, record DNSRecord) []string {
+	var changes []string
+	for _, field := range []struct {
+		name           string
+		previous, next any
+	}{
+		{"name", previous.Name, record.Name},
+		{"type", previous.Type, record.Type},
+		{"content", previous.Content, record.Content},
+		{"ttl", previous.TTL, record.TTL},
+		{"priority", previous.Priority, record.Priority},
+		{"comments", previous.Comments, record.Comments},
+	} {
+		if field.previous != field.next {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", field.name, fmt.Sprint(field.previous), fmt.Sprint(field.next)))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,71 @@
+package hostingde
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLogRecordMutations(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(_ []byte) any {
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 300},
+				{ID: "3", Name: "ftp.example.test", Type: "A", Content: "192.0.2.3", TTL: 3600},
+			}
+			return findResponse
+		},
+	})
+
+	current := []DNSRecord{
+		{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "2", Name: "mail.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+	}
+	desired := []DNSRecord{
+		{Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 300},
+		{Name: "ftp.example.test", Type: "A", Content: "192.0.2.3", TTL: 3600},
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	if _, _, err := client.applyRecords(ctx, "zone-id", current, desired); err != nil {
+		t.Fatal(err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("expected no mutations to be logged by default, got %s", output.String())
+	}
+
+	client.logMutations = true
+	if _, _, err := client.applyRecords(ctx, "zone-id", current, desired); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		message, name, changes string
+	}{
+		{"Deleting DNS record", "mail.example.test", ""},
+		{"Modifying DNS record", "www.example.test", `ttl: "3600" -> "300"`},
+		{"Adding DNS record", "ftp.example.test", ""},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d logged mutations, got %v", len(expected), entries)
+	}
+	for i, entry := range entries {
+		if entry["@level"] != "info" || entry["@message"] != expected[i].message || entry["name"] != expected[i].name || entry["zone_id"] != "zone-id" {
+			t.Errorf("expected %s of %s, got %v", expected[i].message, expected[i].name, entry)
+		}
+		if changes, _ := entry["changes"].(string); changes != expected[i].changes {
+			t.Errorf("expected changes %q, got %q", expected[i].changes, changes)
+		}
+	}
+}
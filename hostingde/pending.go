@@ -0,0 +1,64 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Defaults for waiting on changes the API processes asynchronously.
+const (
+	defaultPendingTimeout   = time.Minute
+	defaultPendingPollDelay = 2 * time.Second
+)
+
+// errChangePending is returned if a change is still pending when the pending
+// timeout elapses.
+var errChangePending = errors.New("change is still pending")
+
+// waitZoneNotPending waits until a change of a zone, for which the API
+// responded with status pending, is processed. It polls the zone config every
+// pendingPollDelay until the zone is no longer pending and returns the
+// refreshed config, which replaces the cached one. It returns nil without
+// waiting if pendingTimeout is 0, and ErrNotFound if the zone no longer
+// exists, e.g. after a pending deletion.
+func (c *Client) waitZoneNotPending(ctx context.Context, zoneId string) (*ZoneConfig, error) {
+	if c.pendingTimeout <= 0 || zoneId == "" {
+		return nil, nil
+	}
+
+	deadline := time.Now().Add(c.pendingTimeout)
+	for {
+		findResponse, err := c.listZoneConfigs(ctx, ZoneConfigsFindRequest{
+			BaseRequest: c.newBaseRequest(ctx),
+			Filter: FilterOrChain{Filter: Filter{
+				Field: "ZoneConfigId",
+				Value: zoneId,
+			}},
+			Limit: 1,
+			Page:  1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(findResponse.Response.Data) == 0 {
+			return nil, fmt.Errorf("zone ID %s: %w", zoneId, ErrNotFound)
+		}
+
+		zoneConfig := findResponse.Response.Data[0]
+		if zoneConfig.Status != "pending" {
+			c.cacheZoneConfig(zoneId, zoneConfig)
+			return &zoneConfig, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("zone ID %s: %w after %s, increase pending_timeout in the provider configuration to wait longer",
+				zoneId, errChangePending, c.pendingTimeout)
+		}
+		if err := c.backoff(ctx, "change pending", min(c.pendingPollDelay, remaining)); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitPendingChanges(t *testing.T) {
+	var polls, pendingPolls int
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(_ []byte) any {
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "pending"}}
+		},
+		"zoneUpdate": func(_ []byte) any {
+			updateResponse := ZoneUpdateResponse{BaseResponse: BaseResponse{Status: "pending"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test", Status: "pending"}
+			return updateResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			polls++
+			status := "active"
+			if polls <= pendingPolls {
+				status = "pending"
+			}
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: status}}
+			return findResponse
+		},
+	})
+	client.pendingPollDelay = time.Millisecond
+	updateRequest := RecordsUpdateRequest{BaseRequest: &BaseRequest{}, ZoneConfigId: "zone-id"}
+
+	// A pending records update is awaited until the zone is processed.
+	pendingPolls = 2
+	if _, err := client.updateRecords(context.Background(), updateRequest); err != nil {
+		t.Fatal(err)
+	}
+	if polls != 3 {
+		t.Errorf("expected the zone to be polled until it is no longer pending, got %d polls", polls)
+	}
+
+	// A pending zone update returns the processed zone config.
+	polls, pendingPolls = 0, 1
+	updateResponse, err := client.updateZone(context.Background(), ZoneUpdateRequest{
+		BaseRequest: &BaseRequest{},
+		ZoneConfig:  ZoneConfig{ID: "zone-id", Name: "example.test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls != 2 || updateResponse.Response.ZoneConfig.Status != "active" {
+		t.Errorf("expected the processed zone config after 2 polls, got status %q after %d polls", updateResponse.Response.ZoneConfig.Status, polls)
+	}
+
+	// The operation fails if the change is still pending after the timeout.
+	polls, pendingPolls = 0, 1000
+	client.pendingTimeout = 20 * time.Millisecond
+	if _, err := client.updateRecords(context.Background(), updateRequest); !errors.Is(err, errChangePending) {
+		t.Errorf("expected pending error after the timeout, got %v", err)
+	}
+
+	// Pending changes are not awaited without a timeout.
+	polls = 0
+	client.pendingTimeout = 0
+	if _, err := client.updateRecords(context.Background(), updateRequest); err != nil {
+		t.Fatal(err)
+	}
+	if polls != 0 {
+		t.Errorf("expected no polls without pending timeout, got %d", polls)
+	}
+}
@@ -2,29 +2,81 @@ package hostingde
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-const defaultBaseURL = "https://secure.hosting.de/api/dns/v1/json"
-
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ provider.Provider = &hostingdeProvider{}
+	_ provider.Provider              = &hostingdeProvider{}
+	_ provider.ProviderWithFunctions = &hostingdeProvider{}
 )
 
 // hostingdeProviderModel maps provider schema data to a Go type.
 type hostingdeProviderModel struct {
-	AccountId types.String `tfsdk:"account_id"`
-	AuthToken types.String `tfsdk:"auth_token"`
-	BaseUrl   types.String `tfsdk:"base_url"`
+	AccountId         types.String `tfsdk:"account_id"`
+	AuthToken         types.String `tfsdk:"auth_token"`
+	FallbackAuthToken types.String `tfsdk:"fallback_auth_token"`
+	SigningSecret     types.String `tfsdk:"signing_secret"`
+	BaseUrl           types.String `tfsdk:"base_url"`
+
+	AccountBaseUrl types.String `tfsdk:"account_base_url"`
+	DomainBaseUrl  types.String `tfsdk:"domain_base_url"`
+
+	DisableHTTP2      types.Bool  `tfsdk:"disable_http2"`
+	DisableKeepAlives types.Bool  `tfsdk:"disable_keep_alives"`
+	KeepAlive         types.Int64 `tfsdk:"keep_alive"`
+
+	ErrorVerbosity types.String `tfsdk:"error_verbosity"`
+	IDNDisplay     types.String `tfsdk:"idn_display"`
+
+	PageConcurrency types.Int64 `tfsdk:"page_concurrency"`
+	BatchWindow     types.Int64 `tfsdk:"batch_window"`
+	MaxBatchSize    types.Int64 `tfsdk:"max_batch_size"`
+	MaxRetries      types.Int64 `tfsdk:"max_retries"`
+	ConflictRetries types.Int64 `tfsdk:"conflict_retries"`
+
+	ReadAfterWriteRetries types.Int64 `tfsdk:"read_after_write_retries"`
+	ReadAfterWriteDelay   types.Int64 `tfsdk:"read_after_write_delay"`
+	RequestTimeout        types.Int64 `tfsdk:"request_timeout"`
+
+	DeleteGracePeriod  types.Int64  `tfsdk:"delete_grace_period"`
+	AutoCreateZone     types.Bool   `tfsdk:"auto_create_zone"`
+	ChangeSummary      types.Bool   `tfsdk:"change_summary"`
+	ChangeArtifactPath types.String `tfsdk:"change_artifact_path"`
+	LogMutations       types.Bool   `tfsdk:"log_mutations"`
+	MaintenanceTimeout types.Int64  `tfsdk:"maintenance_timeout"`
+	PendingTimeout     types.Int64  `tfsdk:"pending_timeout"`
+	ZoneLockTimeout    types.Int64  `tfsdk:"zone_lock_timeout"`
+	ZoneReadCache      types.Bool   `tfsdk:"zone_read_cache"`
+	ZoneRecordLimit    types.Int64  `tfsdk:"zone_record_limit"`
+
+	ValidateTargetsResolve types.Bool `tfsdk:"validate_targets_resolve"`
+
+	NormalizeRecordTypes types.List `tfsdk:"normalize_record_types"`
+	NormalizationWarning types.Bool `tfsdk:"normalization_warning"`
+
+	CommentPrefix     types.String `tfsdk:"comment_prefix"`
+	DefaultRecordType types.String `tfsdk:"default_record_type"`
+	DefaultTTL        types.Int64  `tfsdk:"default_ttl"`
+	StrictValidation  types.Bool   `tfsdk:"strict_validation"`
+	TXTSizeThreshold  types.Int64  `tfsdk:"txt_size_threshold"`
+	TruncateComments  types.Bool   `tfsdk:"truncate_comments"`
+	ConvertSPFToTXT   types.Bool   `tfsdk:"convert_spf_to_txt"`
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -44,19 +96,288 @@ func (p *hostingdeProvider) Metadata(_ context.Context, _ provider.MetadataReque
 func (p *hostingdeProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"account_base_url": schema.StringAttribute{
+				Description: "Base URL for the account service of the hosting.de API. " +
+					"May also be provided via HOSTINGDE_ACCOUNT_BASE_URL environment variable. " +
+					"Defaults to https://secure.hosting.de/api/account/v1/json.",
+				Optional: true,
+			},
 			"account_id": schema.StringAttribute{
-				Description: "Account ID for hosting.de API. May also be provided via HOSTINGDE_ACCOUNT_ID environment variable.",
-				Optional:    true,
+				Description: "Account ID for hosting.de API. May also be provided via HOSTINGDE_ACCOUNT_ID environment variable. " +
+					"Requests are sent on behalf of this account. The account_id of a hostingde_zone and the owner_account_id of a hostingde_record " +
+					"take precedence for the requests of that resource.",
+				Optional: true,
 			},
 			"auth_token": schema.StringAttribute{
 				Description: "Auth token for hosting.de API. May also be provided via HOSTINGDE_AUTH_TOKEN environment variable.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"auto_create_zone": schema.BoolAttribute{
+				Description: "Create the zone of a hostingde_record resource configured by zone_name if it does not exist, " +
+					"as a NATIVE zone using the default name server set, e.g. to bootstrap a new domain in a single apply. " +
+					"Created zones are reported as a warning and are not managed by Terraform. Defaults to false.",
+				Optional: true,
+			},
 			"base_url": schema.StringAttribute{
-				Description: "Base URL for hosting.de API. May also be provided via HOSTINGDE_BASE_URL environment variable.",
+				Description: "Base URL for the DNS service of the hosting.de API. May also be provided via HOSTINGDE_BASE_URL environment variable. " +
+					"Must be an absolute http or https URL, e.g. of a staging or reseller gateway. " +
+					"Defaults to https://secure.hosting.de/api/dns/v1/json.",
+				Optional: true,
+			},
+			"batch_window": schema.Int64Attribute{
+				Description: "Time in milliseconds to collect changes of hostingde_record resources in the same zone before " +
+					"sending them to the API as a single update. Reduces the number of API requests of large applies. " +
+					"If a combined update fails, the changes are sent one by one to report errors for the affected records only. " +
+					"Defaults to 0 (disabled).",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"change_artifact_path": schema.StringAttribute{
+				Description: "Path of a file to write the record changes applied by the provider to as JSON, e.g. for audit and rollback tooling. " +
+					"Each change lists the action (add, modify or delete), the zone ID, the record before and after the change in the form sent to the API, " +
+					"and the client and server transaction IDs of the API request. The file is replaced at the start of every Terraform run " +
+					"and rewritten after every change. If the file cannot be written, a warning is reported and the changes are applied anyway. Not written by default.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"change_summary": schema.BoolAttribute{
+				Description: "Add a warning summarizing the number of added, modified and deleted records per zone after " +
+					"hostingde_multi_zone_records or hostingde_zone_records applies, for a quick overview of large changes. Defaults to false.",
+				Optional: true,
+			},
+			"comment_prefix": schema.StringAttribute{
+				Description: "Prefix added to the comments of records managed by Terraform, e.g. \"[terraform] \". " +
+					"The prefix is hidden from the comments attribute and reported as terraform_managed. Disabled by default.",
+				Optional: true,
+			},
+			"conflict_retries": schema.Int64Attribute{
+				Description: "Maximum number of retries of record updates of hostingde_zone_records and hostingde_multi_zone_records " +
+					"rejected because the zone was modified concurrently, e.g. by a user in the hosting.de control panel (HTTP 409 or a conflict error of the API). " +
+					"Each retry reads the zone again and applies the changes still needed. Defaults to 2.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"convert_spf_to_txt": schema.BoolAttribute{
+				Description: "Publish records of the deprecated SPF type as TXT records with the same content. " +
+					"The record keeps type = \"SPF\" in the Terraform state. Defaults to false.",
+				Optional: true,
+			},
+			"default_record_type": schema.StringAttribute{
+				Description: "Type of hostingde_record resources that do not set type, e.g. PTR for a reverse zone. " +
+					"The type set on a record takes precedence. Not set by default, requiring type on every record.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(recordTypes...),
+				},
+			},
+			"default_ttl": schema.Int64Attribute{
+				Description: "TTL in seconds of hostingde_record resources without a configured ttl. " +
+					"Takes precedence over the TTL of the zone's SOA record, which is used otherwise.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(minRecordTTL, maxRecordTTL),
+				},
+			},
+			"delete_grace_period": schema.Int64Attribute{
+				Description: "Time in seconds to wait before deleting a record or zone, giving operators a chance to cancel " +
+					"an accidental destroy. A warning is logged when the wait starts. Defaults to 0 (delete immediately).",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"disable_http2": schema.BoolAttribute{
+				Description: "Disable HTTP/2 for requests to the hosting.de API. Useful behind proxies that misbehave with HTTP/2. Defaults to false.",
 				Optional:    true,
 			},
+			"disable_keep_alives": schema.BoolAttribute{
+				Description: "Disable HTTP keep-alives, using each connection for a single request only. Defaults to false.",
+				Optional:    true,
+			},
+			"domain_base_url": schema.StringAttribute{
+				Description: "Base URL for the domain service of the hosting.de API. " +
+					"May also be provided via HOSTINGDE_DOMAIN_BASE_URL environment variable. " +
+					"Defaults to https://secure.hosting.de/api/domain/v1/json.",
+				Optional: true,
+			},
+			"error_verbosity": schema.StringAttribute{
+				Description: "Level of detail in API error messages. Valid values are basic, detailed, and raw. " +
+					"detailed includes the request body (with the auth token redacted) for validation errors (HTTP 4xx), " +
+					"raw includes it for all HTTP errors. Defaults to basic.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(errorVerbosityBasic, errorVerbosityDetailed, errorVerbosityRaw),
+				},
+			},
+			"fallback_auth_token": schema.StringAttribute{
+				Description: "Auth token for hosting.de API to use if the API rejects auth_token with HTTP 401, e.g. while rotating tokens. " +
+					"May also be provided via HOSTINGDE_FALLBACK_AUTH_TOKEN environment variable. " +
+					"Once auth_token was rejected, requests are sent with the fallback token, and each operation warns that auth_token failed.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"idn_display": schema.StringAttribute{
+				Description: "Form in which internationalized host names in the content of ALIAS, CNAME, MX, NS, PTR and SRV records are read, " +
+					"if the content does not match the configuration, e.g. after an import. Valid values are ace and unicode. " +
+					"ace shows host names in ASCII compatible form (xn--mller-kva.de) as stored by hosting.de, unicode shows them in Unicode (müller.de). " +
+					"Configured host names are always sent in ASCII compatible form. Defaults to ace.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(idnDisplayACE, idnDisplayUnicode),
+				},
+			},
+			"keep_alive": schema.Int64Attribute{
+				Description: "Interval in seconds between TCP keep-alive probes on connections to the hosting.de API. Defaults to Go's standard interval.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"log_mutations": schema.BoolAttribute{
+				Description: "Log every record and zone change with its zone, record identity and changed fields at INFO level " +
+					"before it is sent to the API, e.g. to keep an audit trail of the changes of an apply. " +
+					"Changes are still applied. Defaults to false.",
+				Optional: true,
+			},
+			"maintenance_timeout": schema.Int64Attribute{
+				Description: "Maximum time in seconds to wait for the end of a hosting.de API maintenance, retrying rejected requests every 30 seconds. " +
+					"The API is considered in maintenance if it reports a maintenance error, also with HTTP 503. " +
+					"Defaults to 0, which fails requests during a maintenance with a distinct error.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_batch_size": schema.Int64Attribute{
+				Description: "Maximum number of record changes sent to the API in a single update. Larger change sets, " +
+					"e.g. of hostingde_multi_zone_records, are split into several updates. If a later update fails, " +
+					"the changes of the earlier updates remain applied and the error reports how many were applied. Defaults to 100.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of retries of requests rejected by the hosting.de API's rate limit (HTTP 429), " +
+					"and of reads failed with HTTP 502, 503 or 504. Changes are not retried after server errors, since they may have been applied. " +
+					"The delay between attempts doubles from one second, unless the API sends a Retry-After header. Defaults to 3.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"normalization_warning": schema.BoolAttribute{
+				Description: "Warn when the normalization of normalize_record_types changes the content of a hostingde_record, " +
+					"showing the content before and after. Helps to migrate to storing content verbatim. Defaults to true.",
+				Optional: true,
+			},
+			"normalize_record_types": schema.ListAttribute{
+				Description: "Record types whose content is normalized by removing the quotes the API adds, e.g. [\"TXT\", \"SPF\"]. " +
+					"Escape sequences such as \\\" and \\065 in quoted content are resolved, and quotes and backslashes in the configured content are escaped when sent to the API. " +
+					"The content of other record types is stored verbatim. Defaults to [\"TXT\"].",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"page_concurrency": schema.Int64Attribute{
+				Description: "Maximum number of result pages fetched in parallel when listing many records. Defaults to 4.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"pending_timeout": schema.Int64Attribute{
+				Description: "Maximum time in seconds to wait for changes the hosting.de API processes asynchronously, " +
+					"reported with status pending. The zone is polled every 2 seconds until it is no longer pending, " +
+					"so the state reflects the processed change. If the change is still pending after the timeout, the operation fails. " +
+					"Set to 0 to not wait for pending changes. Defaults to 60.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"read_after_write_delay": schema.Int64Attribute{
+				Description: "Delay in milliseconds between attempts to find a record right after creating it. Defaults to 1000.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"read_after_write_retries": schema.Int64Attribute{
+				Description: "Number of additional attempts to find a record right after creating it, if the API does not return it yet. " +
+					"If the record is still not found, creating it fails. Defaults to 3.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"request_timeout": schema.Int64Attribute{
+				Description: "Timeout in seconds of a single request to the hosting.de API, including reading the response. " +
+					"Requests are also aborted when the Terraform operation is canceled or times out. Defaults to 10.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"signing_secret": schema.StringAttribute{
+				Description: "Secret to sign API requests with, in addition to the auth token. May also be provided via HOSTINGDE_SIGNING_SECRET environment variable. " +
+					"Reserved for request signing schemes hosting.de may introduce: the hosting.de API does not support request signing yet, so requests are not signed.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"strict_validation": schema.BoolAttribute{
+				Description: "Report likely misconfigurations of records as errors instead of warnings, " +
+					"e.g. SRV records whose name does not start with the service and protocol, such as _sip._tcp.example.com. Defaults to false.",
+				Optional: true,
+			},
+			"truncate_comments": schema.BoolAttribute{
+				Description: "Truncate comments of hostingde_record resources to the 255 characters accepted by the API, " +
+					"warning when planning, instead of failing. The configured comment is kept in the Terraform state. Defaults to false.",
+				Optional: true,
+			},
+			"txt_size_threshold": schema.Int64Attribute{
+				Description: "Size in bytes of the record data of TXT records above which planning them warns, or fails if strict_validation is set. " +
+					"Larger DNS responses do not fit into plain UDP messages, so resolvers retry over TCP, which some networks block. " +
+					"Set to 0 to disable the check. Defaults to 512.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"validate_targets_resolve": schema.BoolAttribute{
+				Description: "Look up the targets of MX, NS and CNAME records when planning and warn if they do not resolve, " +
+					"e.g. because of a typo. The check is best-effort and requires DNS access during plan. Defaults to false.",
+				Optional: true,
+			},
+			"zone_lock_timeout": schema.Int64Attribute{
+				Description: "Maximum time in seconds to wait for other operations on the same zone to finish before updating its records. " +
+					"Updates of records in the same zone are serialized. Defaults to waiting until the operation times out.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"zone_read_cache": schema.BoolAttribute{
+				Description: "Refresh hostingde_record resources from the records of their zone, listed once per zone on the first read " +
+					"and reused until a record of the zone is changed. This reduces the number of requests when refreshing many records " +
+					"of the same zone, but lists all records of every zone with a managed record. Defaults to false, reading each record individually.",
+				Optional: true,
+			},
+			"zone_record_limit": schema.Int64Attribute{
+				Description: "Maximum number of records per zone of the account. If set, planning a new record warns once the zone " +
+					"has reached 90% of the limit, which requires counting the zone's records. Errors about the zone's record limit " +
+					"always report the limit, if known, and the zone's current number of records. Defaults to 0 (unknown).",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
@@ -100,7 +421,9 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 
 	account_id := os.Getenv("HOSTINGDE_ACCOUNT_ID")
 	auth_token := os.Getenv("HOSTINGDE_AUTH_TOKEN")
+	fallback_auth_token := os.Getenv("HOSTINGDE_FALLBACK_AUTH_TOKEN")
 	base_url := os.Getenv("HOSTINGDE_BASE_URL")
+	signing_secret := os.Getenv("HOSTINGDE_SIGNING_SECRET")
 
 	if !config.AccountId.IsNull() {
 		account_id = config.AccountId.ValueString()
@@ -110,13 +433,33 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 		auth_token = config.AuthToken.ValueString()
 	}
 
+	if !config.FallbackAuthToken.IsNull() {
+		fallback_auth_token = config.FallbackAuthToken.ValueString()
+	}
+
 	if !config.BaseUrl.IsNull() {
 		base_url = config.BaseUrl.ValueString()
 	}
 
+	if !config.SigningSecret.IsNull() {
+		signing_secret = config.SigningSecret.ValueString()
+	}
+
 	// Default for API Base URL
 	if base_url == "" {
-		base_url = defaultBaseURL
+		base_url = defaultServiceURLs[serviceDNS]
+	}
+
+	// The account and domain services default to their production endpoints.
+	serviceURLs := map[string]string{
+		serviceAccount: os.Getenv("HOSTINGDE_ACCOUNT_BASE_URL"),
+		serviceDomain:  os.Getenv("HOSTINGDE_DOMAIN_BASE_URL"),
+	}
+	if !config.AccountBaseUrl.IsNull() {
+		serviceURLs[serviceAccount] = config.AccountBaseUrl.ValueString()
+	}
+	if !config.DomainBaseUrl.IsNull() {
+		serviceURLs[serviceDomain] = config.DomainBaseUrl.ValueString()
 	}
 
 	// If any of the expected configurations are missing, return
@@ -131,6 +474,27 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	for _, baseURL := range []struct {
+		attribute string
+		value     string
+	}{
+		{"base_url", base_url},
+		{"account_base_url", serviceURLs[serviceAccount]},
+		{"domain_base_url", serviceURLs[serviceDomain]},
+	} {
+		if baseURL.value == "" {
+			continue
+		}
+		if err := validateBaseURL(baseURL.value); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(baseURL.attribute),
+				"Invalid hosting.de API base URL",
+				"The provider cannot create the hosting.de API client: "+err.Error()+". "+
+					"Set "+baseURL.attribute+" to an absolute http or https URL, such as https://secure.hosting.de/api/dns/v1/json.",
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -144,6 +508,100 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 
 	// Create a new hosting.de client using the configuration values
 	client := NewClient(&account_id, &auth_token, &base_url)
+	client.fallbackAuthToken = fallback_auth_token
+	for service, url := range serviceURLs {
+		if url != "" {
+			client.serviceURLs[service] = url
+		}
+	}
+	client.HTTPClient.Transport = newTransport(
+		config.DisableHTTP2.ValueBool(),
+		config.DisableKeepAlives.ValueBool(),
+		time.Duration(config.KeepAlive.ValueInt64())*time.Second,
+	)
+	if !config.RequestTimeout.IsNull() {
+		client.HTTPClient.Timeout = time.Duration(config.RequestTimeout.ValueInt64()) * time.Second
+	}
+	if !config.ErrorVerbosity.IsNull() {
+		client.errorVerbosity = config.ErrorVerbosity.ValueString()
+	}
+	if !config.IDNDisplay.IsNull() {
+		client.idnDisplay = config.IDNDisplay.ValueString()
+	}
+	if !config.NormalizationWarning.IsNull() {
+		client.normalizationWarning = config.NormalizationWarning.ValueBool()
+	}
+	if !config.NormalizeRecordTypes.IsNull() {
+		var recordTypes []string
+		resp.Diagnostics.Append(config.NormalizeRecordTypes.ElementsAs(ctx, &recordTypes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		client.normalizeRecordTypes = map[string]bool{}
+		for _, recordType := range recordTypes {
+			client.normalizeRecordTypes[recordType] = true
+		}
+	}
+	client.commentPrefix = config.CommentPrefix.ValueString()
+	client.truncateComments = config.TruncateComments.ValueBool()
+	client.defaultRecordType = config.DefaultRecordType.ValueString()
+	client.autoCreateZone = config.AutoCreateZone.ValueBool()
+	client.changeSummary = config.ChangeSummary.ValueBool()
+	client.zoneReadCache = config.ZoneReadCache.ValueBool()
+	if artifactPath := config.ChangeArtifactPath.ValueString(); artifactPath != "" {
+		artifact, err := newChangeArtifact(artifactPath)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("change_artifact_path"),
+				"Could not write change artifact",
+				"The record changes are not written to a change artifact: "+err.Error(),
+			)
+		}
+		client.changeArtifact = artifact
+	}
+	client.logMutations = config.LogMutations.ValueBool()
+	client.strictValidation = config.StrictValidation.ValueBool()
+	if !config.TXTSizeThreshold.IsNull() {
+		client.txtSizeThreshold = int(config.TXTSizeThreshold.ValueInt64())
+	}
+	client.convertSPFToTXT = config.ConvertSPFToTXT.ValueBool()
+	if !config.PageConcurrency.IsNull() {
+		client.pageConcurrency = int(config.PageConcurrency.ValueInt64())
+	}
+	if !config.ReadAfterWriteRetries.IsNull() {
+		client.readAfterWriteRetries = int(config.ReadAfterWriteRetries.ValueInt64())
+	}
+	if !config.ReadAfterWriteDelay.IsNull() {
+		client.readAfterWriteDelay = time.Duration(config.ReadAfterWriteDelay.ValueInt64()) * time.Millisecond
+	}
+	client.validateTargetsResolve = config.ValidateTargetsResolve.ValueBool()
+	client.deleteGracePeriod = time.Duration(config.DeleteGracePeriod.ValueInt64()) * time.Second
+	if !config.MaxBatchSize.IsNull() {
+		client.maxBatchSize = int(config.MaxBatchSize.ValueInt64())
+	}
+	if !config.MaxRetries.IsNull() {
+		client.maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.ConflictRetries.IsNull() {
+		client.conflictRetries = int(config.ConflictRetries.ValueInt64())
+	}
+	client.batchWindow = time.Duration(config.BatchWindow.ValueInt64()) * time.Millisecond
+	client.zoneLockTimeout = time.Duration(config.ZoneLockTimeout.ValueInt64()) * time.Second
+	client.maintenanceTimeout = time.Duration(config.MaintenanceTimeout.ValueInt64()) * time.Second
+	if !config.PendingTimeout.IsNull() {
+		client.pendingTimeout = time.Duration(config.PendingTimeout.ValueInt64()) * time.Second
+	}
+	client.defaultTTL = config.DefaultTTL.ValueInt64()
+	client.zoneRecordLimit = int(config.ZoneRecordLimit.ValueInt64())
+	client.signer = newRequestSigner(signing_secret)
+	if signing_secret != "" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("signing_secret"),
+			"Request signing not supported",
+			"The hosting.de API does not support request signing yet, so requests are not signed with the signing_secret. "+
+				"Requests are authenticated by the auth token only.",
+		)
+	}
 
 	// Make the hosting.de client available during DataSource and Resource
 	// type Configure methods.
@@ -155,7 +613,15 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 
 // DataSources defines the data sources implemented in the provider.
 func (p *hostingdeProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewRecordCountDataSource,
+		NewRecordDataSource,
+		NewRecordIDsDataSource,
+		NewRecordsDataSource,
+		NewZoneDataSource,
+		NewZoneFullDataSource,
+		NewZoneSOADataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
@@ -163,5 +629,32 @@ func (p *hostingdeProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewZoneResource,
 		NewRecordResource,
+		NewRecordAbsentResource,
+		NewRecordContentReplaceResource,
+		NewZoneTTLResource,
+		NewMultiZoneRecordsResource,
+		NewZoneRecordsResource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *hostingdeProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewValidateRecordFunction,
+		NewToHexFunction,
+		NewToBase64Function,
 	}
 }
+
+// validateBaseURL checks that a base URL of the hosting.de API is an absolute
+// http or https URL, e.g. of a staging or reseller gateway.
+func validateBaseURL(baseURL string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %w", baseURL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute http or https URL", baseURL)
+	}
+	return nil
+}
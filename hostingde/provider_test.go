@@ -1,8 +1,16 @@
 package hostingde
 
 import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -26,3 +34,110 @@ var (
 		"hostingde": providerserver.NewProtocol6WithError(New()),
 	}
 )
+
+// newTestConfig returns a configuration for the schema of the given state,
+// with all attributes null except for the given values.
+func newTestConfig(t *testing.T, state tfsdk.State, values map[string]any) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)
+	for name, value := range values {
+		diags := state.SetAttribute(ctx, path.Root(name), value)
+		if diags.HasError() {
+			t.Fatalf("setting %s: %v", name, diags)
+		}
+	}
+
+	return tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+}
+
+func TestProviderConfigureEnvironment(t *testing.T) {
+	ctx := context.Background()
+	p := &hostingdeProvider{}
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	for _, env := range []string{"HOSTINGDE_FALLBACK_AUTH_TOKEN", "HOSTINGDE_BASE_URL", "HOSTINGDE_ACCOUNT_BASE_URL", "HOSTINGDE_DOMAIN_BASE_URL", "HOSTINGDE_SIGNING_SECRET"} {
+		t.Setenv(env, "")
+	}
+
+	configure := func(values map[string]any) *provider.ConfigureResponse {
+		t.Helper()
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{
+			Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values),
+		}, resp)
+		return resp
+	}
+
+	// Without a token in the configuration or the environment, configuring
+	// the provider fails.
+	t.Setenv("HOSTINGDE_AUTH_TOKEN", "")
+	t.Setenv("HOSTINGDE_ACCOUNT_ID", "")
+	resp := configure(map[string]any{"auth_token": types.StringNull()})
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Missing hosting.de API auth token" {
+		t.Errorf("expected a missing auth token error, got %v", resp.Diagnostics)
+	}
+
+	// The environment variables are used for unset attributes.
+	t.Setenv("HOSTINGDE_AUTH_TOKEN", "env-token")
+	t.Setenv("HOSTINGDE_ACCOUNT_ID", "env-account")
+	resp = configure(map[string]any{"auth_token": types.StringNull()})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	client := resp.ResourceData.(*Client)
+	if client.authToken != "env-token" || client.accountId != "env-account" {
+		t.Errorf("expected the token and account ID from the environment, got %q and %q", client.authToken, client.accountId)
+	}
+
+	// The configuration takes precedence over the environment.
+	resp = configure(map[string]any{"auth_token": "config-token", "account_id": "config-account"})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	client = resp.ResourceData.(*Client)
+	if client.authToken != "config-token" || client.accountId != "config-account" {
+		t.Errorf("expected the token and account ID from the configuration, got %q and %q", client.authToken, client.accountId)
+	}
+}
+
+func TestProviderConfigureBaseURL(t *testing.T) {
+	ctx := context.Background()
+	p := &hostingdeProvider{}
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	for _, env := range []string{"HOSTINGDE_BASE_URL", "HOSTINGDE_ACCOUNT_BASE_URL", "HOSTINGDE_DOMAIN_BASE_URL", "HOSTINGDE_SIGNING_SECRET"} {
+		t.Setenv(env, "")
+	}
+
+	for _, test := range []struct {
+		baseURL string
+		valid   bool
+	}{
+		{"https://staging.example.test/api/dns/v1/json", true},
+		{"http://127.0.0.1:8080", true},
+		{"staging.example.test/api/dns/v1/json", false},
+		{"ftp://staging.example.test", false},
+		{"https://", false},
+	} {
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{
+			Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+				"auth_token": "token",
+				"base_url":   test.baseURL,
+			}),
+		}, resp)
+		if test.valid {
+			if resp.Diagnostics.HasError() {
+				t.Errorf("%s: unexpected error: %v", test.baseURL, resp.Diagnostics)
+			} else if client := resp.ResourceData.(*Client); client.endpoint(serviceDNS, "zonesFind") != test.baseURL+"/zonesFind" {
+				t.Errorf("%s: expected requests to be sent to the base URL, got %s", test.baseURL, client.endpoint(serviceDNS, "zonesFind"))
+			}
+			continue
+		}
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid hosting.de API base URL" {
+			t.Errorf("%s: expected an invalid base URL error, got %v", test.baseURL, resp.Diagnostics)
+		}
+	}
+}
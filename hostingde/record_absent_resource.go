@@ -0,0 +1,212 @@
+package hostingde
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &recordAbsentResource{}
+	_ resource.ResourceWithConfigure = &recordAbsentResource{}
+)
+
+// NewRecordAbsentResource is a helper function to simplify the provider implementation.
+func NewRecordAbsentResource() resource.Resource {
+	return &recordAbsentResource{}
+}
+
+// recordAbsentResource ensures that matching DNS records do not exist.
+type recordAbsentResource struct {
+	client *Client
+}
+
+// recordAbsentResourceModel maps the record_absent resource schema data.
+type recordAbsentResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	ZoneID  types.String `tfsdk:"zone_id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Content types.String `tfsdk:"content"`
+}
+
+// Metadata returns the resource type name.
+func (r *recordAbsentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_absent"
+}
+
+// Schema defines the schema for the resource.
+func (r *recordAbsentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ensures that no DNS record with the given name and type (and optionally content) exists in a zone. " +
+			"Matching records are deleted on create. If a matching record reappears, the next plan re-creates this " +
+			"resource, which deletes the record again. Destroying this resource does not change any records.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the form zone_id/name/type.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone to remove the records from.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the records that must not exist. Example: mail.example.com.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the records that must not exist.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the records that must not exist. If omitted, all records with matching name and type are removed.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Create deletes all matching records.
+func (r *recordAbsentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Retrieve values from plan
+	var plan recordAbsentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock, err := r.client.lockZone(ctx, plan.ZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Locking hosting.de DNS zone", err.Error())
+		return
+	}
+	defer unlock()
+
+	deleted, err := r.client.ensureRecordsAbsent(
+		ctx,
+		plan.ZoneID.ValueString(),
+		plan.Name.ValueString(),
+		plan.Type.ValueString(),
+		plan.Content.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Records",
+			"Could not delete records, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	for _, record := range deleted {
+		tflog.Info(ctx, "Deleted record to ensure absence", map[string]any{
+			"id":      record.ID,
+			"name":    record.Name,
+			"type":    record.Type,
+			"content": record.Content,
+		})
+	}
+
+	plan.ID = types.StringValue(strings.Join([]string{
+		plan.ZoneID.ValueString(),
+		plan.Name.ValueString(),
+		plan.Type.ValueString(),
+	}, "/"))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read checks whether a matching record reappeared. If so, the resource is
+// removed from state, so the next apply deletes the records again.
+func (r *recordAbsentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Get current state
+	var state recordAbsentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := r.client.findRecords(
+		ctx,
+		state.ZoneID.ValueString(),
+		state.Name.ValueString(),
+		state.Type.ValueString(),
+		state.Content.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records for "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(records) > 0 {
+		tflog.Info(ctx, "Record reappeared, planning deletion", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+// Update is never called, as all attributes require replacement.
+func (r *recordAbsentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var plan recordAbsentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete only removes the resource from state, records are left untouched.
+func (r *recordAbsentResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *recordAbsentResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,137 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRecordAbsentResourceReappear(t *testing.T) {
+	legacyMX := DNSRecord{ID: "record-id", ZoneID: "zone-id", Name: "example.test", Type: "MX", Content: "legacy.example.test"}
+
+	existing := []DNSRecord{legacyMX}
+	var deleted []DNSRecord
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = existing
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			deleted = append(deleted, updateRequest.RecordsToDelete...)
+			existing = nil
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordAbsentResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":      types.StringUnknown(),
+		"zone_id": "zone-id",
+		"name":    "example.test",
+		"type":    "MX",
+	})
+	create := func() *fwresource.CreateResponse {
+		t.Helper()
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		return resp
+	}
+	read := func(state tfsdk.State) *fwresource.ReadResponse {
+		t.Helper()
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		return resp
+	}
+
+	createResp := create()
+	if len(deleted) != 1 || deleted[0].ID != legacyMX.ID {
+		t.Fatalf("expected record %s to be deleted, got %v", legacyMX.ID, deleted)
+	}
+
+	// The resource stays in state while the record is absent.
+	if readResp := read(createResp.State); readResp.State.Raw.IsNull() {
+		t.Fatal("expected the resource to stay in state")
+	}
+
+	// A reappearing record removes the resource from state, and creating it
+	// again deletes the record again.
+	existing = []DNSRecord{legacyMX}
+	if readResp := read(createResp.State); !readResp.State.Raw.IsNull() {
+		t.Fatal("expected the resource to be removed from state")
+	}
+	deleted = nil
+	createResp = create()
+	if len(deleted) != 1 || deleted[0].ID != legacyMX.ID || len(existing) != 0 {
+		t.Fatalf("expected reappeared record %s to be deleted again, got %v", legacyMX.ID, deleted)
+	}
+	var state recordAbsentResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "zone-id/example.test/MX" {
+		t.Errorf("unexpected ID %s", state.ID)
+	}
+}
+
+func TestAccRecordAbsentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a record, then ensure its absence
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example3.test"
+  type = "NATIVE"
+  email = "hostmaster@example3.test"
+}
+resource "hostingde_record" "legacy_mx" {
+  zone_id = hostingde_zone.test.id
+  name = "example3.test"
+  type = "MX"
+  content = "legacy.example3.test"
+  priority = 10
+}
+`,
+			},
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example3.test"
+  type = "NATIVE"
+  email = "hostmaster@example3.test"
+}
+resource "hostingde_record_absent" "legacy_mx" {
+  zone_id = hostingde_zone.test.id
+  name = "example3.test"
+  type = "MX"
+  content = "legacy.example3.test"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("hostingde_record_absent.legacy_mx", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package hostingde
+
+import (
+	"context"
+	"sync"
+)
+
+// zoneRecordsCacheEntry holds the records of a zone, by ID, once loaded. Its
+// mutex serializes loading, so concurrent reads of a zone cause a single
+// request.
+type zoneRecordsCacheEntry struct {
+	mu      sync.Mutex
+	loaded  bool
+	records map[string]DNSRecord
+}
+
+// getCachedRecord returns the record with the given ID like getRecord. If
+// zone_read_cache is enabled, all records of the zone are listed on the first
+// read of a record in the zone and later reads are served from them. Records
+// not found in the cached zone, e.g. records moved from another zone, are
+// looked up individually.
+func (c *Client) getCachedRecord(ctx context.Context, zoneId, recordId string) (DNSRecord, error) {
+	if !c.zoneReadCache || zoneId == "" {
+		return c.getRecord(ctx, recordId)
+	}
+
+	c.zoneRecordsMu.Lock()
+	if c.zoneRecords == nil {
+		c.zoneRecords = map[string]*zoneRecordsCacheEntry{}
+	}
+	entry, ok := c.zoneRecords[zoneId]
+	if !ok {
+		entry = &zoneRecordsCacheEntry{}
+		c.zoneRecords[zoneId] = entry
+	}
+	c.zoneRecordsMu.Unlock()
+
+	entry.mu.Lock()
+	if !entry.loaded {
+		records, err := c.listAllRecords(ctx, RecordsFindRequest{
+			BaseRequest: c.newBaseRequest(ctx),
+			Filter:      recordsFilter(zoneId, "", ""),
+		})
+		if err != nil {
+			entry.mu.Unlock()
+			return DNSRecord{}, err
+		}
+		entry.records = make(map[string]DNSRecord, len(records))
+		for _, record := range records {
+			entry.records[record.ID] = record
+		}
+		entry.loaded = true
+	}
+	record, ok := entry.records[recordId]
+	entry.mu.Unlock()
+
+	if !ok {
+		return c.getRecord(ctx, recordId)
+	}
+	return record, nil
+}
+
+// invalidateZoneRecords drops the cached records of a zone, or of all zones
+// if zoneId is empty, after they were changed.
+func (c *Client) invalidateZoneRecords(zoneId string) {
+	c.zoneRecordsMu.Lock()
+	defer c.zoneRecordsMu.Unlock()
+
+	if zoneId == "" {
+		c.zoneRecords = nil
+		return
+	}
+	delete(c.zoneRecords, zoneId)
+}
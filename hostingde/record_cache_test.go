@@ -0,0 +1,108 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestGetCachedRecord(t *testing.T) {
+	stored := map[string][]DNSRecord{
+		"zone-one": {
+			{ID: "1", ZoneID: "zone-one", Name: "www.one.test", Type: "A", Content: "192.0.2.1"},
+			{ID: "2", ZoneID: "zone-one", Name: "mail.one.test", Type: "A", Content: "192.0.2.2"},
+		},
+		"zone-two": {
+			{ID: "3", ZoneID: "zone-two", Name: "www.two.test", Type: "A", Content: "192.0.2.3"},
+		},
+	}
+	var requests []string
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			if findRequest.Filter.Field == "RecordId" {
+				requests = append(requests, "record "+findRequest.Filter.Value)
+				for _, records := range stored {
+					for _, record := range records {
+						if record.ID == findRequest.Filter.Value {
+							findResponse.Response.Data = append(findResponse.Response.Data, record)
+						}
+					}
+				}
+			} else {
+				zoneId := findRequest.Filter.SubFilter[0].Value
+				requests = append(requests, "zone "+zoneId)
+				findResponse.Response.Data = stored[zoneId]
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+		"recordsUpdate": func(_ []byte) any {
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+
+	read := func(zoneId, recordId string) DNSRecord {
+		t.Helper()
+
+		record, err := client.getCachedRecord(context.Background(), zoneId, recordId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.ID != recordId {
+			t.Fatalf("expected record %s, got %v", recordId, record)
+		}
+		return record
+	}
+
+	// Without the cache, every record is read individually.
+	read("zone-one", "1")
+	read("zone-one", "2")
+	if fmt.Sprint(requests) != "[record 1 record 2]" {
+		t.Errorf("expected individual reads by default, got %v", requests)
+	}
+
+	// With the cache, the records of each zone are listed once.
+	client.zoneReadCache = true
+	requests = nil
+	for range 2 {
+		if record := read("zone-one", "2"); record.Content != "192.0.2.2" {
+			t.Errorf("expected the content of record 2, got %v", record)
+		}
+		read("zone-one", "1")
+		read("zone-two", "3")
+	}
+	if fmt.Sprint(requests) != "[zone zone-one zone zone-two]" {
+		t.Errorf("expected a single request per zone, got %v", requests)
+	}
+
+	// Records not in the cached zone are read individually.
+	requests = nil
+	read("zone-one", "3")
+	if _, err := client.getCachedRecord(context.Background(), "zone-one", "missing"); err == nil {
+		t.Errorf("expected an error for a missing record")
+	}
+	if fmt.Sprint(requests) != "[record 3 record missing]" {
+		t.Errorf("expected records outside the cached zone to be read individually, got %v", requests)
+	}
+
+	// Changing a zone invalidates its cached records only.
+	stored["zone-one"][0].Content = "192.0.2.10"
+	if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}, ZoneConfigId: "zone-one"}); err != nil {
+		t.Fatal(err)
+	}
+	requests = nil
+	if record := read("zone-one", "1"); record.Content != "192.0.2.10" {
+		t.Errorf("expected the changed content after an update, got %v", record)
+	}
+	read("zone-two", "3")
+	if fmt.Sprint(requests) != "[zone zone-one]" {
+		t.Errorf("expected the changed zone to be listed again, got %v", requests)
+	}
+}
@@ -0,0 +1,903 @@
+package hostingde
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/idna"
+)
+
+// structuredAttribute points to a structured content attribute of a record
+// model. Exactly one of int64Value and stringValue is set.
+type structuredAttribute struct {
+	name        string
+	int64Value  *types.Int64
+	stringValue *types.String
+}
+
+func (a structuredAttribute) isNull() bool {
+	if a.int64Value != nil {
+		return a.int64Value.IsNull()
+	}
+	return a.stringValue.IsNull()
+}
+
+func (a structuredAttribute) isUnknown() bool {
+	if a.int64Value != nil {
+		return a.int64Value.IsUnknown()
+	}
+	return a.stringValue.IsUnknown()
+}
+
+// set copies the value of the same attribute of another model.
+func (a structuredAttribute) set(other structuredAttribute) {
+	if a.int64Value != nil {
+		*a.int64Value = *other.int64Value
+		return
+	}
+	*a.stringValue = *other.stringValue
+}
+
+// reset sets the attribute to null or unknown.
+func (a structuredAttribute) reset(unknown bool) {
+	switch {
+	case a.int64Value != nil && unknown:
+		*a.int64Value = types.Int64Unknown()
+	case a.int64Value != nil:
+		*a.int64Value = types.Int64Null()
+	case unknown:
+		*a.stringValue = types.StringUnknown()
+	default:
+		*a.stringValue = types.StringNull()
+	}
+}
+
+// structuredContentType assembles and parses the content of a record type
+// from and to structured attributes.
+type structuredContentType struct {
+	// attributes returns the structured attributes of the record type in m.
+	attributes func(m *recordResourceModel) []structuredAttribute
+	// assemble returns the content of the structured attributes, which are
+	// all known and not null.
+	assemble func(m *recordResourceModel) (string, error)
+	// parse sets the structured attributes from the content.
+	parse func(m *recordResourceModel, content string) error
+}
+
+// structuredContentTypes lists the record types supporting structured content.
+var structuredContentTypes = map[string]structuredContentType{
+	"DS": {
+		attributes: func(m *recordResourceModel) []structuredAttribute {
+			return []structuredAttribute{
+				{name: "ds_key_tag", int64Value: &m.DSKeyTag},
+				{name: "ds_algorithm", int64Value: &m.DSAlgorithm},
+				{name: "ds_digest_type", int64Value: &m.DSDigestType},
+				{name: "ds_digest", stringValue: &m.DSDigest},
+			}
+		},
+		assemble: func(m *recordResourceModel) (string, error) {
+			if err := validateDSDigest(m.DSDigestType.ValueInt64(), m.DSDigest.ValueString()); err != nil {
+				return "", err
+			}
+			return dsContent{
+				KeyTag:     m.DSKeyTag.ValueInt64(),
+				Algorithm:  m.DSAlgorithm.ValueInt64(),
+				DigestType: m.DSDigestType.ValueInt64(),
+				Digest:     m.DSDigest.ValueString(),
+			}.String(), nil
+		},
+		parse: func(m *recordResourceModel, content string) error {
+			parsed, err := parseDSContent(content)
+			if err != nil {
+				return err
+			}
+			m.DSKeyTag = types.Int64Value(parsed.KeyTag)
+			m.DSAlgorithm = types.Int64Value(parsed.Algorithm)
+			m.DSDigestType = types.Int64Value(parsed.DigestType)
+			m.DSDigest = types.StringValue(parsed.Digest)
+			return nil
+		},
+	},
+	"TLSA": {
+		attributes: func(m *recordResourceModel) []structuredAttribute {
+			return []structuredAttribute{
+				{name: "tlsa_usage", int64Value: &m.TLSAUsage},
+				{name: "tlsa_selector", int64Value: &m.TLSASelector},
+				{name: "tlsa_matching_type", int64Value: &m.TLSAMatchingType},
+				{name: "tlsa_data_base64", stringValue: &m.TLSADataBase64},
+			}
+		},
+		assemble: func(m *recordResourceModel) (string, error) {
+			data, err := decodeBase64(m.TLSADataBase64.ValueString())
+			if err != nil {
+				return "", err
+			}
+			if err := validateHashLength("matching type", m.TLSAMatchingType.ValueInt64(), tlsaDataLengths, data); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d %d %d %s", m.TLSAUsage.ValueInt64(), m.TLSASelector.ValueInt64(), m.TLSAMatchingType.ValueInt64(), hex.EncodeToString(data)), nil
+		},
+		parse: func(m *recordResourceModel, content string) error {
+			numbers, data, err := parseHexContent(content, "usage", "selector", "matching type")
+			if err != nil {
+				return err
+			}
+			m.TLSAUsage = types.Int64Value(numbers[0])
+			m.TLSASelector = types.Int64Value(numbers[1])
+			m.TLSAMatchingType = types.Int64Value(numbers[2])
+			m.TLSADataBase64 = types.StringValue(base64.StdEncoding.EncodeToString(data))
+			return nil
+		},
+	},
+	"SSHFP": {
+		attributes: func(m *recordResourceModel) []structuredAttribute {
+			return []structuredAttribute{
+				{name: "sshfp_algorithm", int64Value: &m.SSHFPAlgorithm},
+				{name: "sshfp_fingerprint_type", int64Value: &m.SSHFPFingerprintType},
+				{name: "sshfp_fingerprint_base64", stringValue: &m.SSHFPFingerprintBase64},
+			}
+		},
+		assemble: func(m *recordResourceModel) (string, error) {
+			data, err := decodeBase64(m.SSHFPFingerprintBase64.ValueString())
+			if err != nil {
+				return "", err
+			}
+			if err := validateHashLength("fingerprint type", m.SSHFPFingerprintType.ValueInt64(), sshfpFingerprintLengths, data); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d %d %s", m.SSHFPAlgorithm.ValueInt64(), m.SSHFPFingerprintType.ValueInt64(), hex.EncodeToString(data)), nil
+		},
+		parse: func(m *recordResourceModel, content string) error {
+			numbers, data, err := parseHexContent(content, "algorithm", "fingerprint type")
+			if err != nil {
+				return err
+			}
+			m.SSHFPAlgorithm = types.Int64Value(numbers[0])
+			m.SSHFPFingerprintType = types.Int64Value(numbers[1])
+			m.SSHFPFingerprintBase64 = types.StringValue(base64.StdEncoding.EncodeToString(data))
+			return nil
+		},
+	},
+}
+
+// dsDigestLengths maps DS digest types to the length of their hex encoded digest.
+// https://www.iana.org/assignments/ds-rr-types/ds-rr-types.xhtml
+var dsDigestLengths = map[int64]int{
+	1: 40, // SHA-1
+	2: 64, // SHA-256
+	4: 96, // SHA-384
+}
+
+// tlsaDataLengths maps TLSA matching types to the length of the hashed data
+// in bytes. Matching type 0 holds the full certificate or key.
+var tlsaDataLengths = map[int64]int{
+	1: 32, // SHA-256
+	2: 64, // SHA-512
+}
+
+// sshfpFingerprintLengths maps SSHFP fingerprint types to the length of the
+// fingerprint in bytes.
+var sshfpFingerprintLengths = map[int64]int{
+	1: 20, // SHA-1
+	2: 32, // SHA-256
+}
+
+// caaTags are the CAA property tags accepted in record content (RFC 8659).
+var caaTags = []string{"issue", "issuewild", "iodef"}
+
+// caaContent is the content of a CAA record: flags tag "value".
+type caaContent struct {
+	Flags int64
+	Tag   string
+	Value string
+}
+
+// parseCAAContent parses and validates the content of a CAA record. The
+// value must be quoted and may contain spaces.
+func parseCAAContent(content string) (caaContent, error) {
+	fields := strings.SplitN(strings.TrimSpace(content), " ", 3)
+	if len(fields) < 3 {
+		return caaContent{}, fmt.Errorf("expected flags, tag and value, got %q", content)
+	}
+
+	flags, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || flags < 0 || flags > 255 {
+		return caaContent{}, fmt.Errorf("flags must be a number between 0 and 255, got %q", fields[0])
+	}
+	tag := strings.ToLower(fields[1])
+	if !slices.Contains(caaTags, tag) {
+		return caaContent{}, fmt.Errorf("tag must be one of %s, got %q", strings.Join(caaTags, ", "), fields[1])
+	}
+	value := strings.TrimSpace(fields[2])
+	if len(value) < 2 || !strings.HasPrefix(value, "\"") || !strings.HasSuffix(value, "\"") {
+		return caaContent{}, fmt.Errorf("value must be quoted, e.g. \"letsencrypt.org\", got %s", value)
+	}
+
+	return caaContent{Flags: flags, Tag: tag, Value: value[1 : len(value)-1]}, nil
+}
+
+// dsContent is the content of a DS record: key-tag algorithm digest-type digest.
+type dsContent struct {
+	KeyTag     int64
+	Algorithm  int64
+	DigestType int64
+	Digest     string
+}
+
+// String assembles the record content.
+func (c dsContent) String() string {
+	return fmt.Sprintf("%d %d %d %s", c.KeyTag, c.Algorithm, c.DigestType, c.Digest)
+}
+
+// parseDSContent parses and validates the content of a DS record.
+func parseDSContent(content string) (dsContent, error) {
+	fields := strings.Fields(content)
+	if len(fields) < 4 {
+		return dsContent{}, fmt.Errorf("expected key tag, algorithm, digest type and digest, got %q", content)
+	}
+
+	var parsed dsContent
+	for i, field := range []struct {
+		name  string
+		value *int64
+		max   int64
+	}{
+		{"key tag", &parsed.KeyTag, 65535},
+		{"algorithm", &parsed.Algorithm, 255},
+		{"digest type", &parsed.DigestType, 255},
+	} {
+		value, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil || value < 0 || value > field.max {
+			return dsContent{}, fmt.Errorf("%s must be a number between 0 and %d, got %q", field.name, field.max, fields[i])
+		}
+		*field.value = value
+	}
+	// The digest may be split into several blocks.
+	parsed.Digest = strings.Join(fields[3:], "")
+
+	if err := validateDSDigest(parsed.DigestType, parsed.Digest); err != nil {
+		return dsContent{}, err
+	}
+	return parsed, nil
+}
+
+// validateDSDigest checks that a DS digest is hex encoded and has the length
+// of the digest type.
+func validateDSDigest(digestType int64, digest string) error {
+	if _, err := hex.DecodeString(digest); err != nil {
+		return fmt.Errorf("digest must be hex encoded: %s", err)
+	}
+	if length, ok := dsDigestLengths[digestType]; ok && len(digest) != length {
+		return fmt.Errorf("digest of digest type %d must have %d hex characters, got %d", digestType, length, len(digest))
+	}
+	return nil
+}
+
+// parseHexContent parses record content made of numeric fields followed by
+// hex encoded data, which may be split into several blocks.
+func parseHexContent(content string, names ...string) ([]int64, []byte, error) {
+	fields := strings.Fields(content)
+	if len(fields) <= len(names) {
+		return nil, nil, fmt.Errorf("expected %s and data, got %q", strings.Join(names, ", "), content)
+	}
+
+	numbers := make([]int64, 0, len(names))
+	for i, name := range names {
+		value, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil || value < 0 || value > 255 {
+			return nil, nil, fmt.Errorf("%s must be a number between 0 and 255, got %q", name, fields[i])
+		}
+		numbers = append(numbers, value)
+	}
+
+	data, err := hex.DecodeString(strings.Join(fields[len(names):], ""))
+	if err != nil {
+		return nil, nil, fmt.Errorf("data must be hex encoded: %s", err)
+	}
+	return numbers, data, nil
+}
+
+// decodeBase64 decodes standard base64 with padding, ignoring whitespace.
+func decodeBase64(value string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+	if err != nil {
+		return nil, fmt.Errorf("value must be base64 encoded: %s", err)
+	}
+	return data, nil
+}
+
+// validateHashLength checks that data has the length of its hash type, if
+// the hash type has a fixed length.
+func validateHashLength(name string, hashType int64, lengths map[int64]int, data []byte) error {
+	if length, ok := lengths[hashType]; ok && len(data) != length {
+		return fmt.Errorf("data of %s %d must have %d bytes, got %d", name, hashType, length, len(data))
+	}
+	return nil
+}
+
+// configuredStructuredType returns the record type whose structured
+// attributes are set in m, or an empty string if none are set.
+func (m *recordResourceModel) configuredStructuredType() string {
+	recordTypes := make([]string, 0, len(structuredContentTypes))
+	for recordType := range structuredContentTypes {
+		recordTypes = append(recordTypes, recordType)
+	}
+	sort.Strings(recordTypes)
+
+	for _, recordType := range recordTypes {
+		for _, attribute := range structuredContentTypes[recordType].attributes(m) {
+			if !attribute.isNull() {
+				return recordType
+			}
+		}
+	}
+	return ""
+}
+
+// hasStructuredFields reports whether any structured attribute is set.
+func (m *recordResourceModel) hasStructuredFields() bool {
+	return m.configuredStructuredType() != ""
+}
+
+// structuredContent assembles the record content from the structured
+// attributes. It returns false if the content cannot be assembled, because
+// structured attributes are not set, not yet known or invalid.
+func (m *recordResourceModel) structuredContent() (string, bool) {
+	structuredType, ok := structuredContentTypes[m.Type.ValueString()]
+	if !ok || m.configuredStructuredType() != m.Type.ValueString() {
+		return "", false
+	}
+	for _, attribute := range structuredType.attributes(m) {
+		if attribute.isNull() || attribute.isUnknown() {
+			return "", false
+		}
+	}
+
+	content, err := structuredType.assemble(m)
+	if err != nil {
+		return "", false
+	}
+	return content, true
+}
+
+// copyStructuredContent copies all structured attributes from other.
+func (m *recordResourceModel) copyStructuredContent(other *recordResourceModel) {
+	for _, structuredType := range structuredContentTypes {
+		attributes := structuredType.attributes(m)
+		for i, attribute := range structuredType.attributes(other) {
+			attributes[i].set(attribute)
+		}
+	}
+}
+
+// setStructuredContent sets the structured attributes parsed from the record
+// content. Attributes not applicable to the record type are set to null, and
+// unknown if the content is not known yet.
+func (m *recordResourceModel) setStructuredContent() {
+	unknown := m.Type.IsUnknown() || m.Content.IsUnknown()
+	for _, structuredType := range structuredContentTypes {
+		for _, attribute := range structuredType.attributes(m) {
+			attribute.reset(unknown)
+		}
+	}
+	if unknown {
+		return
+	}
+
+	structuredType, ok := structuredContentTypes[m.Type.ValueString()]
+	if !ok {
+		return
+	}
+	if err := structuredType.parse(m, m.Content.ValueString()); err != nil {
+		// Content that cannot be parsed is only available as content.
+		for _, attribute := range structuredType.attributes(m) {
+			attribute.reset(false)
+		}
+	}
+}
+
+// validateStructuredContent checks that the record content is either set
+// directly or, for supported types, by all structured attributes.
+func validateStructuredContent(config recordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.Type.IsUnknown() {
+		return diags
+	}
+
+	configuredType := config.configuredStructuredType()
+	if configuredType == "" {
+		if config.Content.IsNull() {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Missing attribute",
+				"Setting content is required, unless the content is set by the structured attributes of its record type.",
+			)
+		}
+		return diags
+	}
+
+	prefix := strings.ToLower(configuredType) + "_*"
+	if config.Type.ValueString() != configuredType {
+		diags.AddAttributeError(
+			path.Root("type"),
+			"Unexpected combination of attributes",
+			"The "+prefix+" attributes are only relevant for records of type "+configuredType+". "+
+				"Please remove them from the resource or change its type.",
+		)
+		return diags
+	}
+	conflict := diag.NewAttributeErrorDiagnostic(
+		path.Root("content"),
+		"Conflicting attributes",
+		"The content of a "+configuredType+" record can either be set by content or by the "+prefix+" attributes, not both. "+
+			"Both may only be set if they describe the same content, as in configuration generated for imported records.",
+	)
+
+	structuredType := structuredContentTypes[configuredType]
+	attributes := structuredType.attributes(&config)
+	complete := true
+	for _, attribute := range attributes {
+		if attribute.isNull() {
+			diags.AddAttributeError(
+				path.Root(attribute.name),
+				"Missing attribute",
+				"Setting "+attribute.name+" is required if the content of a "+configuredType+" record is set by the "+prefix+" attributes.",
+			)
+		}
+		if attribute.isNull() || attribute.isUnknown() {
+			complete = false
+		}
+	}
+	if !config.Content.IsNull() && !complete && !config.Content.IsUnknown() {
+		diags.Append(conflict)
+	}
+
+	// The data is the last structured attribute of all record types.
+	if complete {
+		content, err := structuredType.assemble(&config)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(attributes[len(attributes)-1].name),
+				"Invalid "+configuredType+" content",
+				err.Error(),
+			)
+		} else if !config.Content.IsNull() && !config.Content.IsUnknown() && !sameStructuredContent(configuredType, config.Content.ValueString(), content) {
+			diags.Append(conflict)
+		}
+	}
+
+	return diags
+}
+
+// sameStructuredContent reports whether content describes the same structured
+// content as the assembled content of a record type. The assembled content
+// only consists of numbers and hex data, so it is compared ignoring case.
+func sameStructuredContent(recordType, content, assembled string) bool {
+	if strings.EqualFold(content, assembled) {
+		return true
+	}
+
+	structuredType := structuredContentTypes[recordType]
+	var parsed recordResourceModel
+	if err := structuredType.parse(&parsed, content); err != nil {
+		return false
+	}
+	reassembled, err := structuredType.assemble(&parsed)
+	return err == nil && strings.EqualFold(reassembled, assembled)
+}
+
+// canonicalContent returns the canonical form of the content of A, AAAA, MX
+// and SRV records, which consists of addresses, host names and numbers that
+// may be written in several ways: addresses are written in their shortest
+// form (RFC 5952 for IPv6), host names are compared ignoring case and a
+// trailing dot, and the fields of SRV content are separated by single spaces.
+// The content of other record types, and content that cannot be parsed, is
+// returned as is.
+func canonicalContent(recordType, content string) string {
+	switch recordType {
+	case "A", "AAAA":
+		if address, err := parseAddressContent(recordType, content); err == nil {
+			return address.String()
+		}
+	case "MX":
+		if fields := strings.Fields(content); len(fields) == 1 {
+			return canonicalHostName(fields[0])
+		}
+	case "SRV":
+		if parsed, err := parseSRVContent(content); err == nil {
+			return parsed.String()
+		}
+	}
+	return content
+}
+
+// srvContent is the content of an SRV record: weight port target. The
+// priority is a separate attribute.
+type srvContent struct {
+	Weight int64
+	Port   int64
+	Target string
+}
+
+// String returns the content in canonical form.
+func (c srvContent) String() string {
+	return fmt.Sprintf("%d %d %s", c.Weight, c.Port, canonicalHostName(c.Target))
+}
+
+// parseSRVContent parses and validates the content of an SRV record. The
+// target is a host name, with or without trailing dot, or "." if the service
+// is not available (RFC 2782).
+func parseSRVContent(content string) (srvContent, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 3 {
+		return srvContent{}, fmt.Errorf("expected weight, port and target, got %q", content)
+	}
+
+	var parsed srvContent
+	for i, field := range []struct {
+		name  string
+		value *int64
+	}{
+		{"weight", &parsed.Weight},
+		{"port", &parsed.Port},
+	} {
+		value, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil || value < 0 || value > 65535 {
+			return srvContent{}, fmt.Errorf("%s must be a number between 0 and 65535, got %q", field.name, fields[i])
+		}
+		*field.value = value
+	}
+	target, err := aceHostName(fields[2])
+	if err != nil {
+		return srvContent{}, fmt.Errorf("target %w", err)
+	}
+	if target != "." && !hostName.MatchString(target) {
+		return srvContent{}, fmt.Errorf("target must be a host name, such as sip.example.com., or \".\", got %q", fields[2])
+	}
+	parsed.Target = fields[2]
+
+	return parsed, nil
+}
+
+// parseAddressContent parses the IPv4 address of A records and the IPv6
+// address of AAAA records.
+func parseAddressContent(recordType, content string) (netip.Addr, error) {
+	address, err := netip.ParseAddr(strings.TrimSpace(content))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if address.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("address %q must not have a zone", content)
+	}
+	if recordType == "A" && !address.Is4() {
+		return netip.Addr{}, fmt.Errorf("%q is not an IPv4 address", content)
+	}
+	if recordType == "AAAA" && !address.Is6() {
+		return netip.Addr{}, fmt.Errorf("%q is not an IPv6 address", content)
+	}
+	return address, nil
+}
+
+// canonicalHostName lowercases a host name and removes its trailing dot,
+// except for the root name ".".
+func canonicalHostName(name string) string {
+	if name == "." {
+		return name
+	}
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// sameRecordContent reports whether two contents of a record type only differ
+// in insignificant formatting. The text of TXT and SPF records is compared
+// once quotes and escape sequences are resolved, and internationalized host
+// names are compared in their ASCII compatible form.
+func sameRecordContent(recordType, content, other string) bool {
+	if content == other {
+		return true
+	}
+	if recordType == "TXT" || recordType == "SPF" {
+		return txtText(content) == txtText(other)
+	}
+	return canonicalContent(recordType, aceContent(recordType, content)) ==
+		canonicalContent(recordType, aceContent(recordType, other))
+}
+
+// stateContent returns the content to store for a record: the configured or
+// previously stored content if it only differs in formatting from the
+// returned content, which avoids perpetual diffs, and the returned content
+// otherwise. Returned addresses of A and AAAA records are stored in canonical
+// form.
+func stateContent(recordType string, configured types.String, returned string) types.String {
+	if !configured.IsNull() && !configured.IsUnknown() && sameRecordContent(recordType, configured.ValueString(), returned) {
+		return configured
+	}
+	if recordType == "A" || recordType == "AAAA" {
+		return types.StringValue(canonicalContent(recordType, returned))
+	}
+	return types.StringValue(returned)
+}
+
+// isQuotedContent reports whether content is in master file format, i.e.
+// starts with a quoted character string.
+func isQuotedContent(content string) bool {
+	return strings.HasPrefix(strings.TrimSpace(content), `"`)
+}
+
+// parseTXTContent returns the text of TXT content in master file format: one
+// or more quoted character strings separated by whitespace, which may contain
+// the escape sequences \X for a literal character X and \DDD for the byte with
+// the decimal value DDD (RFC 1035 section 5.1). The character strings are
+// concatenated.
+func parseTXTContent(content string) (string, error) {
+	texts, err := parseTXTStrings(content)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(texts, ""), nil
+}
+
+// txtDataSize returns the size in bytes of the record data of TXT content on
+// the wire: each character string of at most 255 bytes is preceded by its
+// length. Unquoted text is split into character strings by the API.
+func txtDataSize(content string) int {
+	texts, err := parseTXTStrings(content)
+	if err != nil {
+		texts = []string{txtText(content)}
+	}
+
+	size := 0
+	for _, text := range texts {
+		size += len(text) + max(1, (len(text)+254)/255)
+	}
+	return size
+}
+
+// parseTXTStrings returns the text of each character string of TXT content in
+// master file format, see parseTXTContent.
+func parseTXTStrings(content string) ([]string, error) {
+	rest := strings.TrimSpace(content)
+	if !strings.HasPrefix(rest, `"`) {
+		return nil, fmt.Errorf("content must start with a quote")
+	}
+
+	var texts []string
+	for rest != "" {
+		if rest[0] != '"' {
+			return nil, fmt.Errorf("unexpected text %q after quoted string, quote all text", rest)
+		}
+		var text []byte
+		i := 1
+		for ; i < len(rest) && rest[i] != '"'; i++ {
+			if rest[i] != '\\' {
+				text = append(text, rest[i])
+				continue
+			}
+			switch {
+			case i+1 >= len(rest):
+				return nil, fmt.Errorf("incomplete escape sequence at end of content")
+			case isDigit(rest[i+1]):
+				if i+3 >= len(rest) || !isDigit(rest[i+2]) || !isDigit(rest[i+3]) {
+					return nil, fmt.Errorf("decimal escape sequence %q must have three digits", rest[i:min(i+4, len(rest))])
+				}
+				value, _ := strconv.Atoi(rest[i+1 : i+4])
+				if value > 255 {
+					return nil, fmt.Errorf("decimal escape sequence %q exceeds 255", rest[i:i+4])
+				}
+				text = append(text, byte(value))
+				i += 3
+			default:
+				text = append(text, rest[i+1])
+				i++
+			}
+		}
+		if i >= len(rest) {
+			return nil, fmt.Errorf("missing closing quote")
+		}
+		texts = append(texts, string(text))
+		rest = strings.TrimSpace(rest[i+1:])
+	}
+	return texts, nil
+}
+
+// Modes of joining the character strings of TXT content read from the API.
+const (
+	txtJoinConcatenate = "concatenate"
+	txtJoinSpace       = "space"
+)
+
+// normalizeContentJoin normalizes content like normalizeContent, joining
+// the character strings of multi-string TXT content as configured by join:
+// concatenated, as the API and DNS resolvers do, or separated by spaces.
+func (c *Client) normalizeContentJoin(recordType, content string, join types.String) string {
+	if join.ValueString() != txtJoinSpace || !c.normalizeRecordTypes[recordType] {
+		return c.normalizeContent(recordType, content)
+	}
+	if texts, err := parseTXTStrings(content); err == nil {
+		return strings.Join(texts, " ")
+	}
+	if isQuotedContent(content) {
+		return joinQuotedSegments(content, " ")
+	}
+	return normalizeRecordContent(content)
+}
+
+// stateContentJoin returns the content to store for a record like
+// stateContent. Configured multi-string TXT content is also kept if it reads
+// back as the returned content once joined by spaces.
+func (c *Client) stateContentJoin(recordType string, configured types.String, returned string, join types.String) types.String {
+	if join.ValueString() == txtJoinSpace && !configured.IsNull() && !configured.IsUnknown() &&
+		c.normalizeContentJoin(recordType, configured.ValueString(), join) == returned {
+		return configured
+	}
+	return stateContent(recordType, configured, returned)
+}
+
+// txtText returns the text of TXT content, resolving quotes and escape
+// sequences if the content is quoted. Other content is returned as is.
+func txtText(content string) string {
+	if text, err := parseTXTContent(content); err == nil {
+		return text
+	}
+	return content
+}
+
+// quotedSegmentSeparator matches the end of a quoted segment, whitespace and
+// the start of the next one.
+var quotedSegmentSeparator = regexp.MustCompile(`"\s+"`)
+
+// joinQuotedSegments returns the text of quoted content that parseTXTContent
+// rejects, e.g. with unescaped quotes or backslashes. Only the outer quotes
+// are removed and adjacent quoted segments are joined by sep, so the text of
+// each segment, including its spaces, is kept.
+func joinQuotedSegments(content, sep string) string {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, `"`)
+	content = strings.TrimSuffix(content, `"`)
+	return quotedSegmentSeparator.ReplaceAllLiteralString(content, sep)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// quoteTXTContent returns TXT content in master file format if it contains
+// quotes or backslashes, escaping them so that the content is not altered by
+// the API. Content that is already quoted, or needs no escaping, is returned
+// as is.
+func quoteTXTContent(content string) string {
+	if isQuotedContent(content) || !strings.ContainsAny(content, `"\`) {
+		return content
+	}
+	return `"` + txtEscaper.Replace(content) + `"`
+}
+
+// txtEscaper escapes the quotes and backslashes of text in a quoted character
+// string.
+var txtEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// maxTXTStringLength is the maximum length in bytes of a character string of
+// TXT content.
+const maxTXTStringLength = 255
+
+// splitTXTContent returns TXT content as quoted character strings of at most
+// maxTXTStringLength bytes, splitting longer text, e.g. of DKIM keys, into
+// several character strings. Segments end at UTF-8 character boundaries where
+// possible. Content whose text already fits, or that is quoted but cannot be
+// parsed, is returned as is.
+func splitTXTContent(content string) string {
+	texts, err := parseTXTStrings(content)
+	if err != nil {
+		if isQuotedContent(content) {
+			return content
+		}
+		texts = []string{content}
+	}
+	if !slices.ContainsFunc(texts, func(text string) bool { return len(text) > maxTXTStringLength }) {
+		return content
+	}
+
+	var quoted []string
+	for _, text := range texts {
+		for len(text) > maxTXTStringLength {
+			n := maxTXTStringLength
+			for n > 0 && !utf8.RuneStart(text[n]) {
+				n--
+			}
+			if n == 0 {
+				n = maxTXTStringLength
+			}
+			quoted = append(quoted, `"`+txtEscaper.Replace(text[:n])+`"`)
+			text = text[n:]
+		}
+		quoted = append(quoted, `"`+txtEscaper.Replace(text)+`"`)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// hostNameContentTypes are the record types whose content is a single host
+// name.
+var hostNameContentTypes = []string{"ALIAS", "CNAME", "MX", "NS", "PTR"}
+
+// mapContentHostName applies convert to the host name in the content of a
+// record: the whole content of the types in hostNameContentTypes and the
+// target of SRV content. Other content is returned as is.
+func mapContentHostName(recordType, content string, convert func(string) (string, error)) (string, error) {
+	switch {
+	case slices.Contains(hostNameContentTypes, recordType):
+		return convert(content)
+	case recordType == "SRV":
+		fields := strings.Fields(content)
+		if len(fields) != 3 {
+			return content, nil
+		}
+		target, err := convert(fields[2])
+		if err != nil || target == fields[2] {
+			return content, err
+		}
+		return fields[0] + " " + fields[1] + " " + target, nil
+	}
+	return content, nil
+}
+
+// aceHostName encodes the internationalized labels of a host name in their
+// ASCII compatible form (RFC 5890), e.g. müller.de as xn--mller-kva.de.
+// ASCII labels, which may contain underscores, are kept as they are.
+func aceHostName(name string) (string, error) {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		encoded, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return name, fmt.Errorf("label %q of %q is not a valid internationalized label: %w", label, name, err)
+		}
+		labels[i] = encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// unicodeHostName decodes the ASCII compatible labels of a host name, e.g.
+// xn--mller-kva.de as müller.de. Labels that cannot be decoded are kept.
+func unicodeHostName(name string) (string, error) {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if !strings.HasPrefix(strings.ToLower(label), "xn--") {
+			continue
+		}
+		if decoded, err := idna.Lookup.ToUnicode(label); err == nil {
+			labels[i] = decoded
+		}
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// aceContent returns the content of a record with the host name it contains
+// in ASCII compatible form. Content that cannot be encoded is returned as
+// is; validateRecord reports it.
+func aceContent(recordType, content string) string {
+	encoded, _ := mapContentHostName(recordType, content, aceHostName)
+	return encoded
+}
+
+// isASCII reports whether s only consists of ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
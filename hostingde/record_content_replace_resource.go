@@ -0,0 +1,279 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxContentReplaceRecords is the maximum number of records whose content is
+// replaced at once, unless allow_many_records is set.
+const maxContentReplaceRecords = 10
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &recordContentReplaceResource{}
+	_ resource.ResourceWithConfigure = &recordContentReplaceResource{}
+)
+
+// NewRecordContentReplaceResource is a helper function to simplify the provider implementation.
+func NewRecordContentReplaceResource() resource.Resource {
+	return &recordContentReplaceResource{}
+}
+
+// recordContentReplaceResource replaces the content of all matching records
+// of a zone.
+type recordContentReplaceResource struct {
+	client *Client
+}
+
+// recordContentReplaceResourceModel maps the record_content_replace resource
+// schema data.
+type recordContentReplaceResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ZoneID            types.String `tfsdk:"zone_id"`
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	Content           types.String `tfsdk:"content"`
+	NewContent        types.String `tfsdk:"new_content"`
+	AllowManyRecords  types.Bool   `tfsdk:"allow_many_records"`
+	ModifiedRecordIDs types.List   `tfsdk:"modified_record_ids"`
+}
+
+// Metadata returns the resource type name.
+func (r *recordContentReplaceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_content_replace"
+}
+
+// Schema defines the schema for the resource.
+func (r *recordContentReplaceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Replaces the content of all records of a zone with the given content, optionally restricted to a name and type, " +
+			"e.g. to point all A records from an old to a new IP address during a migration. " +
+			"Matching records are modified in a single batch on create. If a record with the old content reappears, the next plan " +
+			"re-creates this resource, which modifies the record again. Destroying this resource does not change any records. " +
+			fmt.Sprintf("At most %d records are modified at once, unless allow_many_records is set.", maxContentReplaceRecords),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the form zone_id/type/content.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone to modify the records of.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the records to modify. If omitted, records of any name are modified.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the records to modify. If omitted, records of any type are modified.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Current content of the records to modify, e.g. the old IP address. " +
+					"Contents are compared in canonical form, e.g. IPv6 addresses in any notation match.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"new_content": schema.StringAttribute{
+				Description: "Content to set on the matching records, e.g. the new IP address.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"allow_many_records": schema.BoolAttribute{
+				Description: fmt.Sprintf("Allow modifying more than %d records at once. Defaults to false.", maxContentReplaceRecords),
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"modified_record_ids": schema.ListAttribute{
+				Description: "IDs of the records modified on create.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create replaces the content of all matching records.
+func (r *recordContentReplaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Retrieve values from plan
+	var plan recordContentReplaceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := plan.ZoneID.ValueString()
+	unlock, err := r.client.lockZone(ctx, zoneId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Locking hosting.de DNS zone", err.Error())
+		return
+	}
+	defer unlock()
+
+	records, err := r.client.findRecordsByContent(ctx, zoneId, plan.Name.ValueString(), plan.Type.ValueString(), plan.Content.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records of zone ID "+zoneId+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(records) > maxContentReplaceRecords && !plan.AllowManyRecords.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow_many_records"),
+			"Too many matching records",
+			fmt.Sprintf("%d records of zone ID %s have the content %q, more than the %d records modified at once by default. "+
+				"Restrict the records by name or type, or set allow_many_records to modify all of them.",
+				len(records), zoneId, plan.Content.ValueString(), maxContentReplaceRecords),
+		)
+		return
+	}
+
+	modified, err := r.client.modifyRecordsContent(ctx, zoneId, records, plan.NewContent.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not update records, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	modifiedIds := []string{}
+	for _, record := range modified {
+		tflog.Info(ctx, "Replaced record content", map[string]any{
+			"id":          record.ID,
+			"name":        record.Name,
+			"type":        record.Type,
+			"content":     record.Content,
+			"new_content": plan.NewContent.ValueString(),
+		})
+		modifiedIds = append(modifiedIds, record.ID)
+	}
+
+	plan.ModifiedRecordIDs, diags = types.ListValueFrom(ctx, types.StringType, modifiedIds)
+	resp.Diagnostics.Append(diags...)
+	plan.ID = types.StringValue(strings.Join([]string{
+		zoneId,
+		plan.Type.ValueString(),
+		plan.Content.ValueString(),
+	}, "/"))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read checks whether a record with the old content reappeared. If so, the
+// resource is removed from state, so the next apply modifies the records
+// again.
+func (r *recordContentReplaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Get current state
+	var state recordContentReplaceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := r.client.findRecordsByContent(
+		ctx,
+		state.ZoneID.ValueString(),
+		state.Name.ValueString(),
+		state.Type.ValueString(),
+		state.Content.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records for "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(records) > 0 {
+		tflog.Info(ctx, "Record with replaced content reappeared, planning modification", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+// Update only stores allow_many_records, as all other attributes require
+// replacement.
+func (r *recordContentReplaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var plan recordContentReplaceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete only removes the resource from state, records are left untouched.
+func (r *recordContentReplaceResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *recordContentReplaceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,139 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRecordContentReplaceResource(t *testing.T) {
+	var records []DNSRecord
+	var updates []RecordsUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = records
+			findResponse.Response.TotalEntries = len(records)
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updates = append(updates, updateRequest)
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordContentReplaceResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	create := func(values map[string]any) (recordContentReplaceResourceModel, diag.Diagnostics) {
+		t.Helper()
+
+		updates = nil
+		values["id"] = types.StringUnknown()
+		values["modified_record_ids"] = types.ListUnknown(types.StringType)
+		if _, ok := values["allow_many_records"]; !ok {
+			values["allow_many_records"] = false
+		}
+		plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+
+		var state recordContentReplaceResourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		}
+		return state, resp.Diagnostics
+	}
+
+	records = []DNSRecord{
+		{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.10", TTL: 3600},
+		{ID: "2", Name: "example.test", Type: "A", Content: "192.0.2.10", TTL: 300},
+		{ID: "3", Name: "mail.example.test", Type: "A", Content: "192.0.2.11", TTL: 3600},
+		{ID: "4", Name: "www.example.test", Type: "AAAA", Content: "2001:db8:0:0::10", TTL: 3600},
+	}
+	state, diags := create(map[string]any{
+		"zone_id":     "zone-id",
+		"type":        "A",
+		"content":     "192.0.2.10",
+		"new_content": "192.0.2.20",
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(updates) != 1 || len(updates[0].RecordsToModify) != 2 || len(updates[0].RecordsToAdd) != 0 || len(updates[0].RecordsToDelete) != 0 {
+		t.Fatalf("expected the matching records to be modified in one batch, got %v", updates)
+	}
+	for _, record := range updates[0].RecordsToModify {
+		if record.Content != "192.0.2.20" || record.ID == "3" {
+			t.Errorf("expected only records with the old content to get the new content, got %v", record)
+		}
+	}
+	if updates[0].RecordsToModify[1].TTL != 300 {
+		t.Errorf("expected the other fields of modified records to be kept, got %v", updates[0].RecordsToModify[1])
+	}
+	var modifiedIds []string
+	diags.Append(state.ModifiedRecordIDs.ElementsAs(ctx, &modifiedIds, false)...)
+	if fmt.Sprint(modifiedIds) != "[1 2]" || state.ID.ValueString() != "zone-id/A/192.0.2.10" {
+		t.Errorf("expected the modified records to be reported, got %v %s", modifiedIds, state.ID)
+	}
+
+	// Contents are compared in canonical form.
+	if _, diags = create(map[string]any{
+		"zone_id":     "zone-id",
+		"content":     "2001:db8::10",
+		"new_content": "2001:db8::20",
+	}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(updates) != 1 || len(updates[0].RecordsToModify) != 1 || updates[0].RecordsToModify[0].ID != "4" {
+		t.Fatalf("expected the AAAA record to be modified, got %v", updates)
+	}
+
+	// Nothing is sent if no record matches.
+	if state, diags = create(map[string]any{
+		"zone_id":     "zone-id",
+		"content":     "192.0.2.99",
+		"new_content": "192.0.2.20",
+	}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(updates) != 0 || len(state.ModifiedRecordIDs.Elements()) != 0 {
+		t.Errorf("expected no update without matching records, got %v", updates)
+	}
+
+	// Modifying more records than the limit requires allow_many_records.
+	records = nil
+	for i := 0; i <= maxContentReplaceRecords; i++ {
+		records = append(records, DNSRecord{ID: fmt.Sprint(i), Name: fmt.Sprintf("host%d.example.test", i), Type: "A", Content: "192.0.2.10", TTL: 3600})
+	}
+	values := map[string]any{
+		"zone_id":     "zone-id",
+		"content":     "192.0.2.10",
+		"new_content": "192.0.2.20",
+	}
+	if _, diags = create(values); !diags.HasError() || diags.Errors()[0].Summary() != "Too many matching records" {
+		t.Fatalf("expected an error for too many matching records, got %v", diags)
+	}
+	if len(updates) != 0 {
+		t.Errorf("expected no update above the limit, got %v", updates)
+	}
+	values["allow_many_records"] = true
+	if _, diags = create(values); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(updates) != 1 || len(updates[0].RecordsToModify) != maxContentReplaceRecords+1 {
+		t.Errorf("expected all matching records to be modified with allow_many_records, got %v", updates)
+	}
+}
@@ -0,0 +1,370 @@
+package hostingde
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testSHA256Digest = "2BB183AF5F22588179A53B0A98631FAD1A292118C0D8F7A9A2B6D8B6F1D83FAB"
+
+func TestParseDSContent(t *testing.T) {
+	parsed, err := parseDSContent("60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.KeyTag != 60485 || parsed.Algorithm != 5 || parsed.DigestType != 1 || parsed.Digest != "2BB183AF5F22588179A53B0A98631FAD1A292118" {
+		t.Errorf("unexpected DS content: %+v", parsed)
+	}
+
+	// Digests split into blocks are joined.
+	parsed, err = parseDSContent("2371 13 2 " + testSHA256Digest[:32] + " " + testSHA256Digest[32:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.String() != "2371 13 2 "+testSHA256Digest {
+		t.Errorf("unexpected assembled DS content: %s", parsed)
+	}
+
+	for _, content := range []string{
+		"2371 13 2",
+		"key 13 2 " + testSHA256Digest,
+		"70000 13 2 " + testSHA256Digest,
+		"2371 13 2 " + testSHA256Digest[:40],
+		"2371 13 1 " + testSHA256Digest,
+		"2371 13 2 " + strings.Repeat("X", 64),
+	} {
+		if _, err := parseDSContent(content); err == nil {
+			t.Errorf("%q: expected error", content)
+		}
+	}
+}
+
+func TestRecordResourceDS(t *testing.T) {
+	diags := validateRecordResourceConfig(t, map[string]any{
+		"zone_id":        "zone-id",
+		"name":           "sub.example.test",
+		"type":           "DS",
+		"ds_key_tag":     2371,
+		"ds_algorithm":   13,
+		"ds_digest_type": 2,
+		"ds_digest":      testSHA256Digest,
+	})
+	if diags.HasError() {
+		t.Errorf("expected structured DS record to be valid, got %v", diags)
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id":        "zone-id",
+		"name":           "sub.example.test",
+		"type":           "DS",
+		"ds_key_tag":     2371,
+		"ds_algorithm":   13,
+		"ds_digest_type": 1,
+		"ds_digest":      testSHA256Digest,
+	})
+	if !diags.HasError() {
+		t.Errorf("expected SHA-256 digest with SHA-1 digest type to be rejected")
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id":    "zone-id",
+		"name":       "sub.example.test",
+		"type":       "DS",
+		"content":    "2371 13 2 " + testSHA256Digest,
+		"ds_key_tag": 2371,
+	})
+	if !diags.HasError() {
+		t.Errorf("expected content and ds_* attributes to conflict")
+	}
+
+	// Configuration generated for imported records contains both.
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id":        "zone-id",
+		"name":           "sub.example.test",
+		"type":           "DS",
+		"content":        "2371 13 2 " + strings.ToLower(testSHA256Digest),
+		"ds_key_tag":     2371,
+		"ds_algorithm":   13,
+		"ds_digest_type": 2,
+		"ds_digest":      testSHA256Digest,
+	})
+	if diags.HasError() {
+		t.Errorf("expected content matching the ds_* attributes to be valid, got %v", diags)
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id":        "zone-id",
+		"name":           "sub.example.test",
+		"type":           "DS",
+		"content":        "2372 13 2 " + testSHA256Digest,
+		"ds_key_tag":     2371,
+		"ds_algorithm":   13,
+		"ds_digest_type": 2,
+		"ds_digest":      testSHA256Digest,
+	})
+	if !diags.HasError() {
+		t.Errorf("expected content differing from the ds_* attributes to conflict")
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "sub.example.test",
+		"type":    "DS",
+		"content": "2371 13 2 " + testSHA256Digest[:40],
+	})
+	if !diags.HasError() {
+		t.Errorf("expected raw DS content with mismatched digest to be rejected")
+	}
+
+	// Structured attributes round-trip through the content.
+	model := recordResourceModel{
+		Type:         types.StringValue("DS"),
+		DSKeyTag:     types.Int64Value(2371),
+		DSAlgorithm:  types.Int64Value(13),
+		DSDigestType: types.Int64Value(2),
+		DSDigest:     types.StringValue(testSHA256Digest),
+	}
+	content, ok := model.structuredContent()
+	if !ok {
+		t.Fatal("expected content to be assembled")
+	}
+	parsed := recordResourceModel{Type: types.StringValue("DS"), Content: types.StringValue(content)}
+	parsed.setStructuredContent()
+	if !parsed.DSKeyTag.Equal(model.DSKeyTag) || !parsed.DSAlgorithm.Equal(model.DSAlgorithm) ||
+		!parsed.DSDigestType.Equal(model.DSDigestType) || !parsed.DSDigest.Equal(model.DSDigest) {
+		t.Errorf("expected structured attributes to round-trip, got %+v", parsed)
+	}
+}
+
+func TestRecordResourceTLSA(t *testing.T) {
+	digest := sha256.Sum256([]byte("example certificate"))
+	encoded := base64.StdEncoding.EncodeToString(digest[:])
+
+	model := recordResourceModel{
+		Type:             types.StringValue("TLSA"),
+		TLSAUsage:        types.Int64Value(3),
+		TLSASelector:     types.Int64Value(1),
+		TLSAMatchingType: types.Int64Value(1),
+		TLSADataBase64:   types.StringValue(encoded),
+	}
+	content, ok := model.structuredContent()
+	if !ok {
+		t.Fatal("expected content to be assembled")
+	}
+	if content != "3 1 1 "+hex.EncodeToString(digest[:]) {
+		t.Errorf("unexpected TLSA content %s", content)
+	}
+
+	// The hex encoded data round-trips to the configured base64.
+	parsed := recordResourceModel{Type: types.StringValue("TLSA"), Content: types.StringValue(content)}
+	parsed.setStructuredContent()
+	if parsed.TLSADataBase64.ValueString() != encoded || parsed.TLSAUsage.ValueInt64() != 3 || !parsed.DSDigest.IsNull() {
+		t.Errorf("expected structured attributes to round-trip, got %+v", parsed)
+	}
+
+	diags := validateRecordResourceConfig(t, map[string]any{
+		"zone_id":            "zone-id",
+		"name":               "_443._tcp.example.test",
+		"type":               "TLSA",
+		"tlsa_usage":         3,
+		"tlsa_selector":      1,
+		"tlsa_matching_type": 2,
+		"tlsa_data_base64":   encoded,
+	})
+	if !diags.HasError() {
+		t.Errorf("expected SHA-256 data with SHA-512 matching type to be rejected")
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id":            "zone-id",
+		"name":               "_443._tcp.example.test",
+		"type":               "TLSA",
+		"tlsa_usage":         3,
+		"tlsa_selector":      1,
+		"tlsa_matching_type": 1,
+		"tlsa_data_base64":   "not base64!",
+	})
+	if !diags.HasError() {
+		t.Errorf("expected invalid base64 to be rejected")
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "_443._tcp.example.test",
+		"type":    "TLSA",
+		"content": "3 1 1 " + encoded,
+	})
+	if !diags.HasError() {
+		t.Errorf("expected base64 data in raw TLSA content to be rejected")
+	}
+}
+
+func TestCanonicalContent(t *testing.T) {
+	for _, test := range []struct {
+		recordType, content, other string
+		same                       bool
+	}{
+		{"AAAA", "2001:db8::0:1", "2001:db8::1", true},
+		{"AAAA", "2001:DB8:0:0:0:0:0:1", "2001:db8::1", true},
+		{"AAAA", "2001:0db8::0001", "2001:db8::1", true},
+		{"AAAA", "2001:db8::1", "2001:db8::2", false},
+		{"AAAA", "::ffff:192.0.2.1", "192.0.2.1", false},
+		{"A", " 192.0.2.1", "192.0.2.1", true},
+		{"A", "192.0.2.1", "192.0.2.10", false},
+		{"MX", "mail.example.test", "Mail.Example.Test.", true},
+		{"MX", " mail.example.test ", "mail.example.test", true},
+		{"MX", "mail.example.test", "mx.example.test", false},
+		{"SRV", "10 5060 sip.example.test", "10  5060\tsip.example.test.", true},
+		{"SRV", "10 5060 sip.example.test", "010 5060 SIP.example.test", true},
+		{"SRV", "0 0 .", "0 0 .", true},
+		{"SRV", "10 5060 sip.example.test", "10 5061 sip.example.test", false},
+		{"CNAME", "www.example.test", "www.example.test.", false},
+	} {
+		if same := sameRecordContent(test.recordType, test.content, test.other); same != test.same {
+			t.Errorf("%s %q and %q: expected same = %t", test.recordType, test.content, test.other, test.same)
+		}
+	}
+
+	if content := canonicalContent("AAAA", "2001:DB8:0000::0:1"); content != "2001:db8::1" {
+		t.Errorf("unexpected canonical AAAA content %q", content)
+	}
+	if content := stateContent("AAAA", types.StringNull(), "2001:DB8::0:1"); content.ValueString() != "2001:db8::1" {
+		t.Errorf("expected returned AAAA content to be stored in canonical form, got %q", content.ValueString())
+	}
+	if content := canonicalContent("SRV", "10  5060 SIP.example.test."); content != "10 5060 sip.example.test" {
+		t.Errorf("unexpected canonical SRV content %q", content)
+	}
+	if content := canonicalContent("SRV", "5060 sip.example.test"); content != "5060 sip.example.test" {
+		t.Errorf("expected unparseable content to be kept, got %q", content)
+	}
+}
+
+func TestRecordResourceAddressContent(t *testing.T) {
+	for _, test := range []struct {
+		recordType, content string
+		valid               bool
+	}{
+		{"A", "192.0.2.1", true},
+		{"A", "2001:db8::1", false},
+		{"A", "192.0.2.256", false},
+		{"A", "192.000.002.001", false},
+		{"AAAA", "2001:db8::0:1", true},
+		{"AAAA", "::ffff:192.0.2.1", true},
+		{"AAAA", "192.0.2.1", false},
+		{"AAAA", "2001:db8::1::2", false},
+		{"AAAA", "fe80::1%eth0", false},
+		{"AAAA", "www.example.test", false},
+	} {
+		diags := validateRecordResourceConfig(t, map[string]any{
+			"zone_id": "zone-id",
+			"name":    "www.example.test",
+			"type":    test.recordType,
+			"content": test.content,
+		})
+		if diags.HasError() == test.valid {
+			t.Errorf("%s %q: expected valid = %t, got %v", test.recordType, test.content, test.valid, diags)
+		}
+	}
+}
+
+func TestParseTXTContent(t *testing.T) {
+	for content, expected := range map[string]string{
+		`"hello"`:                    "hello",
+		`"v=spf1 " "-all"`:           "v=spf1 -all",
+		`"say \"hi\""`:               `say "hi"`,
+		`"\065\066C"`:                "ABC",
+		`"back\\slash" "\;"`:         `back\slash;`,
+		` "padded"  "strings" `:      "paddedstrings",
+		`"with \"quotes\" and \046"`: `with "quotes" and .`,
+	} {
+		text, err := parseTXTContent(content)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", content, err)
+		} else if text != expected {
+			t.Errorf("%s: expected %q, got %q", content, expected, text)
+		}
+		if quoted := quoteTXTContent(expected); normalizeRecordContent(quoted) != expected {
+			t.Errorf("%q: expected quoted content %s to round-trip", expected, quoted)
+		}
+	}
+
+	for _, content := range []string{`"unterminated`, `"trailing\`, `"\25"`, `"\256"`, `"quoted" unquoted`} {
+		if _, err := parseTXTContent(content); err == nil {
+			t.Errorf("%s: expected error", content)
+		}
+	}
+
+	if quoted := quoteTXTContent(`say "hi"`); quoted != `"say \"hi\""` {
+		t.Errorf("unexpected quoted content %s", quoted)
+	}
+	if quoted := quoteTXTContent("v=spf1 -all"); quoted != "v=spf1 -all" {
+		t.Errorf("expected content without quotes to be kept, got %s", quoted)
+	}
+}
+
+func TestSplitTXTContent(t *testing.T) {
+	key := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12)
+	for _, content := range []string{
+		key,
+		`"` + key + `"`,
+		`note "` + strings.Repeat("x", 300) + `"`,
+		strings.Repeat("ü", 200),
+	} {
+		split := splitTXTContent(content)
+		texts, err := parseTXTStrings(split)
+		if err != nil {
+			t.Fatalf("%.20s: unexpected error: %v", content, err)
+		}
+		if len(texts) < 2 {
+			t.Errorf("%.20s: expected several character strings, got %s", content, split)
+		}
+		for _, text := range texts {
+			if len(text) > maxTXTStringLength || !utf8.ValidString(text) {
+				t.Errorf("%.20s: unexpected character string %q", content, text)
+			}
+		}
+		if !sameRecordContent("TXT", content, split) {
+			t.Errorf("%.20s: expected split content %s to keep the text", content, split)
+		}
+	}
+
+	for _, content := range []string{"v=spf1 -all", `"v=spf1 " "-all"`, `"unterminated ` + key} {
+		if split := splitTXTContent(content); split != content {
+			t.Errorf("%.20s: expected content to be kept, got %s", content, split)
+		}
+	}
+}
+
+func TestNormalizeTXTContent(t *testing.T) {
+	client := NewClient(nil, nil, nil)
+
+	// The API splits long content into character strings of 255 bytes.
+	key := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12)
+	for _, test := range []struct {
+		stored, expected string
+	}{
+		{`"` + key[:255] + `" "` + key[255:] + `"`, key},
+		{`"v=spf1 ip4:192.0.2.0/24 include:_spf.example.test " "~all"`, "v=spf1 ip4:192.0.2.0/24 include:_spf.example.test ~all"},
+		{`"v=spf1 a mx" " -all"`, "v=spf1 a mx -all"},
+		// Content with unescaped quotes keeps the text of each segment.
+		{`"say "hi" there" "my friend"`, `say "hi" theremy friend`},
+	} {
+		content := client.normalizeContent("TXT", test.stored)
+		if content != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.stored, test.expected, content)
+		}
+		if again := client.normalizeContent("TXT", client.apiContent("TXT", content)); again != content {
+			t.Errorf("%s: expected normalized content to round-trip, got %q", test.stored, again)
+		}
+	}
+
+	if content := client.normalizeContentJoin("TXT", `"say "hi" there" "my friend"`, types.StringValue(txtJoinSpace)); content != `say "hi" there my friend` {
+		t.Errorf("expected segments joined by spaces, got %q", content)
+	}
+}
@@ -0,0 +1,136 @@
+package hostingde
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &recordCountDataSource{}
+	_ datasource.DataSourceWithConfigure = &recordCountDataSource{}
+)
+
+// NewRecordCountDataSource is a helper function to simplify the provider implementation.
+func NewRecordCountDataSource() datasource.DataSource {
+	return &recordCountDataSource{}
+}
+
+// recordCountDataSource is the data source implementation.
+type recordCountDataSource struct {
+	client *Client
+}
+
+// recordCountDataSourceModel maps the record_count data source schema data.
+type recordCountDataSourceModel struct {
+	ZoneID types.String `tfsdk:"zone_id"`
+	Type   types.String `tfsdk:"type"`
+	Count  types.Int64  `tfsdk:"count"`
+}
+
+// recordCountFilter returns a filter matching the records of a zone, or of
+// all zones if zoneId is empty, optionally restricted to a record type.
+func recordCountFilter(zoneId, recordType string) FilterOrChain {
+	var filters []Filter
+	if zoneId != "" {
+		filters = append(filters, Filter{Field: "zoneConfigId", Value: zoneId})
+	}
+	if recordType != "" {
+		filters = append(filters, Filter{Field: "recordType", Value: recordType})
+	}
+
+	switch len(filters) {
+	case 0:
+		return FilterOrChain{}
+	case 1:
+		return FilterOrChain{Filter: filters[0]}
+	}
+	return FilterOrChain{
+		SubFilterConnective: "AND",
+		SubFilter:           filters,
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *recordCountDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_count"
+}
+
+// Schema defines the schema for the data source.
+func (d *recordCountDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Counts the records of a zone, or of all zones, e.g. to track how close zones are to their record limit. " +
+			"Only the number of records reported by the API is read, not the records themselves, so the lookup is cheap for large zones.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "ID of the DNS zone whose records are counted. If not set, the records of all zones accessible with the configured API key are counted.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Only count records of this type, e.g. A.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"count": schema.Int64Attribute{
+				Description: "Number of matching records.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *recordCountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer d.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var state recordCountDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var recordType string
+	if !state.Type.IsNull() {
+		recordType = d.client.apiRecordType(state.Type.ValueString())
+	}
+	count, err := d.client.countMatchingRecords(ctx, recordCountFilter(state.ZoneID.ValueString(), recordType))
+	if err != nil {
+		zones := "all zones"
+		if !state.ZoneID.IsNull() {
+			zones = "zone ID " + state.ZoneID.ValueString()
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not count the records of "+zones+": "+err.Error(),
+		)
+		return
+	}
+	state.Count = types.Int64Value(int64(count))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *recordCountDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
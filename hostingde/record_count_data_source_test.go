@@ -0,0 +1,94 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRecordCountDataSource(t *testing.T) {
+	var requests []RecordsFindRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			requests = append(requests, findRequest)
+
+			// Only the first of many pages is returned.
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1"}}
+			findResponse.Response.Page = findRequest.Page
+			findResponse.Response.TotalPages = 1234
+			findResponse.Response.TotalEntries = 1234
+			return findResponse
+		},
+	})
+
+	read := func(values map[string]any) recordCountDataSourceModel {
+		t.Helper()
+
+		ctx := context.Background()
+		d := &recordCountDataSource{client: client}
+		schemaResp := &datasource.SchemaResponse{}
+		d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		d.Read(ctx, datasource.ReadRequest{Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)}, resp)
+
+		var state recordCountDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		return state
+	}
+
+	for _, test := range []struct {
+		values map[string]any
+		filter FilterOrChain
+	}{
+		{
+			map[string]any{"zone_id": "zone-id", "type": "A"},
+			FilterOrChain{SubFilterConnective: "AND", SubFilter: []Filter{
+				{Field: "zoneConfigId", Value: "zone-id"},
+				{Field: "recordType", Value: "A"},
+			}},
+		},
+		{
+			map[string]any{"zone_id": "zone-id", "type": types.StringNull()},
+			FilterOrChain{Filter: Filter{Field: "zoneConfigId", Value: "zone-id"}},
+		},
+		{
+			map[string]any{"zone_id": types.StringNull(), "type": types.StringNull()},
+			FilterOrChain{},
+		},
+	} {
+		requests = nil
+		state := read(test.values)
+
+		// The count is the total reported by the API, read with a single
+		// request for a single record.
+		if state.Count.ValueInt64() != 1234 {
+			t.Errorf("%v: expected the total number of entries, got %s", test.values, state.Count)
+		}
+		if len(requests) != 1 || requests[0].Limit != 1 || requests[0].Page != 1 {
+			t.Fatalf("%v: expected a single request for one record, got %+v", test.values, requests)
+		}
+		if !reflect.DeepEqual(requests[0].Filter, test.filter) {
+			t.Errorf("%v: expected filter %+v, got %+v", test.values, test.filter, requests[0].Filter)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &recordDataSource{}
+	_ datasource.DataSourceWithConfigure = &recordDataSource{}
+)
+
+// NewRecordDataSource is a helper function to simplify the provider implementation.
+func NewRecordDataSource() datasource.DataSource {
+	return &recordDataSource{}
+}
+
+// recordDataSource is the data source implementation.
+type recordDataSource struct {
+	client *Client
+}
+
+// recordDataSourceModel maps the record data source schema data.
+type recordDataSourceModel struct {
+	ZoneID        types.String             `tfsdk:"zone_id"`
+	Name          types.String             `tfsdk:"name"`
+	Type          types.String             `tfsdk:"type"`
+	AllowMultiple types.Bool               `tfsdk:"allow_multiple"`
+	ID            types.String             `tfsdk:"id"`
+	Content       types.String             `tfsdk:"content"`
+	TTL           types.Int64              `tfsdk:"ttl"`
+	Priority      types.Int64              `tfsdk:"priority"`
+	Comments      types.String             `tfsdk:"comments"`
+	Records       []recordDataSourceRecord `tfsdk:"records"`
+}
+
+// Metadata returns the data source type name.
+func (d *recordDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record"
+}
+
+// Schema defines the schema for the data source.
+func (d *recordDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single DNS record of a zone by name and type, e.g. to reference a record not managed by Terraform. " +
+			"It is an error if no record matches, or if several records match, unless allow_multiple is set.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "ID of the DNS zone of the record.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the record. Example: mail.example.com.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the record.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"allow_multiple": schema.BoolAttribute{
+				Description: "Allow several matching records, e.g. round-robin A records. The first record, ordered by content and ID, " +
+					"is read into the record attributes, and all matching records are listed in records. Defaults to false.",
+				Optional: true,
+			},
+			"id": schema.StringAttribute{
+				Description: "DNS record ID.",
+				Computed:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the record.",
+				Computed:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the record in seconds.",
+				Computed:    true,
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Priority of MX and SRV records.",
+				Computed:    true,
+			},
+			"comments": schema.StringAttribute{
+				Description: "Comment to the record.",
+				Computed:    true,
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "All matching records, ordered by content and ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: recordDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *recordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer d.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var config recordDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := config.ZoneID.ValueString()
+	records, err := d.client.listAllRecords(ctx, RecordsFindRequest{
+		BaseRequest: d.client.newBaseRequest(ctx),
+		Filter:      recordsFilter(zoneId, config.Name.ValueString(), d.client.apiRecordType(config.Type.ValueString())),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records of zone ID "+zoneId+": "+err.Error(),
+		)
+		return
+	}
+
+	config.Records = []recordDataSourceRecord{}
+	for _, record := range records {
+		config.Records = append(config.Records, d.client.newRecordDataSourceRecord(record))
+	}
+	sortDataSourceRecords(config.Records, nil)
+
+	switch {
+	case len(config.Records) == 0:
+		resp.Diagnostics.AddError(
+			"hosting.de DNS record not found",
+			fmt.Sprintf("No %s record named %s exists in zone ID %s.", config.Type.ValueString(), config.Name.ValueString(), zoneId),
+		)
+		return
+	case len(config.Records) > 1 && !config.AllowMultiple.ValueBool():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Several hosting.de DNS records found",
+			fmt.Sprintf("%d %s records named %s exist in zone ID %s. "+
+				"Use the hostingde_records data source to list all of them, or set allow_multiple to read the first one.",
+				len(config.Records), config.Type.ValueString(), config.Name.ValueString(), zoneId),
+		)
+		return
+	}
+
+	record := config.Records[0]
+	config.ID = record.ID
+	config.Content = record.Content
+	config.TTL = record.TTL
+	config.Priority = record.Priority
+	config.Comments = record.Comments
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *recordDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,100 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readRecordDataSource reads the record data source with the given config values.
+func readRecordDataSource(t *testing.T, client *Client, values map[string]any) (recordDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := &recordDataSource{client: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	req := datasource.ReadRequest{
+		Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values),
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+
+	var state recordDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	}
+
+	return state, resp.Diagnostics
+}
+
+func TestRecordDataSource(t *testing.T) {
+	stored := []DNSRecord{
+		{ID: "mx", ZoneID: "zone-id", Name: "example.test", Type: "MX", Content: "mail.example.test", TTL: 3600, Priority: 10},
+		{ID: "www-2", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.2", TTL: 300},
+		{ID: "www-1", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 300},
+	}
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			fields := map[string]string{}
+			for _, filter := range findRequest.Filter.SubFilter {
+				fields[filter.Field] = filter.Value
+			}
+			if findRequest.Filter.SubFilterConnective != "AND" || fields["zoneConfigId"] != "zone-id" {
+				t.Errorf("expected records of the zone to be filtered, got %+v", findRequest.Filter)
+			}
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			for _, record := range stored {
+				if record.Name == fields["recordName"] && record.Type == fields["recordType"] {
+					findResponse.Response.Data = append(findResponse.Response.Data, record)
+				}
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+	})
+
+	state, diags := readRecordDataSource(t, client, map[string]any{"zone_id": "zone-id", "name": "example.test", "type": "MX"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.ID.ValueString() != "mx" || state.Content.ValueString() != "mail.example.test" || state.Priority.ValueInt64() != 10 || len(state.Records) != 1 {
+		t.Errorf("expected the MX record, got %+v", state)
+	}
+
+	// Round-robin records must be allowed explicitly.
+	values := map[string]any{"zone_id": "zone-id", "name": "www.example.test", "type": "A"}
+	if _, diags = readRecordDataSource(t, client, values); !diags.HasError() || diags.Errors()[0].Summary() != "Several hosting.de DNS records found" {
+		t.Errorf("expected an error for several matching records, got %v", diags)
+	}
+	values["allow_multiple"] = true
+	state, diags = readRecordDataSource(t, client, values)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.ID.ValueString() != "www-1" || state.Content.ValueString() != "192.0.2.1" || len(state.Records) != 2 {
+		t.Errorf("expected the first of both records, got %+v", state)
+	}
+
+	if _, diags = readRecordDataSource(t, client, map[string]any{"zone_id": "zone-id", "name": "mail.example.test", "type": "A"}); !diags.HasError() {
+		t.Errorf("expected an error for a missing record")
+	}
+}
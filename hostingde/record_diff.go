@@ -0,0 +1,171 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// recordKey identifies a record by name, type and normalized, canonical
+// content.
+func recordKey(record DNSRecord) string {
+	return record.Name + "\x00" + record.Type + "\x00" + normalizeRecordContent(canonicalContent(record.Type, record.Content))
+}
+
+// diffRecords computes the changes turning the current into the desired
+// records. Records are matched by name, type and content. Matched records
+// with a different TTL, priority or comment are modified, unmatched current
+// records are deleted and unmatched desired records are added.
+func diffRecords(current, desired []DNSRecord) (toAdd, toModify, toDelete []DNSRecord) {
+	unmatched := map[string][]DNSRecord{}
+	for _, record := range current {
+		key := recordKey(record)
+		unmatched[key] = append(unmatched[key], record)
+	}
+
+	for _, record := range desired {
+		key := recordKey(record)
+		candidates := unmatched[key]
+		if len(candidates) == 0 {
+			toAdd = append(toAdd, record)
+			continue
+		}
+
+		existing := candidates[0]
+		unmatched[key] = candidates[1:]
+		if existing.TTL != record.TTL || existing.Priority != record.Priority || existing.Comments != record.Comments {
+			record.ID = existing.ID
+			toModify = append(toModify, record)
+		}
+	}
+
+	// Delete in the order of current records, to keep requests deterministic.
+	for _, record := range current {
+		key := recordKey(record)
+		for i, candidate := range unmatched[key] {
+			if candidate.ID == record.ID {
+				toDelete = append(toDelete, DNSRecord{ID: record.ID, Name: record.Name, Type: record.Type})
+				unmatched[key] = append(unmatched[key][:i:i], unmatched[key][i+1:]...)
+				break
+			}
+		}
+	}
+
+	return toAdd, toModify, toDelete
+}
+
+// recordChanges counts the records changed in a zone.
+type recordChanges struct {
+	added, modified, deleted int
+}
+
+// applyRecords turns the current into the desired records of a zone with a
+// single batch update, split into several if it exceeds the maximum batch
+// size, and returns the desired records as stored by the API,
+// in the order of desired, and the number of changed records.
+func (c *Client) applyRecords(ctx context.Context, zoneId string, current, desired []DNSRecord) ([]DNSRecord, recordChanges, error) {
+	toAdd, toModify, toDelete := diffRecords(current, desired)
+	changes := recordChanges{added: len(toAdd), modified: len(toModify), deleted: len(toDelete)}
+
+	stored := current
+	if len(toAdd) > 0 || len(toModify) > 0 || len(toDelete) > 0 {
+		updateRequest := RecordsUpdateRequest{
+			BaseRequest:     c.newBaseRequest(ctx),
+			ZoneConfigId:    zoneId,
+			RecordsToAdd:    toAdd,
+			RecordsToModify: toModify,
+			RecordsToDelete: toDelete,
+		}
+		c.logRecordMutations(ctx, updateRequest, current)
+		updateResponse, err := c.updateRecordsChunked(ctx, updateRequest)
+		if err != nil {
+			return nil, changes, err
+		}
+		stored = updateResponse.Response.Records
+	}
+
+	applied, ok := matchRecords(stored, desired)
+	if ok {
+		return applied, changes, nil
+	}
+
+	// The response does not contain all records, e.g. if the change is
+	// pending, so look them up in the zone.
+	stored, err := c.listAllRecords(ctx, RecordsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter:      recordsFilter(zoneId, "", ""),
+	})
+	if err != nil {
+		return nil, changes, err
+	}
+
+	applied, ok = matchRecords(stored, desired)
+	if !ok {
+		return nil, changes, fmt.Errorf("%w in zone %s after update", errRecordNotFound, zoneId)
+	}
+	return applied, changes, nil
+}
+
+// applyRecordsRetrying is applyRecords, retrying up to conflict_retries times
+// if the API rejects the update because the zone was modified concurrently.
+// Before each retry, reread returns the current records again, so only the
+// changes still needed are sent.
+func (c *Client) applyRecordsRetrying(ctx context.Context, zoneId string, current, desired []DNSRecord, reread func(context.Context) ([]DNSRecord, error)) ([]DNSRecord, recordChanges, error) {
+	for attempt := 0; ; attempt++ {
+		applied, changes, err := c.applyRecords(ctx, zoneId, current, desired)
+		if !errors.Is(err, ErrConflict) {
+			return applied, changes, err
+		}
+		if attempt >= c.conflictRetries {
+			return nil, changes, fmt.Errorf("giving up after %d attempts: %w", attempt+1, err)
+		}
+
+		tflog.Warn(ctx, "Zone modified concurrently, retrying the records update", map[string]any{"zone_id": zoneId, "attempt": attempt + 1})
+		if err := c.backoff(ctx, "zone modified concurrently", c.rateLimitDelay); err != nil {
+			return nil, changes, err
+		}
+		current, err = reread(ctx)
+		if err != nil {
+			return nil, changes, fmt.Errorf("reading records of zone %s after a conflict: %w", zoneId, err)
+		}
+	}
+}
+
+// managedRecords returns the stored records that were among the previously
+// current records, matched by ID, or that match a desired record, so a retry
+// neither deletes records it does not manage nor adds records created
+// concurrently a second time.
+func managedRecords(stored, previous, desired []DNSRecord) []DNSRecord {
+	managed := map[string]bool{}
+	for _, record := range previous {
+		managed[record.ID] = true
+	}
+	wanted := map[string]bool{}
+	for _, record := range desired {
+		wanted[recordKey(record)] = true
+	}
+
+	var records []DNSRecord
+	for _, record := range stored {
+		if managed[record.ID] || wanted[recordKey(record)] {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// matchRecords finds each desired record in the stored records. It returns
+// false if any record cannot be found.
+func matchRecords(stored, desired []DNSRecord) ([]DNSRecord, bool) {
+	matched := make([]DNSRecord, 0, len(desired))
+	for _, record := range desired {
+		storedRecord, ok := matchReturnedRecord(stored, record)
+		if !ok {
+			return nil, false
+		}
+		matched = append(matched, storedRecord)
+	}
+	return matched, true
+}
@@ -0,0 +1,172 @@
+package hostingde
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &recordIDsDataSource{}
+	_ datasource.DataSourceWithConfigure = &recordIDsDataSource{}
+)
+
+// NewRecordIDsDataSource is a helper function to simplify the provider implementation.
+func NewRecordIDsDataSource() datasource.DataSource {
+	return &recordIDsDataSource{}
+}
+
+// recordIDsDataSource is the data source implementation.
+type recordIDsDataSource struct {
+	client *Client
+}
+
+// recordIDsDataSourceModel maps the record_ids data source schema data.
+type recordIDsDataSourceModel struct {
+	ZoneID           types.String            `tfsdk:"zone_id"`
+	TerraformManaged types.Bool              `tfsdk:"terraform_managed"`
+	IDs              map[string]types.String `tfsdk:"ids"`
+	Records          []recordIDModel         `tfsdk:"records"`
+}
+
+// recordIDModel maps the identity of a record to its ID.
+type recordIDModel struct {
+	Key     types.String `tfsdk:"key"`
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Content types.String `tfsdk:"content"`
+}
+
+// recordIdentityKey identifies a record by name, type and content, e.g.
+// "www.example.test A 192.0.2.1".
+func recordIdentityKey(name, recordType, content string) string {
+	return name + " " + recordType + " " + content
+}
+
+// Metadata returns the data source type name.
+func (d *recordIDsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_ids"
+}
+
+// Schema defines the schema for the data source.
+func (d *recordIDsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Maps the records of a zone, identified by name, type and content, to their hosting.de record IDs, " +
+			"e.g. to reconcile external DNS tooling with the records managed by Terraform. " +
+			"All pages of records of the zone are read.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "ID of the DNS zone.",
+				Required:    true,
+			},
+			"terraform_managed": schema.BoolAttribute{
+				Description: "Only map records managed (true) or not managed (false) by Terraform, as indicated by the provider's comment_prefix.",
+				Optional:    true,
+			},
+			"ids": schema.MapAttribute{
+				Description: "Record IDs by record identity, name, type and content separated by single spaces, e.g. \"www.example.test A 192.0.2.1\". " +
+					"If several records have the same identity, the first one in records is mapped.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "Identity and ID of each record, ordered by name, type, content and ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "Identity of the record, the key in ids.",
+							Computed:    true,
+						},
+						"id": schema.StringAttribute{
+							Description: "ID of the record.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the record.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the record.",
+							Computed:    true,
+						},
+						"content": schema.StringAttribute{
+							Description: "Content of the record.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *recordIDsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer d.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var state recordIDsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.listAllRecords(ctx, RecordsFindRequest{
+		BaseRequest: d.client.newBaseRequest(ctx),
+		Filter:      recordsFilter(state.ZoneID.ValueString(), "", ""),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read the records of hosting.de DNS zone "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	var dataSourceRecords []recordDataSourceRecord
+	for _, record := range records {
+		dataSourceRecord := d.client.newRecordDataSourceRecord(record)
+		if !state.TerraformManaged.IsNull() && dataSourceRecord.TerraformManaged.ValueBool() != state.TerraformManaged.ValueBool() {
+			continue
+		}
+		dataSourceRecords = append(dataSourceRecords, dataSourceRecord)
+	}
+	sortDataSourceRecords(dataSourceRecords, nil)
+
+	state.IDs = map[string]types.String{}
+	state.Records = []recordIDModel{}
+	for _, record := range dataSourceRecords {
+		key := recordIdentityKey(record.Name.ValueString(), record.Type.ValueString(), record.Content.ValueString())
+		if _, ok := state.IDs[key]; !ok {
+			state.IDs[key] = record.ID
+		}
+		state.Records = append(state.Records, recordIDModel{
+			Key:     types.StringValue(key),
+			ID:      record.ID,
+			Name:    record.Name,
+			Type:    record.Type,
+			Content: record.Content,
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *recordIDsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
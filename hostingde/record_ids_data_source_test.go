@@ -0,0 +1,96 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRecordIDsDataSource(t *testing.T) {
+	// The records are returned on two pages, not ordered by identity.
+	pages := [][]DNSRecord{
+		{
+			{ID: "3", Name: "www.example.test", Type: "A", Content: "192.0.2.1", Comments: "[terraform] web"},
+			{ID: "1", Name: "mail.example.test", Type: "A", Content: "192.0.2.2"},
+		},
+		{
+			{ID: "2", Name: "example.test", Type: "MX", Content: "mail.example.test", Comments: "[terraform]"},
+			{ID: "4", Name: "www.example.test", Type: "AAAA", Content: "2001:db8::1", Comments: "[terraform]"},
+		},
+	}
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = pages[findRequest.Page-1]
+			findResponse.Response.Page = findRequest.Page
+			findResponse.Response.TotalPages = len(pages)
+			findResponse.Response.TotalEntries = 4
+			return findResponse
+		},
+	})
+	client.commentPrefix = "[terraform]"
+
+	read := func(values map[string]any) recordIDsDataSourceModel {
+		t.Helper()
+
+		ctx := context.Background()
+		d := &recordIDsDataSource{client: client}
+		schemaResp := &datasource.SchemaResponse{}
+		d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		d.Read(ctx, datasource.ReadRequest{Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)}, resp)
+
+		var state recordIDsDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		return state
+	}
+
+	state := read(map[string]any{"zone_id": "zone-id"})
+	expected := map[string]string{
+		"example.test MX mail.example.test": "2",
+		"mail.example.test A 192.0.2.2":     "1",
+		"www.example.test A 192.0.2.1":      "3",
+		"www.example.test AAAA 2001:db8::1": "4",
+	}
+	if len(state.IDs) != len(expected) {
+		t.Errorf("expected %d mapped IDs, got %v", len(expected), state.IDs)
+	}
+	for key, id := range expected {
+		if state.IDs[key].ValueString() != id {
+			t.Errorf("expected %q to map to ID %s, got %s", key, id, state.IDs[key])
+		}
+	}
+
+	var order []string
+	for _, record := range state.Records {
+		order = append(order, record.ID.ValueString())
+		if state.IDs[record.Key.ValueString()] != record.ID {
+			t.Errorf("expected key %q to match ids", record.Key.ValueString())
+		}
+	}
+	if len(order) != 4 || order[0] != "2" || order[1] != "1" || order[2] != "3" || order[3] != "4" {
+		t.Errorf("expected records ordered by name, type and content, got IDs %v", order)
+	}
+
+	state = read(map[string]any{"zone_id": "zone-id", "terraform_managed": true})
+	if len(state.IDs) != 3 || !state.IDs["mail.example.test A 192.0.2.2"].IsNull() {
+		t.Errorf("expected only the records managed by Terraform, got %v", state.IDs)
+	}
+}
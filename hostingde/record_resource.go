@@ -2,29 +2,317 @@ package hostingde
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &recordResource{}
-	_ resource.ResourceWithConfigure   = &recordResource{}
-	_ resource.ResourceWithImportState = &recordResource{}
+	_ resource.Resource                 = &recordResource{}
+	_ resource.ResourceWithConfigure    = &recordResource{}
+	_ resource.ResourceWithImportState  = &recordResource{}
+	_ resource.ResourceWithModifyPlan   = &recordResource{}
+	_ resource.ResourceWithUpgradeState = &recordResource{}
 )
 
+// normalizeRecordContent removes the quotes the API adds to the content of
+// TXT records. Escape sequences of quoted content are resolved. Quoted content
+// that is not well-formed is joined leniently, see joinQuotedSegments. Other
+// content, such as CAA content, is normalized by removing all quotes.
 func normalizeRecordContent(content string) string {
-	newContent := strings.ReplaceAll(content, "\" \"", "");
-	return strings.ReplaceAll(newContent, "\"", "");
+	if text, err := parseTXTContent(content); err == nil {
+		return text
+	}
+	if isQuotedContent(content) {
+		return joinQuotedSegments(content, "")
+	}
+	return strings.ReplaceAll(content, "\"", "")
+}
+
+// normalizeContent normalizes the content of records whose type is listed in
+// normalize_record_types. The content of other record types is kept verbatim,
+// except that host names are shown in Unicode if idn_display is "unicode".
+func (c *Client) normalizeContent(recordType, content string) string {
+	if !c.normalizeRecordTypes[recordType] {
+		if c.idnDisplay == idnDisplayUnicode {
+			content, _ = mapContentHostName(recordType, content, unicodeHostName)
+		}
+		return content
+	}
+	return normalizeRecordContent(content)
+}
+
+// apiContent returns the content of a record sent to the API. Internationalized
+// host names are sent in ASCII compatible form, the content of MX and SRV
+// records is sent in canonical form, and the content of record types listed in
+// normalize_record_types is escaped, so that it reads back unaltered once
+// normalized.
+func (c *Client) apiContent(recordType, content string) string {
+	content = canonicalContent(recordType, aceContent(recordType, content))
+	if c.normalizeRecordTypes[c.apiRecordType(recordType)] {
+		return quoteTXTContent(content)
+	}
+	return content
+}
+
+// apiContentSplit returns the content of a record sent to the API like
+// apiContent. If autoSplit is set, the text of TXT content is also split into
+// quoted character strings of at most 255 bytes, which read back as the
+// configured content.
+func (c *Client) apiContentSplit(recordType, content string, autoSplit types.Bool) string {
+	content = c.apiContent(recordType, content)
+	if autoSplit.ValueBool() && c.apiRecordType(recordType) == "TXT" {
+		return splitTXTContent(content)
+	}
+	return content
+}
+
+// addNormalizationWarning warns that the legacy normalization of
+// normalize_record_types changed the content of a record, unless the
+// normalization_warning option is disabled.
+func (c *Client) addNormalizationWarning(diags *diag.Diagnostics, record DNSRecord, normalized string) {
+	if !c.normalizationWarning || record.Content == normalized {
+		return
+	}
+	diags.AddAttributeWarning(
+		path.Root("content"),
+		"Record content normalized",
+		fmt.Sprintf("The content of %s record %s was altered by removing quotes, from %q to %q. "+
+			"This legacy normalization applies to the record types listed in the provider's normalize_record_types option. "+
+			"Remove %s from normalize_record_types to store its content verbatim, or set normalization_warning to false to silence this warning.",
+			record.Type, record.Name, record.Content, normalized, record.Type),
+	)
+}
+
+// withCommentPrefix marks a record comment as managed by Terraform by
+// prepending the configured prefix. An empty prefix disables marking.
+func withCommentPrefix(prefix, comments string) string {
+	if prefix == "" || strings.HasPrefix(comments, prefix) {
+		return comments
+	}
+	return prefix + comments
+}
+
+// splitCommentPrefix removes the configured prefix from a record comment and
+// reports whether it was present, i.e. whether the record is managed by Terraform.
+func splitCommentPrefix(prefix, comments string) (string, bool) {
+	if prefix == "" || !strings.HasPrefix(comments, prefix) {
+		return comments, false
+	}
+	return strings.TrimPrefix(comments, prefix), true
+}
+
+// maxCommentsLength is the maximum number of characters of a record comment
+// accepted by the API, including the comment prefix.
+const maxCommentsLength = 255
+
+// apiComments returns the comment sent to the API for the configured comment,
+// truncated to maxCommentsLength if truncate_comments is enabled.
+func (c *Client) apiComments(comments string) string {
+	comments = withCommentPrefix(c.commentPrefix, comments)
+	if runes := []rune(comments); c.truncateComments && len(runes) > maxCommentsLength {
+		return string(runes[:maxCommentsLength])
+	}
+	return comments
+}
+
+// stateComments returns the comment stored in state for a comment returned by
+// the API, and whether the record is managed by Terraform. A configured
+// comment that was truncated is kept, so it does not show a difference.
+func (c *Client) stateComments(configured types.String, returned string) (types.String, bool) {
+	comments, managed := splitCommentPrefix(c.commentPrefix, returned)
+	if c.truncateComments && !configured.IsNull() && c.apiComments(configured.ValueString()) == returned {
+		return configured, managed
+	}
+	return types.StringValue(comments), managed
+}
+
+// apiRecordType returns the record type sent to the API. SPF records are
+// published as TXT records if convert_spf_to_txt is enabled.
+func (c *Client) apiRecordType(recordType string) string {
+	if recordType == "SPF" && c.convertSPFToTXT {
+		return "TXT"
+	}
+	return recordType
+}
+
+// statePriority returns the priority stored in state, null for records
+// whose type has no priority.
+func statePriority(recordType string, priority int) types.Int64 {
+	if !recordTypeHasPriority(recordType) {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(priority))
+}
+
+// stateRecordType returns the record type stored in state, keeping the
+// configured SPF type for records that were converted to TXT.
+func stateRecordType(configuredType, returnedType string) string {
+	if configuredType == "SPF" && returnedType == "TXT" {
+		return configuredType
+	}
+	return returnedType
+}
+
+// matchReturnedRecord finds the record in an API response. A record with the
+// ID of record is preferred, otherwise the record is matched by name, type and
+// content, also accepting returned content that only matches once normalized
+// or differs in insignificant formatting.
+// The returned record's content is not modified.
+func matchReturnedRecord(records []DNSRecord, record DNSRecord) (DNSRecord, bool) {
+	matchContent := func(candidate DNSRecord) (DNSRecord, bool) {
+		if sameRecordContent(record.Type, candidate.Content, record.Content) || normalizeRecordContent(candidate.Content) == record.Content {
+			return candidate, true
+		}
+		return DNSRecord{}, false
+	}
+
+	if record.ID != "" {
+		for _, candidate := range records {
+			if candidate.ID != record.ID {
+				continue
+			}
+			if matched, ok := matchContent(candidate); ok {
+				return matched, true
+			}
+		}
+	}
+
+	for _, candidate := range records {
+		if candidate.Name != record.Name || candidate.Type != record.Type {
+			continue
+		}
+		if matched, ok := matchContent(candidate); ok {
+			return matched, true
+		}
+	}
+
+	return DNSRecord{}, false
+}
+
+// resolvePlanZone sets the zone ID of a record configured by zone name,
+// creating the zone if it does not exist and auto_create_zone is enabled. It
+// reports false if the zone could not be resolved. prior is the state of an
+// existing record, used to tell a renamed zone apart from a missing one, and
+// nil when creating a record.
+func (r *recordResource) resolvePlanZone(ctx context.Context, plan *recordResourceModel, prior *recordResourceModel, diags *diag.Diagnostics) bool {
+	if !plan.ZoneID.IsNull() && !plan.ZoneID.IsUnknown() {
+		return true
+	}
+
+	zoneName := plan.ZoneName.ValueString()
+	zoneConfig, created, err := r.client.resolveZone(ctx, zoneName)
+	if err != nil {
+		detail := "Could not read hosting.de DNS zone " + zoneName + ": " + err.Error()
+		if changed, ok := r.zoneChangeDetail(ctx, prior, err); ok {
+			detail += "\n\n" + changed
+		} else if errors.Is(err, ErrNotFound) {
+			detail += "\n\nCreate the zone first, or enable the provider's auto_create_zone option to create it along with the record."
+		}
+		diags.AddAttributeError(path.Root("zone_name"), "Error Reading hosting.de DNS zone", detail)
+		return false
+	}
+	if created {
+		diags.AddAttributeWarning(
+			path.Root("zone_name"),
+			"Zone created automatically",
+			fmt.Sprintf("The zone %s (ID %s) did not exist and was created with the default name server set, "+
+				"because auto_create_zone is enabled. It is not managed by Terraform.", zoneConfig.Name, zoneConfig.ID),
+		)
+	}
+	plan.ZoneID = types.StringValue(zoneConfig.ID)
+	return true
+}
+
+// zoneChangeDetail describes why the zone of an existing record could not be
+// found by name, if the zone it was read from has been renamed or deleted.
+func (r *recordResource) zoneChangeDetail(ctx context.Context, prior *recordResourceModel, err error) (string, bool) {
+	if prior == nil || !errors.Is(err, ErrNotFound) {
+		return "", false
+	}
+	return r.client.zoneChangeDetail(ctx, prior.ZoneID.ValueString(), prior.ZoneName.ValueString())
+}
+
+// resolvePlanTTL sets the default TTL of a planned record without a
+// configured TTL, which may depend on a zone only known during apply.
+func (r *recordResource) resolvePlanTTL(ctx context.Context, plan *recordResourceModel) {
+	if !plan.TTL.IsUnknown() && !plan.TTL.IsNull() {
+		return
+	}
+	ttl, _ := r.client.defaultRecordTTL(ctx, plan.ZoneID.ValueString())
+	plan.TTL = types.Int64Value(ttl)
+}
+
+// recordFQDN returns the absolute name of a planned record. Names relative to
+// the zone are expanded using the name of the zone, if the plan does not
+// contain the expanded name yet.
+func (r *recordResource) recordFQDN(ctx context.Context, plan recordResourceModel) (string, error) {
+	if !plan.FQDN.IsNull() && !plan.FQDN.IsUnknown() {
+		return plan.FQDN.ValueString(), nil
+	}
+
+	name := plan.Name.ValueString()
+	if strings.HasSuffix(name, ".") {
+		return expandRecordName(name, ""), nil
+	}
+	zoneConfig, err := r.client.getZoneConfig(ctx, plan.ZoneID.ValueString())
+	if err != nil {
+		return "", err
+	}
+	return expandRecordName(name, zoneConfig.Name), nil
+}
+
+// addZoneStatusWarning warns if a zone is not active, e.g. because it is
+// pending, blocked or restorable.
+func addZoneStatusWarning(diags *diag.Diagnostics, zoneConfig *ZoneConfig) {
+	if zoneConfig.Status == "" || zoneConfig.Status == "active" {
+		return
+	}
+	if detail := zoneRestorableDetail(zoneConfig); detail != "" {
+		diags.AddAttributeWarning(path.Root("zone_status"), "hosting.de DNS zone is deleted", detail)
+		return
+	}
+	diags.AddAttributeWarning(
+		path.Root("zone_status"),
+		"Zone not active",
+		fmt.Sprintf("Zone %s (ID %s) has status %s, so changes to its records may fail until it is active again.",
+			zoneConfig.Name, zoneConfig.ID, zoneConfig.Status),
+	)
+}
+
+// addTTLClampWarning warns if the API applied a different TTL than requested,
+// e.g. because the zone clamps TTLs to a minimum.
+func addTTLClampWarning(diags *diag.Diagnostics, requested, applied int) {
+	if requested == applied {
+		return
+	}
+	diags.AddAttributeWarning(
+		path.Root("ttl"),
+		"Record TTL changed by hosting.de",
+		fmt.Sprintf("The requested TTL of %d seconds was clamped to %d seconds by the API. "+
+			"The applied TTL is stored in the state, set ttl to %d to avoid a difference on the next plan.", requested, applied, applied),
+	)
 }
 
 // NewRecordResource is a helper function to simplify the provider implementation.
@@ -41,12 +329,72 @@ type recordResource struct {
 type recordResourceModel struct {
 	ID       types.String `tfsdk:"id"`
 	ZoneID   types.String `tfsdk:"zone_id"`
-	Name     types.String `tfsdk:"name"`
-	Type     types.String `tfsdk:"type"`
-	Content  types.String `tfsdk:"content"`
-	TTL      types.Int64  `tfsdk:"ttl"`
-	Priority types.Int64  `tfsdk:"priority"`
-	Comments types.String `tfsdk:"comments"`
+	ZoneName types.String `tfsdk:"zone_name"`
+	// ZoneStatus is the status of the record's zone as of the last read.
+	ZoneStatus types.String `tfsdk:"zone_status"`
+	Name       types.String `tfsdk:"name"`
+	FQDN       types.String `tfsdk:"fqdn"`
+	Type       types.String `tfsdk:"type"`
+	Content    types.String `tfsdk:"content"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	Priority   types.Int64  `tfsdk:"priority"`
+	Comments   types.String `tfsdk:"comments"`
+
+	RecordTemplateID types.String `tfsdk:"record_template_id"`
+
+	ContentHash types.String `tfsdk:"content_hash"`
+
+	AccountID        types.String `tfsdk:"account_id"`
+	OwnerAccountID   types.String `tfsdk:"owner_account_id"`
+	TerraformManaged types.Bool   `tfsdk:"terraform_managed"`
+	Upsert           types.Bool   `tfsdk:"upsert"`
+	TXTJoin          types.String `tfsdk:"txt_join"`
+	AutoSplit        types.Bool   `tfsdk:"auto_split"`
+
+	// Structured content of DS records.
+	DSKeyTag     types.Int64  `tfsdk:"ds_key_tag"`
+	DSAlgorithm  types.Int64  `tfsdk:"ds_algorithm"`
+	DSDigestType types.Int64  `tfsdk:"ds_digest_type"`
+	DSDigest     types.String `tfsdk:"ds_digest"`
+
+	// Structured content of TLSA records.
+	TLSAUsage        types.Int64  `tfsdk:"tlsa_usage"`
+	TLSASelector     types.Int64  `tfsdk:"tlsa_selector"`
+	TLSAMatchingType types.Int64  `tfsdk:"tlsa_matching_type"`
+	TLSADataBase64   types.String `tfsdk:"tlsa_data_base64"`
+
+	// Structured content of SSHFP records.
+	SSHFPAlgorithm         types.Int64  `tfsdk:"sshfp_algorithm"`
+	SSHFPFingerprintType   types.Int64  `tfsdk:"sshfp_fingerprint_type"`
+	SSHFPFingerprintBase64 types.String `tfsdk:"sshfp_fingerprint_base64"`
+}
+
+// setContentHash sets the content hash from the stored name, type, canonical
+// content and TTL of the record.
+func (m *recordResourceModel) setContentHash() {
+	fields := []string{
+		strings.ToLower(m.FQDN.ValueString()),
+		strings.ToUpper(m.Type.ValueString()),
+		canonicalContent(m.Type.ValueString(), m.Content.ValueString()),
+		strconv.FormatInt(m.TTL.ValueInt64(), 10),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\n")))
+	m.ContentHash = types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+// stateAPIRecord returns the record stored in state in the form sent to the
+// API.
+func (c *Client) stateAPIRecord(state recordResourceModel) DNSRecord {
+	return DNSRecord{
+		ID:       state.ID.ValueString(),
+		ZoneID:   state.ZoneID.ValueString(),
+		Name:     state.FQDN.ValueString(),
+		Type:     c.apiRecordType(state.Type.ValueString()),
+		Content:  c.apiContentSplit(state.Type.ValueString(), state.Content.ValueString(), state.AutoSplit),
+		TTL:      int(state.TTL.ValueInt64()),
+		Priority: int(state.Priority.ValueInt64()),
+		Comments: c.apiComments(state.Comments.ValueString()),
+	}
 }
 
 // Metadata returns the resource type name.
@@ -57,51 +405,230 @@ func (r *recordResource) Metadata(_ context.Context, req resource.MetadataReques
 // Schema defines the schema for the resource.
 func (r *recordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 added the attributes derived from the record, such as
+		// fqdn and the structured content. See UpgradeState.
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "DNS record ID",
+				Description: "DNS record ID. The API may assign a new ID when the record is modified.",
+				Computed:    true,
+			},
+			"account_id": schema.StringAttribute{
+				Description: "ID of the account owning the record's zone.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"owner_account_id": schema.StringAttribute{
+				Description: "ID of the account on whose behalf the record is created, read, modified and deleted, " +
+					"which takes precedence over the provider's account_id, e.g. for zones of a reseller's sub-accounts. " +
+					"Changing it replaces the record, as records cannot move between accounts.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"zone_id": schema.StringAttribute{
-				Description: "ID of DNS zone that the record belongs to.",
-				Required:    true,
+				Description: "ID of DNS zone that the record belongs to. Either zone_id or zone_name must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"zone_name": schema.StringAttribute{
+				Description: "Name of DNS zone that the record belongs to. Either zone_id or zone_name must be set. " +
+					"If the zone is set by name and does not exist, it is created if the provider's auto_create_zone option is enabled.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
-				Description: "Name of the record. Example: mail.example.com.",
-				Required:    true,
+				Description: "Name of the record. Example: mail.example.com. Like in a zone file, \"@\" refers to the zone apex " +
+					"and names not ending with the zone name are relative to the zone, e.g. \"mail\". Names ending with a dot are absolute. " +
+					"Labels may contain letters, digits, hyphens and underscores, e.g. _dmarc, and \"*\" as the leftmost label of a wildcard name.",
+				Required: true,
+			},
+			"zone_status": schema.StringAttribute{
+				Description: "Status of the record's zone as of the last read, e.g. active, pending, blocked or restorable. " +
+					"Reading a record of a zone that is not active adds a warning, as changes to its records may fail.",
+				Computed: true,
+			},
+			"fqdn": schema.StringAttribute{
+				Description: "Absolute name of the record, with \"@\" and relative names expanded.",
+				Computed:    true,
+			},
+			"content_hash": schema.StringAttribute{
+				Description: "Hex encoded SHA-256 hash of the absolute name, type, normalized content and TTL of the record. " +
+					"Changes whenever one of them changes, e.g. for external change detection.",
+				Computed: true,
 			},
 			"type": schema.StringAttribute{
-				Description: "Type of the DNS record. Valid types are A, AAAA, ALIAS, CAA, CERT, CNAME, DNSKEY, DS, MX, NS, NSEC, NSEC3, NSEC3PARAM, NULLMX, OPENPGPKEY, PTR, RRSIG, SRV, SSHFP, TLSA, and TXT.",
-				Required:    true,
+				Description: "Type of the DNS record. Valid types are A, AAAA, ALIAS, CAA, CERT, CNAME, DNSKEY, DS, MX, NS, NSEC, NSEC3, NSEC3PARAM, NULLMX, OPENPGPKEY, PTR, RRSIG, SRV, SSHFP, TLSA, and TXT. Other types, such as NAPTR, are not supported by the API and fail validation. " +
+					"The deprecated SPF type is only accepted by the API if the provider's convert_spf_to_txt option publishes it as TXT. " +
+					"ALIAS records are resolved by the hosting.de name servers at query time: queries for A and AAAA records of the name " +
+					"are answered with the A and AAAA records of the target, so resolvers do not need to support ALIAS. " +
+					"The content of an ALIAS record must be a fully qualified host name, not an IP address or the record's own name. " +
+					"Unlike CNAME, ALIAS records can be placed at the zone apex next to other records. The API has no options to change ALIAS resolution. " +
+					"NULLMX records declare that a domain does not accept email (RFC 7505): their content must be \".\" and their priority, if set, 0. " +
+					"Defaults to the provider's default_record_type, and is required if that is not set.",
+				Optional: true,
+				Computed: true,
 			},
 			"content": schema.StringAttribute{
-				Description: "Content of the DNS record.",
-				Required:    true,
+				Description: "Content of the DNS record. Required, unless the content of a DS, TLSA or SSHFP record is set by the ds_*, tlsa_* or sshfp_* attributes. " +
+					"The addresses of A and AAAA records are compared in their shortest form, e.g. 2001:db8::1 for 2001:DB8:0::0:1. " +
+					"The content of MX and SRV records is compared ignoring the case and trailing dot of host names and the whitespace between fields. " +
+					"The content of these types is sent to the API in canonical form. " +
+					"Changing the content of DNSKEY, NSEC, NSEC3, NSEC3PARAM and RRSIG records re-creates the record, " +
+					"the content of other types is modified in place.",
+				Optional: true,
+				Computed: true,
 			},
-			"ttl": schema.Int64Attribute{
-				Description: "TTL of the DNS record in seconds. Minimum is 60, maximum is 31556926. Defaults to 3600.",
+			"ds_key_tag": schema.Int64Attribute{
+				Description: "Key tag of the DNSKEY referenced by a DS record. Set together with the other ds_* attributes instead of content.",
+				Optional:    true,
 				Computed:    true,
-				Required:    false,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 65535),
+				},
+			},
+			"ds_algorithm": schema.Int64Attribute{
+				Description: "DNSSEC algorithm number of the DNSKEY referenced by a DS record, e.g. 13 for ECDSAP256SHA256.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 255),
+				},
+			},
+			"ds_digest_type": schema.Int64Attribute{
+				Description: "Digest type of a DS record: 1 for SHA-1, 2 for SHA-256 or 4 for SHA-384.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 255),
+				},
+			},
+			"ds_digest": schema.StringAttribute{
+				Description: "Hex encoded digest of a DS record. Must have 40 characters for SHA-1, 64 for SHA-256 and 96 for SHA-384.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"tlsa_usage": schema.Int64Attribute{
+				Description: "Certificate usage of a TLSA record: 0 (PKIX-TA), 1 (PKIX-EE), 2 (DANE-TA) or 3 (DANE-EE). Set together with the other tlsa_* attributes instead of content.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 3),
+				},
+			},
+			"tlsa_selector": schema.Int64Attribute{
+				Description: "Selector of a TLSA record: 0 for the full certificate or 1 for the public key.",
 				Optional:    true,
-				Default:     int64default.StaticInt64(3600),
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1),
+				},
+			},
+			"tlsa_matching_type": schema.Int64Attribute{
+				Description: "Matching type of a TLSA record: 0 for the exact data, 1 for its SHA-256 or 2 for its SHA-512 hash.",
+				Optional:    true,
+				Computed:    true,
 				Validators: []validator.Int64{
-					int64validator.Between(60, 31556926),
+					int64validator.Between(0, 2),
+				},
+			},
+			"tlsa_data_base64": schema.StringAttribute{
+				Description: "Base64 encoded certificate association data of a TLSA record. Published hex encoded in content.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"sshfp_algorithm": schema.Int64Attribute{
+				Description: "Key algorithm of an SSHFP record: 1 (RSA), 2 (DSA), 3 (ECDSA), 4 (Ed25519) or 6 (Ed448). Set together with the other sshfp_* attributes instead of content.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(1, 2, 3, 4, 6),
+				},
+			},
+			"sshfp_fingerprint_type": schema.Int64Attribute{
+				Description: "Fingerprint type of an SSHFP record: 1 for SHA-1 or 2 for SHA-256.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(1, 2),
+				},
+			},
+			"sshfp_fingerprint_base64": schema.StringAttribute{
+				Description: "Base64 encoded fingerprint of an SSHFP record, as printed by ssh-keygen. Published hex encoded in content.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the DNS record in seconds. Minimum is 60, maximum is 31556926. " +
+					"Defaults to the provider's default_ttl if set, otherwise to the TTL of the zone's SOA record, and to 3600 if the zone has none.",
+				Computed: true,
+				Required: false,
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(minRecordTTL, maxRecordTTL),
 				},
 			},
 			"priority": schema.Int64Attribute{
-				Description: "Priority of MX and SRV records.",
+				Description: "Priority of MX and SRV records. Records of other types have no priority, which is not sent to the API.",
 				Computed:    true,
 				Required:    false,
 				Optional:    true,
 			},
 			"comments": schema.StringAttribute{
-				Description: "Comment to the record.",
+				Description: "Comment to the record. The API accepts at most 255 characters, including the provider's comment_prefix. " +
+					"Longer comments fail to plan unless the provider's truncate_comments option is enabled.",
+				Computed: true,
+				Required: false,
+				Optional: true,
+			},
+			"record_template_id": schema.StringAttribute{
+				Description: "ID of the record template the record is linked to, for zones using DNS templates. " +
+					"The template must exist.",
+				Optional: true,
+			},
+			"upsert": schema.BoolAttribute{
+				Description: "Take over an existing record with the same name and type on create, modifying it to the " +
+					"configured content instead of adding another record. Fails if more than one such record exists. " +
+					"This can overwrite records not managed by Terraform. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"txt_join": schema.StringAttribute{
+				Description: "How the character strings of TXT content with several quoted strings are joined when read, " +
+					"for record types listed in the provider's normalize_record_types. " +
+					"concatenate joins them without separator, as the API and DNS resolvers do. " +
+					"space separates them by a space, e.g. to keep long DMARC or DKIM policies readable. Defaults to concatenate.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(txtJoinConcatenate),
+				Validators: []validator.String{
+					stringvalidator.OneOf(txtJoinConcatenate, txtJoinSpace),
+				},
+			},
+			"auto_split": schema.BoolAttribute{
+				Description: "Split the text of TXT content into quoted character strings of at most 255 bytes when sent " +
+					"to the API, e.g. for long DKIM keys. The character strings are joined again when read, so the " +
+					"configured content is kept in state. Cannot be combined with txt_join = \"space\". Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"terraform_managed": schema.BoolAttribute{
+				Description: "Whether the record's comment carries the provider's comment_prefix marker.",
 				Computed:    true,
-				Required:    false,
-				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -109,6 +636,9 @@ func (r *recordResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 
 // Create a new resource
 func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan recordResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -116,25 +646,84 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withAccountID(ctx, plan.OwnerAccountID)
+
+	if !r.resolvePlanZone(ctx, &plan, nil, &resp.Diagnostics) {
+		return
+	}
+	r.resolvePlanTTL(ctx, &plan)
+
+	fqdn, err := r.recordFQDN(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not expand the record name "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
 
 	// Generate API request body from plan
 	record := DNSRecord{
-		Name:     plan.Name.ValueString(),
+		Name:     fqdn,
 		ZoneID:   plan.ZoneID.ValueString(),
-		Type:     plan.Type.ValueString(),
-		Content:  plan.Content.ValueString(),
+		Type:     r.client.apiRecordType(plan.Type.ValueString()),
+		Content:  r.client.apiContentSplit(plan.Type.ValueString(), plan.Content.ValueString(), plan.AutoSplit),
 		TTL:      int(plan.TTL.ValueInt64()),
 		Priority: int(plan.Priority.ValueInt64()),
-		Comments: plan.Comments.ValueString(),
+		Comments: r.client.apiComments(plan.Comments.ValueString()),
+
+		RecordTemplateID: plan.RecordTemplateID.ValueString(),
 	}
 
 	recordReq := RecordsUpdateRequest{
-		BaseRequest:  &BaseRequest{},
+		BaseRequest:  r.client.newBaseRequest(ctx),
 		ZoneConfigId: plan.ZoneID.ValueString(),
 		RecordsToAdd: []DNSRecord{record},
 	}
 
-	recordResp, err := r.client.updateRecords(recordReq)
+	// In upsert mode an existing record with the same name and type is
+	// modified to the desired content instead.
+	var previous []DNSRecord
+	if plan.Upsert.ValueBool() {
+		existing, err := r.client.findRecords(ctx, record.ZoneID, record.Name, record.Type, "")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS record",
+				"Could not look up existing hosting.de DNS records "+record.Name+": "+err.Error(),
+			)
+			return
+		}
+		if len(existing) > 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("upsert"),
+				"Ambiguous upsert of hosting.de DNS record",
+				fmt.Sprintf("Found %d %s records named %s, upsert can only take over a single record.", len(existing), record.Type, record.Name),
+			)
+			return
+		}
+		if len(existing) == 1 {
+			resp.Diagnostics.AddWarning(
+				"Overwriting existing hosting.de DNS record",
+				fmt.Sprintf("The existing %s record %s (ID %s) with content %q is modified to the configured content.",
+					record.Type, record.Name, existing[0].ID, r.client.normalizeContent(existing[0].Type, existing[0].Content)),
+			)
+			record.ID = existing[0].ID
+			previous = existing
+			recordReq.RecordsToAdd = nil
+			recordReq.RecordsToModify = []DNSRecord{record}
+		}
+	}
+
+	r.client.logRecordMutations(ctx, recordReq, previous)
+	recordResp, err := r.client.updateZoneRecords(ctx, recordReq)
+	if detail, ok := r.client.recordLimitDetail(ctx, record.ZoneID, err); ok {
+		resp.Diagnostics.AddError("hosting.de DNS zone record limit reached", detail)
+		return
+	}
+	if detail, ok := r.client.zoneRestorableErrorDetail(ctx, record.ZoneID, err); ok {
+		resp.Diagnostics.AddAttributeError(path.Root("zone_id"), "hosting.de DNS zone is deleted", detail)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating records",
@@ -143,32 +732,41 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	var returnedRecord DNSRecord
-	for _, responseRecord := range recordResp.Response.Records {
-		if responseRecord.Name == record.Name && responseRecord.Type == record.Type {
-			if responseRecord.Content == record.Content {
-				returnedRecord = responseRecord
-				break;
-			} 
+	returnedRecord, found := matchReturnedRecord(recordResp.Response.Records, record)
 
-			normalizedContent := normalizeRecordContent(responseRecord.Content);
-			if normalizedContent == record.Content {
-				returnedRecord = responseRecord
-				returnedRecord.Content = normalizedContent
-				break;
-			} 
+	// The response may not contain the record yet, e.g. if the change is
+	// pending, so look it up until it is found.
+	if !found {
+		returnedRecord, err = r.client.findCreatedRecord(ctx, record)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS record",
+				"Could not find created hosting.de DNS record "+record.Name+": "+err.Error(),
+			)
+			return
 		}
 	}
+	normalizedContent := r.client.normalizeContentJoin(returnedRecord.Type, returnedRecord.Content, plan.TXTJoin)
+	r.client.addNormalizationWarning(&resp.Diagnostics, returnedRecord, normalizedContent)
+	returnedRecord.Content = normalizedContent
 
 	// Overwrite DNS record with refreshed state
-	plan.ZoneID = types.StringValue(recordResp.Response.ZoneConfig.ID)
+	plan.ZoneID = types.StringValue(record.ZoneID)
 	plan.ID = types.StringValue(returnedRecord.ID)
-	plan.Name = types.StringValue(returnedRecord.Name)
-	plan.Type = types.StringValue(returnedRecord.Type)
-	plan.Content = types.StringValue(returnedRecord.Content)
+	plan.FQDN = types.StringValue(returnedRecord.Name)
+	plan.Type = types.StringValue(stateRecordType(plan.Type.ValueString(), returnedRecord.Type))
+	plan.Content = r.client.stateContentJoin(returnedRecord.Type, plan.Content, returnedRecord.Content, plan.TXTJoin)
+	plan.setStructuredContent()
+	addTTLClampWarning(&resp.Diagnostics, record.TTL, returnedRecord.TTL)
 	plan.TTL = types.Int64Value(int64(returnedRecord.TTL))
-	plan.Priority = types.Int64Value(int64(returnedRecord.Priority))
-	plan.Comments = types.StringValue(returnedRecord.Comments)
+	plan.Priority = statePriority(returnedRecord.Type, returnedRecord.Priority)
+	comments, managed := r.client.stateComments(plan.Comments, returnedRecord.Comments)
+	plan.Comments = comments
+	plan.TerraformManaged = types.BoolValue(managed)
+	plan.AccountID = types.StringValue(recordResp.Response.ZoneConfig.AccountID)
+	plan.ZoneName = types.StringValue(recordResp.Response.ZoneConfig.Name)
+	plan.ZoneStatus = types.StringValue(recordResp.Response.ZoneConfig.Status)
+	plan.setContentHash()
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -180,6 +778,9 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 
 // Read refreshes the Terraform state with the latest data.
 func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
 	// Get current state
 	var state recordResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -187,37 +788,90 @@ func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	recordReq := RecordsFindRequest{
-		BaseRequest: &BaseRequest{},
-		Filter: FilterOrChain{Filter: Filter{
-			Field: "RecordId",
-			Value: state.ID.ValueString(),
-		}},
-		Limit: 1,
-		Page:  1,
-	}
+	ctx = withAccountID(ctx, state.OwnerAccountID)
 
 	// Get refreshed DNS record from hostingde
-	recordResp, err := r.client.listRecords(recordReq)
+	returnedRecord, err := r.client.getCachedRecord(ctx, state.ZoneID.ValueString(), state.ID.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		// The record was deleted outside of Terraform, plan to re-create it.
+		// Report if its zone was renamed or deleted, which takes the record
+		// along.
+		tflog.Info(ctx, "Record not found, removing from state", map[string]any{"id": state.ID.ValueString()})
+		if detail, ok := r.client.zoneChangeDetail(ctx, state.ZoneID.ValueString(), state.ZoneName.ValueString()); ok {
+			resp.Diagnostics.AddWarning(
+				"hosting.de DNS zone changed",
+				"The record ID "+state.ID.ValueString()+" was not found and is removed from state. "+detail,
+			)
+		}
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS record",
+			"Could not read hosting.de DNS record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	// The find response does not include the zone config, which is cached
+	// per zone. Failing to read it is only an error if the zone name is not
+	// known yet, e.g. after an import.
+	zoneConfig, err := r.client.getZoneConfig(ctx, returnedRecord.ZoneID)
+	switch {
+	case err == nil:
+		// The account owning the record is the one owning its zone.
+		checkAccountMove(&resp.Diagnostics, "record", state.ID.ValueString(), state.AccountID.ValueString(), zoneConfig.AccountID)
+		state.ZoneName = types.StringValue(zoneConfig.Name)
+		state.ZoneStatus = types.StringValue(zoneConfig.Status)
+		if zoneConfig.AccountID != "" {
+			state.AccountID = types.StringValue(zoneConfig.AccountID)
+		}
+		addZoneStatusWarning(&resp.Diagnostics, zoneConfig)
+	case state.ZoneName.ValueString() == "" || state.ZoneID.ValueString() != returnedRecord.ZoneID:
 		resp.Diagnostics.AddError(
 			"Error Reading hosting.de DNS zone",
-			"Could not read hosting.de DNS zone ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read hosting.de DNS zone ID "+returnedRecord.ZoneID+": "+err.Error(),
 		)
 		return
+	default:
+		tflog.Debug(ctx, "Could not read zone config to refresh the zone status", map[string]any{"error": err.Error()})
 	}
 
-	returnedRecord := recordResp.Response.Data[0]
 	// Overwrite DNS record with refreshed state
 	state.ZoneID = types.StringValue(returnedRecord.ZoneID)
 	state.ID = types.StringValue(returnedRecord.ID)
-	state.Name = types.StringValue(returnedRecord.Name)
-	state.Type = types.StringValue(returnedRecord.Type)
-	state.Content = types.StringValue(normalizeRecordContent(returnedRecord.Content))
+	// Keep the configured name as long as it expands to the record's name.
+	if state.Name.IsNull() || expandRecordName(state.Name.ValueString(), state.ZoneName.ValueString()) != returnedRecord.Name {
+		state.Name = types.StringValue(returnedRecord.Name)
+	}
+	state.FQDN = types.StringValue(returnedRecord.Name)
+	state.Type = types.StringValue(stateRecordType(state.Type.ValueString(), returnedRecord.Type))
+	normalizedContent := r.client.normalizeContentJoin(returnedRecord.Type, returnedRecord.Content, state.TXTJoin)
+	r.client.addNormalizationWarning(&resp.Diagnostics, returnedRecord, normalizedContent)
+	state.Content = r.client.stateContentJoin(returnedRecord.Type, state.Content, normalizedContent, state.TXTJoin)
+	state.setStructuredContent()
 	state.TTL = types.Int64Value(int64(returnedRecord.TTL))
-	state.Priority = types.Int64Value(int64(returnedRecord.Priority))
-	state.Comments = types.StringValue(returnedRecord.Comments)
+	state.Priority = statePriority(returnedRecord.Type, returnedRecord.Priority)
+	comments, managed := r.client.stateComments(state.Comments, returnedRecord.Comments)
+	state.Comments = comments
+	state.TerraformManaged = types.BoolValue(managed)
+	state.RecordTemplateID = types.StringNull()
+	if returnedRecord.RecordTemplateID != "" {
+		state.RecordTemplateID = types.StringValue(returnedRecord.RecordTemplateID)
+	}
+	// Imported records have no upsert, txt_join and auto_split settings yet.
+	// Their defaults are set, so the first plan after an import shows no
+	// changes.
+	if state.Upsert.IsNull() {
+		state.Upsert = types.BoolValue(false)
+	}
+	if state.AutoSplit.IsNull() {
+		state.AutoSplit = types.BoolValue(false)
+	}
+	if state.TXTJoin.IsNull() {
+		state.TXTJoin = types.StringValue(txtJoinConcatenate)
+	}
+	state.setContentHash()
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -229,6 +883,9 @@ func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan recordResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -236,26 +893,66 @@ func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withAccountID(ctx, plan.OwnerAccountID)
+
+	// The prior state tells a renamed zone apart from a missing one.
+	var prior *recordResourceModel
+	if plan.ZoneID.IsUnknown() {
+		prior = &recordResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !r.resolvePlanZone(ctx, &plan, prior, &resp.Diagnostics) {
+		return
+	}
+	r.resolvePlanTTL(ctx, &plan)
+
+	fqdn, err := r.recordFQDN(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not expand the record name "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
 
 	// Generate API request body from plan
 	record := DNSRecord{
-		Name:     plan.Name.ValueString(),
+		Name:     fqdn,
 		ID:       plan.ID.ValueString(),
 		ZoneID:   plan.ZoneID.ValueString(),
-		Type:     plan.Type.ValueString(),
-		Content:  plan.Content.ValueString(),
+		Type:     r.client.apiRecordType(plan.Type.ValueString()),
+		Content:  r.client.apiContentSplit(plan.Type.ValueString(), plan.Content.ValueString(), plan.AutoSplit),
 		TTL:      int(plan.TTL.ValueInt64()),
 		Priority: int(plan.Priority.ValueInt64()),
-		Comments: plan.Comments.ValueString(),
+		Comments: r.client.apiComments(plan.Comments.ValueString()),
+
+		RecordTemplateID: plan.RecordTemplateID.ValueString(),
 	}
 
 	recordReq := RecordsUpdateRequest{
-		BaseRequest:     &BaseRequest{},
+		BaseRequest:     r.client.newBaseRequest(ctx),
 		ZoneConfigId:    plan.ZoneID.ValueString(),
 		RecordsToModify: []DNSRecord{record},
 	}
 
-	recordResp, err := r.client.updateRecords(recordReq)
+	if r.client.logMutations || r.client.changeArtifact != nil {
+		var state recordResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		r.client.logRecordMutations(ctx, recordReq, []DNSRecord{r.client.stateAPIRecord(state)})
+	}
+	recordResp, err := r.client.updateZoneRecords(ctx, recordReq)
+	if detail, ok := r.client.recordLimitDetail(ctx, record.ZoneID, err); ok {
+		resp.Diagnostics.AddError("hosting.de DNS zone record limit reached", detail)
+		return
+	}
+	if detail, ok := r.client.zoneRestorableErrorDetail(ctx, record.ZoneID, err); ok {
+		resp.Diagnostics.AddAttributeError(path.Root("zone_id"), "hosting.de DNS zone is deleted", detail)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating records",
@@ -264,32 +961,46 @@ func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	var returnedRecord DNSRecord
-	for _, responseRecord := range recordResp.Response.Records {
-		if responseRecord.Name == record.Name && responseRecord.Type == record.Type {
-			if responseRecord.Content == record.Content {
-				returnedRecord = responseRecord
-				break;
-			}
-
-			normalizedContent := normalizeRecordContent(responseRecord.Content);
-			if normalizedContent == record.Content {
-				returnedRecord = responseRecord
-				returnedRecord.Content = normalizedContent
-				break;
-			}
+	// The API may replace the record on modify, so the matched record's ID
+	// is adopted even if it differs from the requested one.
+	returnedRecord, found := matchReturnedRecord(recordResp.Response.Records, record)
+	if !found {
+		returnedRecord, err = r.client.findCreatedRecord(ctx, record)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS record",
+				"Could not find updated hosting.de DNS record "+record.Name+": "+err.Error(),
+			)
+			return
 		}
 	}
+	normalizedContent := r.client.normalizeContentJoin(returnedRecord.Type, returnedRecord.Content, plan.TXTJoin)
+	r.client.addNormalizationWarning(&resp.Diagnostics, returnedRecord, normalizedContent)
+	returnedRecord.Content = normalizedContent
+	if returnedRecord.ID != record.ID {
+		tflog.Info(ctx, "Record ID changed on modify", map[string]any{
+			"previous_id": record.ID,
+			"id":          returnedRecord.ID,
+		})
+	}
 
 	// Overwrite DNS record with refreshed state
-	plan.ZoneID = types.StringValue(recordResp.Response.ZoneConfig.ID)
+	plan.ZoneID = types.StringValue(record.ZoneID)
 	plan.ID = types.StringValue(returnedRecord.ID)
-	plan.Name = types.StringValue(returnedRecord.Name)
-	plan.Type = types.StringValue(returnedRecord.Type)
-	plan.Content = types.StringValue(returnedRecord.Content)
+	plan.FQDN = types.StringValue(returnedRecord.Name)
+	plan.Type = types.StringValue(stateRecordType(plan.Type.ValueString(), returnedRecord.Type))
+	plan.Content = r.client.stateContentJoin(returnedRecord.Type, plan.Content, returnedRecord.Content, plan.TXTJoin)
+	plan.setStructuredContent()
+	addTTLClampWarning(&resp.Diagnostics, record.TTL, returnedRecord.TTL)
 	plan.TTL = types.Int64Value(int64(returnedRecord.TTL))
-	plan.Priority = types.Int64Value(int64(returnedRecord.Priority))
-	plan.Comments = types.StringValue(returnedRecord.Comments)
+	plan.Priority = statePriority(returnedRecord.Type, returnedRecord.Priority)
+	comments, managed := r.client.stateComments(plan.Comments, returnedRecord.Comments)
+	plan.Comments = comments
+	plan.TerraformManaged = types.BoolValue(managed)
+	plan.AccountID = types.StringValue(recordResp.Response.ZoneConfig.AccountID)
+	plan.ZoneName = types.StringValue(recordResp.Response.ZoneConfig.Name)
+	plan.ZoneStatus = types.StringValue(recordResp.Response.ZoneConfig.Status)
+	plan.setContentHash()
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -300,6 +1011,9 @@ func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
 	// Retrieve values from state
 	var state recordResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -307,22 +1021,32 @@ func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withAccountID(ctx, state.OwnerAccountID)
+
+	if err := r.client.waitDeleteGracePeriod(ctx, state.Type.ValueString()+" record "+state.FQDN.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Record",
+			"Record deletion was cancelled during the delete grace period: "+err.Error(),
+		)
+		return
+	}
 
 	// Generate API request body from plan
 	record := DNSRecord{
 		ID:   state.ID.ValueString(),
-		Name: state.Name.ValueString(),
-		Type: state.Type.ValueString(),
+		Name: state.FQDN.ValueString(),
+		Type: r.client.apiRecordType(state.Type.ValueString()),
 	}
 
 	recordReq := RecordsUpdateRequest{
-		BaseRequest:     &BaseRequest{},
+		BaseRequest:     r.client.newBaseRequest(ctx),
 		ZoneConfigId:    state.ZoneID.ValueString(),
 		RecordsToDelete: []DNSRecord{record},
 	}
 
 	// Delete existing record
-	_, err := r.client.updateRecords(recordReq)
+	r.client.logRecordMutations(ctx, recordReq, []DNSRecord{r.client.stateAPIRecord(state)})
+	_, err := r.client.updateZoneRecords(ctx, recordReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Record",
@@ -341,9 +1065,268 @@ func (r *recordResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = req.ProviderData.(*Client)
 }
 
+// ImportState imports a record by its ID, or by zone ID, name and type in the
+// form zone_id/name/type, optionally followed by /content to select one of
+// several records with the same name and type. The remaining attributes are
+// set by the read following the import.
 func (r *recordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if !strings.Contains(req.ID, "/") {
+		// Retrieve import ID and save to id attribute
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// The content is last, so it may contain slashes, e.g. in TXT records.
+	parts := strings.SplitN(req.ID, "/", 4)
+	if len(parts) < 3 || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Expected a record ID, or zone_id/name/type or zone_id/name/type/content, e.g. 1a2b3c/www.example.com/A, got "+req.ID+".",
+		)
+		return
+	}
+	zoneId, name, recordType := parts[0], strings.TrimSuffix(parts[1], "."), parts[2]
+	var content string
+	if len(parts) == 4 {
+		content = r.client.apiContent(recordType, parts[3])
+	}
+
+	records, err := r.client.findRecords(ctx, zoneId, name, r.client.apiRecordType(recordType), content)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not find the record to import "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+	switch len(records) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"hosting.de DNS record not found",
+			fmt.Sprintf("No %s record named %s exists in zone ID %s.", recordType, name, zoneId),
+		)
+		return
+	case 1:
+	default:
+		var ids []string
+		for _, record := range records {
+			ids = append(ids, record.ID+" ("+record.Content+")")
+		}
+		resp.Diagnostics.AddError(
+			"Several hosting.de DNS records found",
+			fmt.Sprintf("%d %s records named %s exist in zone ID %s: %s. "+
+				"Import one of them by its ID, or append its content to the import ID as zone_id/name/type/content.",
+				len(records), recordType, name, zoneId, strings.Join(ids, ", ")),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), records[0].ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), records[0].ZoneID)...)
+}
+
+// ModifyPlan assembles the content of records configured by structured
+// attributes, and the structured attributes of records configured by content.
+func (r *recordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan, state recordResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client != nil {
+		r.client.addAccountOverrideWarning(&resp.Diagnostics, path.Root("owner_account_id"), config.OwnerAccountID)
+	}
+	ctx = withAccountID(ctx, plan.OwnerAccountID)
+
+	// Records without a type use the provider's default type, which is
+	// only known now, so the type-specific validation runs here.
+	if config.Type.IsNull() {
+		if r.client == nil || r.client.defaultRecordType == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type"),
+				"Missing record type",
+				"Setting type is required, unless the provider's default_record_type is set.",
+			)
+			return
+		}
+		config.Type = types.StringValue(r.client.defaultRecordType)
+		plan.Type = config.Type
+		resp.Diagnostics.Append(validateStructuredContent(config)...)
+		resp.Diagnostics.Append(validateRecord(recordConfig{
+			Name:     config.Name,
+			Type:     config.Type,
+			Content:  config.Content,
+			Priority: config.Priority,
+			Strict:   r.client.strictValidation,
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if r.client != nil && r.client.strictValidation && config.Type.ValueString() == "SRV" && !config.Name.IsNull() && !config.Name.IsUnknown() {
+		// The provider configuration may not be known during validation.
+		resp.Diagnostics.Append(srvNameDiagnostics(config.Name.ValueString(), true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if content, ok := config.structuredContent(); ok {
+		plan.Content = types.StringValue(content)
+		// Keep content that only differs in formatting, e.g. the case of hex
+		// digits, so imported records do not show a difference.
+		if !req.State.Raw.IsNull() && state.Type.Equal(plan.Type) && sameStructuredContent(plan.Type.ValueString(), state.Content.ValueString(), content) {
+			plan.Content = state.Content
+		}
+	} else if config.hasStructuredFields() {
+		// The content depends on structured attributes not yet known.
+		plan.Content = types.StringUnknown()
+	} else {
+		plan.Content = config.Content
+	}
+
+	// Records configured by zone name keep the zone ID unless the name changes.
+	if config.ZoneID.IsNull() {
+		plan.ZoneID = types.StringUnknown()
+		if !req.State.Raw.IsNull() && plan.ZoneName.Equal(state.ZoneName) {
+			plan.ZoneID = state.ZoneID
+		}
+	}
+
+	// Records without a TTL inherit the default TTL, which may depend on the
+	// zone.
+	if config.TTL.IsNull() {
+		plan.TTL = types.Int64Unknown()
+		zoneId := ""
+		if !plan.ZoneID.IsUnknown() {
+			zoneId = plan.ZoneID.ValueString()
+		}
+		if ttl, ok := r.client.defaultRecordTTL(ctx, zoneId); ok {
+			plan.TTL = types.Int64Value(ttl)
+		}
+	}
+
+	// Expand the record name, which requires the zone name for names that are
+	// not absolute.
+	name := plan.Name.ValueString()
+	switch {
+	case !req.State.Raw.IsNull() && plan.Name.Equal(state.Name) && plan.ZoneID.Equal(state.ZoneID) && !state.FQDN.IsNull():
+		plan.FQDN = state.FQDN
+	case plan.Name.IsUnknown():
+		plan.FQDN = types.StringUnknown()
+	case strings.HasSuffix(name, "."):
+		plan.FQDN = types.StringValue(expandRecordName(name, ""))
+	case !config.ZoneName.IsNull() && !config.ZoneName.IsUnknown():
+		plan.FQDN = types.StringValue(expandRecordName(name, config.ZoneName.ValueString()))
+	case r.client != nil && !plan.ZoneID.IsUnknown():
+		if zoneConfig, err := r.client.getZoneConfig(ctx, plan.ZoneID.ValueString()); err == nil {
+			plan.FQDN = types.StringValue(expandRecordName(name, zoneConfig.Name))
+		} else {
+			plan.FQDN = types.StringUnknown()
+		}
+	default:
+		plan.FQDN = types.StringUnknown()
+	}
+	// Relative names are checked in full once the zone name is known.
+	if !plan.FQDN.IsUnknown() && !strings.HasSuffix(name, ".") {
+		if err := validateRecordNameLength(plan.FQDN.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Record name too long", err.Error())
+		}
+	}
+	if plan.Type.ValueString() == "CNAME" && !plan.FQDN.IsUnknown() && !plan.Content.IsNull() && !plan.Content.IsUnknown() && !plan.ZoneName.IsUnknown() {
+		resp.Diagnostics.Append(cnameSelfReferenceDiagnostics(plan.FQDN.ValueString(), plan.Content.ValueString(), plan.ZoneName.ValueString())...)
+	}
+
+	// The zone name is kept from state unless the record moves to another zone.
+	if !req.State.Raw.IsNull() && config.ZoneName.IsNull() {
+		if !plan.ZoneID.Equal(state.ZoneID) {
+			plan.ZoneName = types.StringUnknown()
+		}
+	}
+
+	if config.hasStructuredFields() {
+		plan.copyStructuredContent(&config)
+	} else {
+		plan.setStructuredContent()
+	}
+
+	// Records whose content cannot be modified are re-created instead.
+	if !req.State.Raw.IsNull() && immutableContentRecordTypes[plan.Type.ValueString()] && !plan.Content.Equal(state.Content) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content"))
+	}
+
+	changed := req.State.Raw.IsNull() || !req.Plan.Raw.Equal(req.State.Raw)
+
+	// Warn about records planned for zones that may not be writable.
+	if r.client != nil && !plan.ZoneID.IsUnknown() && changed {
+		zoneConfig, err := r.client.getZoneConfig(ctx, plan.ZoneID.ValueString())
+		if err != nil {
+			tflog.Debug(ctx, "Could not read zone config to check record placement", map[string]any{"error": err.Error()})
+		} else if detail := zoneRestorableDetail(zoneConfig); detail != "" {
+			resp.Diagnostics.AddAttributeError(path.Root("zone_id"), "hosting.de DNS zone is deleted", detail)
+		} else if warning := zoneWriteWarning(zoneConfig); warning != "" {
+			resp.Diagnostics.AddAttributeWarning(path.Root("zone_id"), "Zone may not be writable", warning)
+		}
+	}
+
+	// Check that the linked record template exists.
+	if r.client != nil && !plan.RecordTemplateID.IsNull() && !plan.RecordTemplateID.IsUnknown() && !plan.RecordTemplateID.Equal(state.RecordTemplateID) {
+		if _, err := r.client.getRecordTemplate(ctx, plan.RecordTemplateID.ValueString()); errors.Is(err, ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("record_template_id"),
+				"Record template not found",
+				"The record template "+plan.RecordTemplateID.ValueString()+" does not exist: "+err.Error(),
+			)
+		} else if err != nil {
+			tflog.Debug(ctx, "Could not read record template", map[string]any{"error": err.Error()})
+		}
+	}
+
+	// Warn about new records in zones approaching the configured record limit.
+	if r.client != nil && r.client.zoneRecordLimit > 0 && !plan.ZoneID.IsUnknown() && (req.State.Raw.IsNull() || !plan.ZoneID.Equal(state.ZoneID)) {
+		if warning := r.client.recordLimitWarning(ctx, plan.ZoneID.ValueString()); warning != "" {
+			resp.Diagnostics.AddAttributeWarning(path.Root("zone_id"), "Zone approaching record limit", warning)
+		}
+	}
+
+	// Check the comment length, including the comment prefix.
+	if r.client != nil && !plan.Comments.IsUnknown() {
+		if length := utf8.RuneCountInString(withCommentPrefix(r.client.commentPrefix, plan.Comments.ValueString())); length > maxCommentsLength {
+			detail := fmt.Sprintf("The comment has %d characters, including the comment prefix, but the API accepts at most %d.", length, maxCommentsLength)
+			if r.client.truncateComments {
+				resp.Diagnostics.AddAttributeWarning(path.Root("comments"), "Comment will be truncated", detail+" It is truncated when sent to the API.")
+			} else {
+				resp.Diagnostics.AddAttributeError(path.Root("comments"), "Comment too long", detail+" Shorten it or enable the provider's truncate_comments option.")
+			}
+		}
+	}
+
+	// Warn about TXT records too large for plain UDP responses.
+	if r.client != nil && changed && !plan.Type.IsUnknown() && !plan.Content.IsUnknown() && r.client.apiRecordType(plan.Type.ValueString()) == "TXT" {
+		resp.Diagnostics.Append(txtSizeDiagnostics(r.client.apiContentSplit(plan.Type.ValueString(), plan.Content.ValueString(), plan.AutoSplit), r.client.txtSizeThreshold, r.client.strictValidation)...)
+	}
+
+	// Optionally check that record targets resolve. Targets may legitimately
+	// not resolve yet, so failures are reported as warnings. Only created or
+	// changed records are checked to keep plans of large zones fast.
+	if r.client != nil && r.client.validateTargetsResolve && changed && !plan.Type.IsUnknown() && !plan.Content.IsUnknown() {
+		if err := r.client.checkTargetResolves(ctx, r.client.apiRecordType(plan.Type.ValueString()), plan.Content.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeWarning(path.Root("content"), "Record target does not resolve", err.Error())
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
 func (r *recordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -356,28 +1339,37 @@ func (r *recordResource) ValidateConfig(ctx context.Context, req resource.Valida
 		return
 	}
 
-	// If Type is MX or SRV, return without warning.
-	if configData.Type.ValueString() == "MX" || configData.Type.ValueString() == "SRV" {
-		if configData.Priority.IsNull() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("Priority"),
-				"Missing attribute",
-				"Setting priority is required for records of type MX or SRV. "+
-					"Please add a priority to the resource, for example priority = 0.",
-			)
+	// The zone is either set by ID or, e.g. to create it, by name.
+	switch {
+	case configData.ZoneID.IsNull() && configData.ZoneName.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("zone_id"), "Missing attribute", "Setting either zone_id or zone_name is required.")
+	case !configData.ZoneID.IsNull() && !configData.ZoneName.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("zone_name"), "Conflicting attributes", "Only one of zone_id and zone_name may be set.")
+	}
+
+	// Split character strings are joined without separator when read.
+	if configData.AutoSplit.ValueBool() && configData.TXTJoin.ValueString() == txtJoinSpace {
+		resp.Diagnostics.AddAttributeError(path.Root("auto_split"), "Conflicting attributes", "auto_split cannot be combined with txt_join = \"space\".")
+	}
+
+	if !configData.Name.IsNull() && !configData.Name.IsUnknown() {
+		if err := validateRecordNameLength(configData.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Record name too long", err.Error())
 		}
-		return
 	}
 
-	// If Priority is not configured, return without warning.
-	if configData.Priority.IsNull() || configData.Priority.IsUnknown() {
+	// Records without a type are validated once the provider's default type
+	// is known.
+	if configData.Type.IsNull() {
 		return
 	}
 
-	resp.Diagnostics.AddAttributeError(
-		path.Root("Type"),
-		"Unexpected combination of attributes",
-		"Priority is only relevant for records of type MX or SRV. "+
-			"Please remove priority from the resource or change its type.",
-	)
+	resp.Diagnostics.Append(validateStructuredContent(configData)...)
+	resp.Diagnostics.Append(validateRecord(recordConfig{
+		Name:     configData.Name,
+		Type:     configData.Type,
+		Content:  configData.Content,
+		Priority: configData.Priority,
+		Strict:   r.client != nil && r.client.strictValidation,
+	})...)
 }
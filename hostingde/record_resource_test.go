@@ -1,8 +1,20 @@
 package hostingde
 
 import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -174,3 +186,2509 @@ resource "hostingde_record" "test_dkim" {
 		},
 	})
 }
+
+// TestAccRecordResourceGeneratedConfig checks that the configuration
+// terraform plan -generate-config-out writes for an imported record plans
+// without changes.
+func TestAccRecordResourceGeneratedConfig(t *testing.T) {
+	zoneConfig := `
+resource "hostingde_zone" "test" {
+  name = "example3.test"
+  type = "NATIVE"
+  email = "hostmaster@example3.test"
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + zoneConfig + `
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "mail.example3.test"
+  type = "MX"
+  content = "mx.example3.test"
+  priority = 10
+}
+`,
+			},
+			{
+				ResourceName:      "hostingde_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Generated configuration contains all optional attributes.
+			{
+				Config: providerConfig + zoneConfig + `
+resource "hostingde_record" "test" {
+  comments = ""
+  content  = "mx.example3.test"
+  name     = "mail.example3.test"
+  priority = 10
+  ttl      = 3600
+  type     = "MX"
+  upsert   = false
+  zone_id  = hostingde_zone.test.id
+}
+`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestCommentPrefix(t *testing.T) {
+	prefix := "[terraform] "
+
+	comments := withCommentPrefix(prefix, "Example Comment")
+	if comments != "[terraform] Example Comment" {
+		t.Fatalf("unexpected prefixed comment %q", comments)
+	}
+	if withCommentPrefix(prefix, comments) != comments {
+		t.Errorf("expected prefix to be added only once")
+	}
+
+	stripped, managed := splitCommentPrefix(prefix, comments)
+	if stripped != "Example Comment" || !managed {
+		t.Errorf("expected managed comment %q, got %q (managed = %t)", "Example Comment", stripped, managed)
+	}
+
+	stripped, managed = splitCommentPrefix(prefix, "Manual Comment")
+	if stripped != "Manual Comment" || managed {
+		t.Errorf("expected unmanaged comment, got %q (managed = %t)", stripped, managed)
+	}
+
+	if _, managed = splitCommentPrefix("", "[terraform] Example Comment"); managed {
+		t.Errorf("expected no record to be managed without a prefix")
+	}
+}
+
+// validateRecordResourceConfig runs the record resource's ValidateConfig for the given configuration.
+func validateRecordResourceConfig(t *testing.T, values map[string]any) diag.Diagnostics {
+	t.Helper()
+
+	ctx := context.Background()
+	r := &recordResource{}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	resp := &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+		Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values),
+	}, resp)
+
+	return resp.Diagnostics
+}
+
+func TestRecordResourceSPF(t *testing.T) {
+	diags := validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "example.test",
+		"type":    "SPF",
+		"content": "v=spf1 mx -all",
+	})
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected a single deprecation warning, got %v", diags)
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "example.test",
+		"type":    "TXT",
+		"content": "v=spf1 ip4:192.0.2.256 -all",
+	})
+	if !diags.HasError() {
+		t.Errorf("expected invalid SPF policy in TXT record to be rejected")
+	}
+
+	client := &Client{}
+	if got := client.apiRecordType("SPF"); got != "SPF" {
+		t.Errorf("expected SPF type to be kept without conversion, got %s", got)
+	}
+	client.convertSPFToTXT = true
+	if got := client.apiRecordType("SPF"); got != "TXT" {
+		t.Errorf("expected SPF type to be converted to TXT, got %s", got)
+	}
+	if got := client.apiRecordType("MX"); got != "MX" {
+		t.Errorf("expected MX type to be kept, got %s", got)
+	}
+	if got := stateRecordType("SPF", "TXT"); got != "SPF" {
+		t.Errorf("expected converted record to keep SPF type in state, got %s", got)
+	}
+	if got := stateRecordType("TXT", "TXT"); got != "TXT" {
+		t.Errorf("expected TXT record to keep TXT type in state, got %s", got)
+	}
+}
+
+func TestRecordResourceALIAS(t *testing.T) {
+	diags := validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "example.test",
+		"type":    "ALIAS",
+		"content": "lb.example.net",
+	})
+	if diags.HasError() {
+		t.Errorf("expected ALIAS to a host name to be valid, got %v", diags)
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "example.test",
+		"type":    "ALIAS",
+		"content": "192.0.2.1",
+	})
+	if !diags.HasError() {
+		t.Errorf("expected ALIAS to an IP address to be rejected")
+	}
+}
+
+func TestRecordResourceCreateZoneName(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			record := updateRequest.RecordsToAdd[0]
+			record.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test", AccountID: "account-id"}
+			updateResponse.Response.Records = []DNSRecord{record}
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"fqdn":     "www.example.test",
+		"type":     "A",
+		"content":  "192.0.2.1",
+		"ttl":      3600,
+		"priority": 0,
+		"comments": "",
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state recordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if state.ZoneName.ValueString() != "example.test" {
+		t.Errorf("expected zone_name to be populated, got %s", state.ZoneName)
+	}
+}
+
+func TestRecordResourceUpsert(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "existing-id", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			if len(updateRequest.RecordsToAdd) != 0 {
+				t.Errorf("expected no record to be added, got %v", updateRequest.RecordsToAdd)
+			}
+			if len(updateRequest.RecordsToModify) != 1 || updateRequest.RecordsToModify[0].ID != "existing-id" ||
+				updateRequest.RecordsToModify[0].Content != "192.0.2.2" {
+				t.Fatalf("expected existing record to be modified, got %v", updateRequest.RecordsToModify)
+			}
+
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			updateResponse.Response.Records = updateRequest.RecordsToModify
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"fqdn":     "www.example.test",
+		"type":     "A",
+		"content":  "192.0.2.2",
+		"ttl":      3600,
+		"priority": 0,
+		"comments": "",
+		"upsert":   true,
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a warning about the overwritten record, got %v", resp.Diagnostics)
+	}
+
+	var state recordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if state.ID.ValueString() != "existing-id" || state.Content.ValueString() != "192.0.2.2" {
+		t.Errorf("expected existing record to be taken over, got %s with content %s", state.ID, state.Content)
+	}
+}
+
+func TestRecordResourceTTLClamp(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			// The zone clamps the TTL to a minimum of 300 seconds.
+			record := updateRequest.RecordsToAdd[0]
+			record.ID = "record-id"
+			record.TTL = 300
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{record}
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"fqdn":     "www.example.test",
+		"type":     "A",
+		"content":  "192.0.2.1",
+		"ttl":      60,
+		"priority": 0,
+		"comments": "",
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "60") || !strings.Contains(warnings[0].Detail(), "300") {
+		t.Errorf("expected a warning showing both TTLs, got %v", resp.Diagnostics)
+	}
+
+	var state recordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if state.TTL.ValueInt64() != 300 {
+		t.Errorf("expected applied TTL to be stored, got %d", state.TTL.ValueInt64())
+	}
+}
+
+func TestRecordResourceRecordLimit(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(_ []byte) any {
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{
+				Status: "error",
+				Errors: []APIError{{
+					Code:    10207,
+					Text:    "Zone record limit exceeded",
+					Value:   "recordLimitExceeded",
+					Details: []APIErrorDetail{{Key: "limit", Value: "500"}},
+				}},
+			}}
+		},
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.TotalEntries = 500
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"fqdn":     "www.example.test",
+		"type":     "A",
+		"content":  "192.0.2.1",
+		"ttl":      3600,
+		"priority": 0,
+		"comments": "",
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "hosting.de DNS zone record limit reached" {
+		t.Fatalf("expected record limit error, got %v", resp.Diagnostics)
+	}
+	if detail := errs[0].Detail(); !strings.Contains(detail, "limit of 500 records") || !strings.Contains(detail, "currently has 500 records") {
+		t.Errorf("expected error to report limit and count, got %q", detail)
+	}
+
+	// Zones approaching the configured limit are reported when planning.
+	client.zoneRecordLimit = 550
+	if warning := client.recordLimitWarning(context.Background(), "zone-id"); !strings.Contains(warning, "500 of at most 550") {
+		t.Errorf("expected warning about approaching limit, got %q", warning)
+	}
+	client.zoneRecordLimit = 1000
+	if warning := client.recordLimitWarning(context.Background(), "zone-id"); warning != "" {
+		t.Errorf("expected no warning below 90%% of the limit, got %q", warning)
+	}
+}
+
+func TestRecordResourceAPIErrors(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(_ []byte) any {
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{
+				Status: "error",
+				Errors: []APIError{{
+					Code:    10205,
+					Text:    "Invalid record content",
+					Details: []APIErrorDetail{{Key: "content", Value: "mail.example.test"}},
+				}},
+			}}
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"fqdn":     "www.example.test",
+		"type":     "A",
+		"content":  "mail.example.test",
+		"ttl":      3600,
+		"priority": 0,
+		"comments": "",
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != 1 || !strings.Contains(errs[0].Detail(), "Invalid record content (code 10205, content: mail.example.test)") {
+		t.Fatalf("expected the API error to be reported, got %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected no state for a rejected record, got %v", resp.State.Raw)
+	}
+}
+
+func TestRecordResourceCommentsLength(t *testing.T) {
+	var stored DNSRecord
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			stored = updateRequest.RecordsToAdd[0]
+			stored.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{stored}
+			return updateResponse
+		},
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{stored}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test"}}
+			return findResponse
+		},
+	})
+	client.commentPrefix = "[terraform] "
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	comments := strings.Repeat("a", maxCommentsLength)
+	config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"fqdn":     "www.example.test",
+		"type":     "A",
+		"content":  "192.0.2.1",
+		"ttl":      3600,
+		"priority": 0,
+		"comments": comments,
+	})
+	plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+	modifyPlan := func() *fwresource.ModifyPlanResponse {
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   plan,
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}, resp)
+		return resp
+	}
+
+	// The comment fits, but not together with the comment prefix.
+	if resp := modifyPlan(); len(resp.Diagnostics.Errors()) != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "267 characters") {
+		t.Fatalf("expected comment length error, got %v", resp.Diagnostics)
+	}
+
+	client.truncateComments = true
+	if resp := modifyPlan(); resp.Diagnostics.HasError() || len(resp.Diagnostics.Warnings()) != 1 {
+		t.Fatalf("expected truncation warning, got %v", resp.Diagnostics)
+	}
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+	if len(stored.Comments) != maxCommentsLength || !strings.HasPrefix(stored.Comments, "[terraform] ") {
+		t.Errorf("expected prefixed comment truncated to %d characters, got %d", maxCommentsLength, len(stored.Comments))
+	}
+
+	// The configured comment is kept in state.
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	var state recordResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if state.Comments.ValueString() != comments || !state.TerraformManaged.ValueBool() {
+		t.Errorf("expected configured comment to be kept, got %q", state.Comments.ValueString())
+	}
+}
+
+func TestRecordResourceDefaultRecordType(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "2.0.192.in-addr.arpa"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	modifyPlan := func(values map[string]any) (recordResourceModel, diag.Diagnostics) {
+		values["zone_id"] = "zone-id"
+		values["name"] = "1.2.0.192.in-addr.arpa"
+		config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+		plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   plan,
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}, resp)
+
+		var planned recordResourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.Plan.Get(ctx, &planned)...)
+		}
+		return planned, resp.Diagnostics
+	}
+
+	if _, diags := modifyPlan(map[string]any{"content": "host.example.test."}); !diags.HasError() {
+		t.Errorf("expected error for missing type without default_record_type")
+	}
+
+	client.defaultRecordType = "PTR"
+	planned, diags := modifyPlan(map[string]any{"content": "host.example.test."})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if planned.Type.ValueString() != "PTR" {
+		t.Errorf("expected default record type PTR, got %s", planned.Type)
+	}
+
+	planned, diags = modifyPlan(map[string]any{"type": "TXT", "content": "\"v=spf1 -all\""})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if planned.Type.ValueString() != "TXT" {
+		t.Errorf("expected configured type to override the default, got %s", planned.Type)
+	}
+
+	// Type-specific validation applies to the default type.
+	client.defaultRecordType = "SPF"
+	if _, diags := modifyPlan(map[string]any{"content": "\"v=spf1 +invalid\""}); !diags.HasError() {
+		t.Errorf("expected SPF validation of records using the default type")
+	}
+}
+
+func TestRecordResourceRecordTemplate(t *testing.T) {
+	var added DNSRecord
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			added = updateRequest.RecordsToAdd[0]
+			record := added
+			record.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{record}
+			return updateResponse
+		},
+		"recordTemplatesFind": func(body []byte) any {
+			var findRequest RecordTemplatesFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			findResponse := RecordTemplatesFindResponse{}
+			findResponse.Status = "success"
+			if findRequest.Filter.Value == "template-id" {
+				findResponse.Response.Data = []RecordTemplate{{ID: "template-id", Name: "##DOMAIN##", Type: "A"}}
+			}
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	newPlan := func(recordTemplateId string) tfsdk.Plan {
+		config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"zone_id":            "zone-id",
+			"name":               "example.test",
+			"fqdn":               "example.test",
+			"type":               "A",
+			"content":            "192.0.2.1",
+			"ttl":                3600,
+			"priority":           0,
+			"comments":           "",
+			"record_template_id": recordTemplateId,
+		})
+		return tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+	}
+	modifyPlan := func(plan tfsdk.Plan) diag.Diagnostics {
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			Plan:   plan,
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}, resp)
+		return resp.Diagnostics
+	}
+
+	if diags := modifyPlan(newPlan("missing-id")); !diags.HasError() {
+		t.Errorf("expected error for missing record template")
+	}
+
+	plan := newPlan("template-id")
+	if diags := modifyPlan(plan); diags.HasError() {
+		t.Fatal(diags)
+	}
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if added.RecordTemplateID != "template-id" {
+		t.Errorf("expected record template ID in the create payload, got %q", added.RecordTemplateID)
+	}
+}
+
+func TestRecordResourceZoneStatus(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "record-id", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "blocked"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	prior := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":        "record-id",
+		"zone_id":   "zone-id",
+		"zone_name": "example.test",
+		"name":      "www.example.test",
+		"type":      "A",
+		"content":   "192.0.2.1",
+	})
+	state := tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected non-active zone not to fail the read, got %v", resp.Diagnostics)
+	}
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "status blocked") {
+		t.Errorf("expected zone status warning, got %v", resp.Diagnostics)
+	}
+
+	var refreshed recordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &refreshed)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if refreshed.ZoneStatus.ValueString() != "blocked" {
+		t.Errorf("expected zone_status blocked, got %s", refreshed.ZoneStatus)
+	}
+}
+
+func TestRecordResourceRestorableZone(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(_ []byte) any {
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{
+				Status: "error",
+				Errors: []APIError{{Code: 10101, Text: "Zone is not active"}},
+			}}
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: zoneStatusRestorable}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "www.example.test",
+		"type":    "A",
+		"content": "192.0.2.1",
+		"ttl":     3600,
+	})
+	plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+
+	// Planning records in a deleted zone fails with guidance to restore it.
+	modifyResp := &fwresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+		Config: config,
+		Plan:   plan,
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}, modifyResp)
+	errs := modifyResp.Diagnostics.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "hosting.de DNS zone is deleted" || !strings.Contains(errs[0].Detail(), "Restore the zone first") {
+		t.Errorf("expected a deleted zone error when planning, got %v", modifyResp.Diagnostics)
+	}
+
+	// Failed changes are reported as caused by the deleted zone.
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+	errs = resp.Diagnostics.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "hosting.de DNS zone is deleted" || !strings.Contains(errs[0].Detail(), "Zone is not active") {
+		t.Errorf("expected a deleted zone error with the API error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestRecordResourceOwnerAccountID(t *testing.T) {
+	var accountIds []string
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			accountIds = append(accountIds, updateRequest.AccountId)
+
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test", AccountID: "sub-account"}
+			for _, record := range updateRequest.RecordsToAdd {
+				record.ID = "record-id"
+				updateResponse.Response.Records = append(updateResponse.Response.Records, record)
+			}
+			return updateResponse
+		},
+	})
+	client.accountId = "reseller-account"
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":          "zone-id",
+		"name":             "www.example.test",
+		"fqdn":             "www.example.test",
+		"type":             "A",
+		"content":          "192.0.2.1",
+		"ttl":              3600,
+		"comments":         "",
+		"owner_account_id": "sub-account",
+	})
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+
+	deleteResp := &fwresource.DeleteResponse{}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", deleteResp.Diagnostics)
+	}
+
+	if !slices.Equal(accountIds, []string{"sub-account", "sub-account"}) {
+		t.Errorf("expected the record to be created and deleted on behalf of the owner account, got %v", accountIds)
+	}
+}
+
+func TestRecordResourceAccountPrecedence(t *testing.T) {
+	for name, test := range map[string]struct {
+		providerAccount, resourceAccount string
+		expectedAccount                  string
+		expectWarning                    bool
+	}{
+		"provider only": {providerAccount: "provider-account", expectedAccount: "provider-account"},
+		"resource only": {resourceAccount: "resource-account", expectedAccount: "resource-account"},
+		"both set":      {providerAccount: "provider-account", resourceAccount: "resource-account", expectedAccount: "resource-account"},
+		"both equal":    {providerAccount: "provider-account", resourceAccount: "provider-account", expectedAccount: "provider-account", expectWarning: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stored := DNSRecord{ID: "record-id", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600}
+			accountIds := map[string][]string{}
+			account := func(method string, body []byte) {
+				var request BaseRequest
+				if err := json.Unmarshal(body, &request); err != nil {
+					t.Fatal(err)
+				}
+				accountIds[method] = append(accountIds[method], request.AccountId)
+			}
+			client := newTestClient(t, map[string]func(body []byte) any{
+				"recordsUpdate": func(body []byte) any {
+					account("recordsUpdate", body)
+					updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+					updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test", AccountID: test.expectedAccount}
+					updateResponse.Response.Records = []DNSRecord{stored}
+					return updateResponse
+				},
+				"recordsFind": func(body []byte) any {
+					account("recordsFind", body)
+					findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+					findResponse.Response.Data = []DNSRecord{stored}
+					findResponse.Response.TotalEntries = 1
+					return findResponse
+				},
+				"zoneConfigsFind": func(body []byte) any {
+					account("zoneConfigsFind", body)
+					findResponse := ZoneConfigsFindResponse{}
+					findResponse.Status = "success"
+					findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active", AccountID: test.expectedAccount}}
+					return findResponse
+				},
+			})
+			client.accountId = test.providerAccount
+
+			ctx := context.Background()
+			r := &recordResource{client: client}
+			schemaResp := &fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+			values := map[string]any{
+				"zone_id":  "zone-id",
+				"name":     "www.example.test",
+				"fqdn":     "www.example.test",
+				"type":     "A",
+				"content":  "192.0.2.1",
+				"ttl":      3600,
+				"comments": "",
+				"upsert":   true,
+			}
+			if test.resourceAccount != "" {
+				values["owner_account_id"] = test.resourceAccount
+			}
+			config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+			plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+
+			planResp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+				Config: config,
+				Plan:   plan,
+				State:  tfsdk.State{Schema: config.Schema, Raw: tftypes.NewValue(config.Raw.Type(), nil)},
+			}, planResp)
+			if planResp.Diagnostics.HasError() {
+				t.Fatal(planResp.Diagnostics)
+			}
+			if warned := planResp.Diagnostics.WarningsCount() > 0; warned != test.expectWarning {
+				t.Errorf("expected warning %t, got %v", test.expectWarning, planResp.Diagnostics)
+			}
+
+			// The upsert lookup, the creation, the refresh and the deletion
+			// are all sent on behalf of the same account.
+			createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatal(createResp.Diagnostics)
+			}
+			readResp := &fwresource.ReadResponse{State: createResp.State}
+			r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatal(readResp.Diagnostics)
+			}
+			deleteResp := &fwresource.DeleteResponse{}
+			r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatal(deleteResp.Diagnostics)
+			}
+
+			for method, sent := range accountIds {
+				for _, accountId := range sent {
+					if accountId != test.expectedAccount {
+						t.Errorf("expected %s to be sent on behalf of %q, got %v", method, test.expectedAccount, sent)
+						break
+					}
+				}
+			}
+			if len(accountIds["recordsFind"]) < 2 || len(accountIds["recordsUpdate"]) != 2 {
+				t.Errorf("expected records to be found and updated, got %v", accountIds)
+			}
+
+			var model recordResourceModel
+			readResp.Diagnostics.Append(readResp.State.Get(ctx, &model)...)
+			if model.AccountID.ValueString() != test.expectedAccount {
+				t.Errorf("expected account_id %q in state, got %q", test.expectedAccount, model.AccountID.ValueString())
+			}
+		})
+	}
+}
+
+func TestRecordResourceReadNotFound(t *testing.T) {
+	var records []DNSRecord
+	var zones []ZoneConfig
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = records
+			findResponse.Response.TotalEntries = len(records)
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = zones
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	prior := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":        "record-id",
+		"zone_id":   "zone-id",
+		"zone_name": "example.test",
+		"name":      "www.example.test",
+		"type":      "A",
+		"content":   "192.0.2.1",
+	})
+
+	active := []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+	for _, test := range []struct {
+		name    string
+		records []DNSRecord
+		zones   []ZoneConfig
+		warning string
+	}{
+		{"deleted", nil, active, ""},
+		{"other ID", []DNSRecord{{ID: "other-id", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1"}}, active, ""},
+		{"zone deleted", nil, nil, "Zone example.test (ID zone-id) no longer exists"},
+		{"zone renamed", nil, []ZoneConfig{{ID: "zone-id", Name: "renamed.test", Status: "active"}}, "was renamed from example.test to renamed.test"},
+	} {
+		records = test.records
+		zones = test.zones
+		client.zoneConfigs = nil
+		state := tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: expected a missing record not to fail the read, got %v", test.name, resp.Diagnostics)
+		}
+		if !resp.State.Raw.IsNull() {
+			t.Errorf("%s: expected the record to be removed from state", test.name)
+		}
+		switch {
+		case test.warning == "" && resp.Diagnostics.WarningsCount() != 0:
+			t.Errorf("%s: expected no warning for a missing record, got %v", test.name, resp.Diagnostics)
+		case test.warning != "" && (resp.Diagnostics.WarningsCount() != 1 ||
+			!strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), test.warning)):
+			t.Errorf("%s: expected a warning containing %q, got %v", test.name, test.warning, resp.Diagnostics)
+		}
+	}
+}
+
+func TestRecordResourceUpdateRenamedZone(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(body []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			// The zone is only found by its ID under its new name.
+			if strings.Contains(string(body), "ZoneConfigId") {
+				findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "renamed.test", Status: "active"}}
+			}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	values := map[string]any{
+		"id":        "record-id",
+		"zone_id":   "zone-id",
+		"zone_name": "example.test",
+		"name":      "www.example.test",
+		"type":      "A",
+		"content":   "192.0.2.1",
+		"ttl":       3600,
+	}
+	prior := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+	values["zone_id"] = types.StringUnknown()
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+
+	resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw},
+	}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the zone name lookup to fail")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "Zone ID zone-id was renamed from example.test to renamed.test") {
+		t.Errorf("expected the error to report the renamed zone, got %q", detail)
+	}
+}
+
+func TestRecordResourceAutoCreateZone(t *testing.T) {
+	var created []ZoneCreateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(_ []byte) any {
+			// The zone does not exist until it is created.
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+		"zoneCreate": func(body []byte) any {
+			var createRequest ZoneCreateRequest
+			if err := json.Unmarshal(body, &createRequest); err != nil {
+				t.Fatal(err)
+			}
+			created = append(created, createRequest)
+
+			createResponse := ZoneCreateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			createResponse.Response.ZoneConfig = createRequest.ZoneConfig
+			createResponse.Response.ZoneConfig.ID = "new-zone-id"
+			return createResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			if updateRequest.ZoneConfigId != "new-zone-id" {
+				t.Errorf("expected record to be added to the created zone, got %s", updateRequest.ZoneConfigId)
+			}
+
+			record := updateRequest.RecordsToAdd[0]
+			record.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "new-zone-id", Name: "new.test", Status: "active"}
+			updateResponse.Response.Records = []DNSRecord{record}
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_name": "new.test",
+		"name":      "www",
+		"type":      "A",
+		"content":   "192.0.2.1",
+		"ttl":       3600,
+		"priority":  0,
+		"comments":  "",
+	})
+	create := func() *fwresource.CreateResponse {
+		plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+		planResp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   plan,
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}, planResp)
+		if planResp.Diagnostics.HasError() {
+			t.Fatal(planResp.Diagnostics)
+		}
+
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: planResp.Plan}, resp)
+		return resp
+	}
+
+	resp := create()
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "auto_create_zone") {
+		t.Fatalf("expected missing zone error pointing to auto_create_zone, got %v", resp.Diagnostics)
+	}
+	if len(created) != 0 {
+		t.Fatalf("expected no zone to be created without auto_create_zone")
+	}
+
+	client.autoCreateZone = true
+	resp = create()
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if len(created) != 1 || created[0].ZoneConfig.Name != "new.test" || created[0].ZoneConfig.Type != "NATIVE" || !created[0].UseDefaultNameserverSet {
+		t.Fatalf("expected native zone new.test with default name servers to be created, got %+v", created)
+	}
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "new.test (ID new-zone-id)") {
+		t.Errorf("expected warning naming the created zone, got %v", resp.Diagnostics)
+	}
+
+	var state recordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if state.ZoneID.ValueString() != "new-zone-id" || state.FQDN.ValueString() != "www.new.test" {
+		t.Errorf("expected record in the created zone, got zone ID %s and name %s", state.ZoneID, state.FQDN)
+	}
+}
+
+func TestNormalizeContent(t *testing.T) {
+	client := NewClient(nil, nil, nil)
+	if content := client.normalizeContent("TXT", `"hello" "world"`); content != "helloworld" {
+		t.Errorf("expected TXT content to be normalized by default, got %s", content)
+	}
+	if content := client.normalizeContent("CAA", `0 issue "ca.example.test"`); content != `0 issue "ca.example.test"` {
+		t.Errorf("expected CAA content to be kept verbatim by default, got %s", content)
+	}
+
+	client.normalizeRecordTypes = map[string]bool{"CAA": true}
+	if content := client.normalizeContent("TXT", `"hello"`); content != `"hello"` {
+		t.Errorf("expected TXT content to be kept verbatim if not listed, got %s", content)
+	}
+	if content := client.normalizeContent("CAA", `0 issue "ca.example.test"`); content != "0 issue ca.example.test" {
+		t.Errorf("expected listed CAA content to be normalized, got %s", content)
+	}
+}
+
+func TestRecordResourceVerbatimContent(t *testing.T) {
+	content := `0 issue "ca.example.test"`
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			record := updateRequest.RecordsToAdd[0]
+			record.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{record}
+			return updateResponse
+		},
+		"recordsFind": func(body []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "record-id", ZoneID: "zone-id", Name: "example.test", Type: "CAA", Content: content, TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "example.test",
+		"fqdn":     "example.test",
+		"type":     "CAA",
+		"content":  content,
+		"ttl":      3600,
+		"priority": 0,
+		"comments": "",
+	})
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+
+	// CAA is not in the default normalize_record_types, so the quotes are kept.
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+
+	var state recordResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if state.Content.ValueString() != content {
+		t.Errorf("expected content to round-trip unmodified, got %s", state.Content.ValueString())
+	}
+}
+
+func TestRecordResourceContentHash(t *testing.T) {
+	content := "192.0.2.1"
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			record := updateRequest.RecordsToAdd[0]
+			record.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{record}
+			return updateResponse
+		},
+		"recordsFind": func(body []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "record-id", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: content, TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "www",
+		"fqdn":     "www.example.test",
+		"type":     "A",
+		"content":  content,
+		"ttl":      3600,
+		"priority": 0,
+		"comments": "",
+	})
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+
+	read := func(state tfsdk.State) (tfsdk.State, string) {
+		readResp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+		var model recordResourceModel
+		readResp.Diagnostics.Append(readResp.State.Get(ctx, &model)...)
+		if readResp.Diagnostics.HasError() {
+			t.Fatal(readResp.Diagnostics)
+		}
+		return readResp.State, model.ContentHash.ValueString()
+	}
+
+	var created recordResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &created)...)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	if len(created.ContentHash.ValueString()) != 64 {
+		t.Fatalf("expected SHA-256 hex digest, got %q", created.ContentHash.ValueString())
+	}
+
+	// The hash is stable across reads and independent of the configured name form.
+	state, hash := read(createResp.State)
+	if hash != created.ContentHash.ValueString() {
+		t.Errorf("expected hash to be stable after read, got %s and %s", created.ContentHash.ValueString(), hash)
+	}
+	state, hash = read(state)
+	if hash != created.ContentHash.ValueString() {
+		t.Errorf("expected hash to be stable across reads, got %s and %s", created.ContentHash.ValueString(), hash)
+	}
+
+	content = "192.0.2.2"
+	if _, changed := read(state); changed == hash {
+		t.Errorf("expected hash to change with the content, got %s", changed)
+	}
+}
+
+func TestRecordResourceRelativeName(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test"}}
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			record := updateRequest.RecordsToAdd[0]
+			record.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{record}
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	for name, fqdn := range map[string]string{
+		"@":    "example.test",
+		"mail": "mail.example.test",
+	} {
+		plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"zone_id":  "zone-id",
+			"name":     name,
+			"type":     "MX",
+			"content":  "mx.example.test",
+			"ttl":      3600,
+			"priority": 10,
+			"comments": "",
+		})
+
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", name, resp.Diagnostics)
+		}
+
+		var state recordResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if state.Name.ValueString() != name || state.FQDN.ValueString() != fqdn {
+			t.Errorf("%s: expected name to be kept and expanded to %s, got %s and %s", name, fqdn, state.Name, state.FQDN)
+		}
+	}
+
+	diags := validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "www.@",
+		"type":    "A",
+		"content": "192.0.2.1",
+	})
+	if !diags.HasError() {
+		t.Errorf("expected @ within a name to be rejected")
+	}
+}
+
+func TestRecordResourceUpdateAdoptsChangedID(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			if len(updateRequest.RecordsToModify) != 1 || updateRequest.RecordsToModify[0].ID != "old-id" {
+				t.Errorf("expected record old-id to be modified, got %v", updateRequest.RecordsToModify)
+			}
+
+			// The API replaced the record, returning it with a new ID.
+			record := updateRequest.RecordsToModify[0]
+			record.ID = "new-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", AccountID: "account-id"}
+			updateResponse.Response.Records = []DNSRecord{record}
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	values := map[string]any{
+		"id":       "old-id",
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"fqdn":     "www.example.test",
+		"type":     "A",
+		"content":  "192.0.2.2",
+		"ttl":      3600,
+		"priority": 0,
+		"comments": "",
+	}
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+
+	resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, fwresource.UpdateRequest{
+		Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state recordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if state.ID.ValueString() != "new-id" {
+		t.Errorf("expected state to adopt the new record ID, got %s", state.ID.ValueString())
+	}
+}
+
+func TestMatchReturnedRecord(t *testing.T) {
+	record := DNSRecord{ID: "id", Name: "www.example.test", Type: "TXT", Content: "hello world"}
+	records := []DNSRecord{
+		{ID: "other", Name: "www.example.test", Type: "TXT", Content: "hello world"},
+		{ID: "id", Name: "www.example.test", Type: "TXT", Content: `"hello world"`},
+	}
+
+	matched, ok := matchReturnedRecord(records, record)
+	if !ok || matched.ID != "id" || matched.Content != `"hello world"` {
+		t.Errorf("expected record with requested ID and unmodified content, got %v", matched)
+	}
+
+	matched, ok = matchReturnedRecord(records[:1], record)
+	if !ok || matched.ID != "other" {
+		t.Errorf("expected record matched by name, type and content, got %v", matched)
+	}
+
+	if _, ok = matchReturnedRecord(nil, record); ok {
+		t.Errorf("expected no match in empty response")
+	}
+}
+
+func TestRecordResourceDeleteGracePeriod(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			t.Errorf("expected record not to be deleted after cancellation")
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+	client.deleteGracePeriod = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":      "record-id",
+		"zone_id": "zone-id",
+		"name":    "www.example.test",
+		"fqdn":    "www.example.test",
+		"type":    "A",
+		"content": "192.0.2.1",
+	})
+
+	// Cancel the operation while the delete is waiting.
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	resp := &fwresource.DeleteResponse{}
+	r.Delete(ctx, fwresource.DeleteRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected cancelled delete to fail")
+	}
+}
+
+func TestRecordResourceNameLength(t *testing.T) {
+	for name, valid := range map[string]bool{
+		strings.Repeat("a", 63) + ".example.test":                       true,
+		strings.Repeat("a", 64) + ".example.test":                       false,
+		strings.Repeat("ä", 30) + ".example.test":                       true,
+		strings.Repeat("ä", 60) + ".example.test":                       false,
+		strings.Repeat(strings.Repeat("a", 63)+".", 3) + "example.test": true,
+		strings.Repeat(strings.Repeat("a", 63)+".", 4) + "example.test": false,
+	} {
+		diags := validateRecordResourceConfig(t, map[string]any{
+			"zone_id": "zone-id",
+			"name":    name,
+			"type":    "A",
+			"content": "192.0.2.1",
+		})
+		if diags.HasError() == valid {
+			t.Errorf("%s: expected valid = %t, got %v", name, valid, diags)
+		}
+	}
+}
+
+func TestRecordResourceCanonicalContent(t *testing.T) {
+	for recordType, configured := range map[string]string{
+		"AAAA": "2001:DB8:0::0:1",
+		"MX":   "Mail.Example.Test.",
+		"SRV":  "10  5060 sip.example.test.",
+	} {
+		var stored DNSRecord
+		client := newTestClient(t, map[string]func(body []byte) any{
+			"recordsUpdate": func(body []byte) any {
+				var updateRequest RecordsUpdateRequest
+				if err := json.Unmarshal(body, &updateRequest); err != nil {
+					t.Fatal(err)
+				}
+
+				stored = updateRequest.RecordsToAdd[0]
+				stored.ID = "record-id"
+				updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+				updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+				updateResponse.Response.Records = []DNSRecord{stored}
+				return updateResponse
+			},
+			"recordsFind": func(body []byte) any {
+				findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+				findResponse.Response.Data = []DNSRecord{stored}
+				findResponse.Response.TotalEntries = 1
+				return findResponse
+			},
+			"zoneConfigsFind": func(_ []byte) any {
+				findResponse := ZoneConfigsFindResponse{}
+				findResponse.Status = "success"
+				findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+				return findResponse
+			},
+		})
+
+		ctx := context.Background()
+		r := &recordResource{client: client}
+		schemaResp := &fwresource.SchemaResponse{}
+		r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+		plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"zone_id":  "zone-id",
+			"name":     "example.test",
+			"fqdn":     "example.test",
+			"type":     recordType,
+			"content":  configured,
+			"ttl":      3600,
+			"priority": 10,
+			"comments": "",
+		})
+
+		createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", recordType, createResp.Diagnostics)
+		}
+		if stored.Content != canonicalContent(recordType, configured) {
+			t.Errorf("%s: expected canonical content to be sent, got %q", recordType, stored.Content)
+		}
+
+		// The configured formatting is kept, so the next plan is empty.
+		readResp := &fwresource.ReadResponse{State: createResp.State}
+		r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", recordType, readResp.Diagnostics)
+		}
+
+		var state recordResourceModel
+		readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+		if state.Content.ValueString() != configured {
+			t.Errorf("%s: expected configured content %q to be kept, got %q", recordType, configured, state.Content.ValueString())
+		}
+	}
+}
+
+func TestRecordResourceIDNContent(t *testing.T) {
+	var stored DNSRecord
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			stored = updateRequest.RecordsToAdd[0]
+			stored.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{stored}
+			return updateResponse
+		},
+		"recordsFind": func(body []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{stored}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"fqdn":     "www.example.test",
+		"type":     "CNAME",
+		"content":  "mail.müller.de",
+		"ttl":      3600,
+		"priority": types.Int64Null(),
+		"comments": "",
+	})
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+	if stored.Content != "mail.xn--mller-kva.de" {
+		t.Errorf("expected the target to be sent in ASCII compatible form, got %q", stored.Content)
+	}
+
+	// The configured Unicode target is kept, so the next plan is empty.
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+	var state recordResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+	if state.Content.ValueString() != "mail.müller.de" {
+		t.Errorf("expected configured content to be kept, got %q", state.Content.ValueString())
+	}
+
+	// Unmatched content is shown in the form set by idn_display.
+	if content := client.normalizeContent("CNAME", stored.Content); content != "mail.xn--mller-kva.de" {
+		t.Errorf("expected ASCII compatible content by default, got %q", content)
+	}
+	client.idnDisplay = idnDisplayUnicode
+	if content := client.normalizeContent("SRV", "10 5060 sip.xn--mller-kva.de."); content != "10 5060 sip.müller.de." {
+		t.Errorf("expected Unicode content, got %q", content)
+	}
+
+	diags := validateRecordResourceConfig(t, map[string]any{
+		"type":    "CNAME",
+		"content": "mail.mü ller.de",
+	})
+	if !diags.HasError() {
+		t.Error("expected an error for an invalid internationalized target")
+	}
+}
+
+func TestRecordResourceNormalizationWarning(t *testing.T) {
+	for content, warn := range map[string]bool{
+		`"v=spf1 " "-all"`: true,
+		"v=spf1 -all":      false,
+	} {
+		client := newTestClient(t, map[string]func(body []byte) any{
+			"recordsFind": func(_ []byte) any {
+				findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+				findResponse.Response.Data = []DNSRecord{
+					{ID: "record-id", ZoneID: "zone-id", Name: "example.test", Type: "TXT", Content: content, TTL: 3600},
+				}
+				findResponse.Response.TotalEntries = 1
+				return findResponse
+			},
+			"zoneConfigsFind": func(_ []byte) any {
+				findResponse := ZoneConfigsFindResponse{}
+				findResponse.Status = "success"
+				findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+				return findResponse
+			},
+		})
+
+		ctx := context.Background()
+		r := &recordResource{client: client}
+		schemaResp := &fwresource.SchemaResponse{}
+		r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+		prior := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"id":        "record-id",
+			"zone_id":   "zone-id",
+			"zone_name": "example.test",
+			"name":      "example.test",
+			"type":      "TXT",
+			"content":   "v=spf1 -all",
+		})
+		state := tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}
+
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", content, resp.Diagnostics)
+		}
+		warnings := resp.Diagnostics.Warnings()
+		if !warn {
+			if len(warnings) != 0 {
+				t.Errorf("%s: expected no warning for unchanged content, got %v", content, warnings)
+			}
+			continue
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), `from "\"v=spf1 \" \"-all\"" to "v=spf1 -all"`) {
+			t.Errorf("%s: expected normalization warning with the content before and after, got %v", content, warnings)
+		}
+
+		// The warning can be disabled.
+		client.normalizationWarning = false
+		resp = &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.WarningsCount() != 0 {
+			t.Errorf("%s: expected no warning if disabled, got %v", content, resp.Diagnostics)
+		}
+	}
+}
+
+func TestRecordResourceTXTEscapes(t *testing.T) {
+	for _, configured := range []string{`say "hi" \o/`, `"say \"hi\" \092o/"`} {
+		var stored DNSRecord
+		client := newTestClient(t, map[string]func(body []byte) any{
+			"recordsUpdate": func(body []byte) any {
+				var updateRequest RecordsUpdateRequest
+				if err := json.Unmarshal(body, &updateRequest); err != nil {
+					t.Fatal(err)
+				}
+
+				stored = updateRequest.RecordsToAdd[0]
+				stored.ID = "record-id"
+				updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+				updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+				updateResponse.Response.Records = []DNSRecord{stored}
+				return updateResponse
+			},
+			"recordsFind": func(body []byte) any {
+				findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+				findResponse.Response.Data = []DNSRecord{stored}
+				findResponse.Response.TotalEntries = 1
+				return findResponse
+			},
+			"zoneConfigsFind": func(_ []byte) any {
+				findResponse := ZoneConfigsFindResponse{}
+				findResponse.Status = "success"
+				findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+				return findResponse
+			},
+		})
+		client.normalizationWarning = false
+
+		ctx := context.Background()
+		r := &recordResource{client: client}
+		schemaResp := &fwresource.SchemaResponse{}
+		r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+		plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"zone_id":  "zone-id",
+			"name":     "example.test",
+			"fqdn":     "example.test",
+			"type":     "TXT",
+			"content":  configured,
+			"ttl":      3600,
+			"comments": "",
+		})
+
+		createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", configured, createResp.Diagnostics)
+		}
+		if text, err := parseTXTContent(stored.Content); err != nil || text != `say "hi" \o/` {
+			t.Errorf("%s: expected escaped content to be sent, got %s", configured, stored.Content)
+		}
+
+		readResp := &fwresource.ReadResponse{State: createResp.State}
+		r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", configured, readResp.Diagnostics)
+		}
+
+		var state recordResourceModel
+		readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+		if state.Content.ValueString() != configured {
+			t.Errorf("%s: expected content to round-trip, got %s", configured, state.Content.ValueString())
+		}
+	}
+
+	diags := validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "example.test",
+		"type":    "TXT",
+		"content": `"invalid \300 escape"`,
+	})
+	if !diags.HasError() {
+		t.Errorf("expected malformed escape sequence to be rejected")
+	}
+}
+
+func TestRecordResourceDefaultTTL(t *testing.T) {
+	zoneConfigs := map[string]ZoneConfig{
+		"zone-id":        {ID: "zone-id", Name: "example.test", Status: "active", SOAValues: &SOAValues{TTL: 7200}},
+		"no-soa-zone-id": {ID: "no-soa-zone-id", Name: "example.org", Status: "active"},
+	}
+	zoneConfigRequests := 0
+	var stored DNSRecord
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(body []byte) any {
+			var findRequest ZoneConfigsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			zoneConfigRequests++
+
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			for _, zoneConfig := range zoneConfigs {
+				if zoneConfig.ID == findRequest.Filter.Value || zoneConfig.Name == findRequest.Filter.Value {
+					findResponse.Response.Data = []ZoneConfig{zoneConfig}
+				}
+			}
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			stored = updateRequest.RecordsToAdd[0]
+			stored.ID = "record-id"
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = zoneConfigs["zone-id"]
+			updateResponse.Response.Records = []DNSRecord{stored}
+			return updateResponse
+		},
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{stored}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	planTTL := func(values map[string]any) types.Int64 {
+		t.Helper()
+
+		values["name"] = "www"
+		values["type"] = "A"
+		values["content"] = "192.0.2.1"
+		config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+		plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   plan,
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+
+		var model recordResourceModel
+		resp.Diagnostics.Append(resp.Plan.Get(ctx, &model)...)
+		return model.TTL
+	}
+
+	// An explicit TTL takes precedence over all defaults.
+	client.defaultTTL = 600
+	if ttl := planTTL(map[string]any{"zone_id": "zone-id", "ttl": 300}); ttl.ValueInt64() != 300 {
+		t.Errorf("expected configured TTL, got %s", ttl)
+	}
+
+	// The provider's default_ttl takes precedence over the zone's TTL.
+	if ttl := planTTL(map[string]any{"zone_id": "zone-id"}); ttl.ValueInt64() != 600 {
+		t.Errorf("expected provider default TTL, got %s", ttl)
+	}
+
+	// Otherwise the TTL of the zone's SOA record is inherited, and 3600 is
+	// used for zones without SOA values.
+	client.defaultTTL = 0
+	if ttl := planTTL(map[string]any{"zone_id": "zone-id"}); ttl.ValueInt64() != 7200 {
+		t.Errorf("expected zone TTL, got %s", ttl)
+	}
+	if ttl := planTTL(map[string]any{"zone_id": "no-soa-zone-id"}); ttl.ValueInt64() != fallbackRecordTTL {
+		t.Errorf("expected fallback TTL, got %s", ttl)
+	}
+
+	// The zone lookup is cached.
+	planTTL(map[string]any{"zone_id": "zone-id"})
+	if zoneConfigRequests != 2 {
+		t.Errorf("expected one zone config request per zone, got %d", zoneConfigRequests)
+	}
+
+	// The zone of records configured by zone name is only known on apply.
+	ttl := planTTL(map[string]any{"zone_name": "example.test"})
+	if !ttl.IsUnknown() {
+		t.Fatalf("expected unknown TTL for a record of a zone resolved on apply, got %s", ttl)
+	}
+	var model recordResourceModel
+	diags := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_name": "example.test",
+		"name":      "www",
+		"type":      "A",
+		"content":   "192.0.2.1",
+		"comments":  "",
+	}).Get(ctx, &model)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	model.TTL = types.Int64Unknown()
+	createPlan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := createPlan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: createPlan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	if stored.TTL != 7200 {
+		t.Errorf("expected the zone TTL to be applied on create, got %d", stored.TTL)
+	}
+}
+
+func TestRecordResourceTXTJoin(t *testing.T) {
+	stored := DNSRecord{
+		ID:      "record-id",
+		ZoneID:  "zone-id",
+		Name:    "_dmarc.example.test",
+		Type:    "TXT",
+		Content: `"v=DMARC1; p=none;" "rua=mailto:dmarc@example.test"`,
+		TTL:     3600,
+	}
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{stored}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+	client.normalizationWarning = false
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	read := func(join string, content types.String) string {
+		t.Helper()
+
+		state := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"id":       "record-id",
+			"zone_id":  "zone-id",
+			"name":     "_dmarc.example.test",
+			"type":     "TXT",
+			"content":  content,
+			"ttl":      3600,
+			"comments": "",
+			"txt_join": join,
+		})
+		readResp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+		r.Read(ctx, fwresource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}, readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatal(readResp.Diagnostics)
+		}
+
+		var model recordResourceModel
+		readResp.Diagnostics.Append(readResp.State.Get(ctx, &model)...)
+		return model.Content.ValueString()
+	}
+
+	for _, test := range []struct {
+		join, configured string
+	}{
+		{txtJoinConcatenate, "v=DMARC1; p=none;rua=mailto:dmarc@example.test"},
+		{txtJoinSpace, "v=DMARC1; p=none; rua=mailto:dmarc@example.test"},
+		{txtJoinConcatenate, `"v=DMARC1; p=none;" "rua=mailto:dmarc@example.test"`},
+		{txtJoinSpace, `"v=DMARC1; p=none;" "rua=mailto:dmarc@example.test"`},
+	} {
+		if content := read(test.join, types.StringValue(test.configured)); content != test.configured {
+			t.Errorf("%s: expected configured content %q to round-trip, got %q", test.join, test.configured, content)
+		}
+	}
+
+	// Imported records get the content joined as configured.
+	if content := read(txtJoinConcatenate, types.StringNull()); content != "v=DMARC1; p=none;rua=mailto:dmarc@example.test" {
+		t.Errorf("expected concatenated content, got %q", content)
+	}
+	if content := read(txtJoinSpace, types.StringNull()); content != "v=DMARC1; p=none; rua=mailto:dmarc@example.test" {
+		t.Errorf("expected space-joined content, got %q", content)
+	}
+	if content := read(txtJoinSpace, types.StringValue("v=DMARC1; p=none;rua=mailto:dmarc@example.test")); content != "v=DMARC1; p=none; rua=mailto:dmarc@example.test" {
+		t.Errorf("expected concatenated content to differ in space mode, got %q", content)
+	}
+}
+
+func TestRecordResourceAutoSplit(t *testing.T) {
+	var stored DNSRecord
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "zone-id", Name: "example.test"}
+			for _, record := range updateRequest.RecordsToAdd {
+				record.ID = "record-id"
+				stored = record
+				updateResponse.Response.Records = append(updateResponse.Response.Records, record)
+			}
+			return updateResponse
+		},
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{stored}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	key := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12)
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":    "zone-id",
+		"name":       "mail._domainkey.example.test",
+		"fqdn":       "mail._domainkey.example.test",
+		"type":       "TXT",
+		"content":    key,
+		"ttl":        3600,
+		"comments":   "",
+		"auto_split": true,
+	})
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+	if expected := `"` + key[:255] + `" "` + key[255:] + `"`; stored.Content != expected {
+		t.Errorf("expected the content to be sent as character strings of 255 bytes, got %s", stored.Content)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+	var state recordResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+	if state.Content.ValueString() != key {
+		t.Errorf("expected the configured content to be kept, got %s", state.Content)
+	}
+
+	// Split character strings cannot be joined by spaces.
+	config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id":    "zone-id",
+		"name":       "mail._domainkey.example.test",
+		"type":       "TXT",
+		"content":    key,
+		"auto_split": true,
+		"txt_join":   txtJoinSpace,
+	})
+	validateResp := &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: config}, validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected auto_split to conflict with txt_join space")
+	}
+}
+
+func TestRecordResourceImmutableContent(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	replaced := func(recordType, content, newContent string) bool {
+		t.Helper()
+
+		values := map[string]any{
+			"zone_id":  "zone-id",
+			"name":     "example.test",
+			"type":     recordType,
+			"content":  content,
+			"ttl":      3600,
+			"priority": 0,
+			"comments": "",
+		}
+		prior := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+		values["content"] = newContent
+		config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+		plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   plan,
+			State:  tfsdk.State{Schema: prior.Schema, Raw: prior.Raw},
+		}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		return slices.ContainsFunc(resp.RequiresReplace, func(p path.Path) bool { return p.Equal(path.Root("content")) })
+	}
+
+	const key = "257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="
+	if !replaced("DNSKEY", key, "256 3 13 oJMRESz5E4gYzS/q6XDrvU1qMPYIjCWzJaOau8XNEZeqCYKD5ar0IRd8KqXXFJkqmVfRvMGPmM1x8fGAa2XhSA==") {
+		t.Errorf("expected a changed DNSKEY content to require replacement")
+	}
+	if replaced("DNSKEY", key, key) {
+		t.Errorf("expected an unchanged DNSKEY content not to require replacement")
+	}
+	if replaced("TXT", "v=spf1 -all", "v=spf1 mx -all") {
+		t.Errorf("expected TXT content to be modified in place")
+	}
+}
+
+func TestRecordResourceCNAMESelfReference(t *testing.T) {
+	diags := validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "www.example.test",
+		"type":    "CNAME",
+		"content": "WWW.example.test.",
+	})
+	if !diags.HasError() || diags.Errors()[0].Summary() != "CNAME record points to itself" {
+		t.Errorf("expected a self-referencing CNAME to be rejected, got %v", diags)
+	}
+
+	diags = validateRecordResourceConfig(t, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "www.example.test",
+		"type":    "CNAME",
+		"content": "web.example.test",
+	})
+	if diags.HasError() {
+		t.Errorf("expected a CNAME to another name to be valid, got %v", diags)
+	}
+
+	// Relative names are qualified with the zone name when planning.
+	client := newTestClient(t, map[string]func(body []byte) any{})
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	for content, selfReference := range map[string]bool{
+		"www.example.test": true,
+		"www":              true,
+		"www.other.test":   false,
+	} {
+		config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"zone_name": "example.test",
+			"name":      "www",
+			"type":      "CNAME",
+			"content":   content,
+			"ttl":       3600,
+		})
+		plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   plan,
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}, resp)
+		if resp.Diagnostics.HasError() != selfReference {
+			t.Errorf("%s: expected self reference %t, got %v", content, selfReference, resp.Diagnostics)
+		}
+	}
+}
+
+func TestRecordResourceImportByName(t *testing.T) {
+	stored := []DNSRecord{
+		{ID: "1", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1"},
+		{ID: "2", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.2"},
+		{ID: "3", ZoneID: "zone-id", Name: "example.test", Type: "TXT", Content: `"v=spf1 include:_spf.example.test/24 -all"`},
+	}
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			for _, record := range stored {
+				matches := true
+				for _, filter := range findRequest.Filter.SubFilter {
+					switch filter.Field {
+					case "zoneConfigId":
+						matches = matches && record.ZoneID == filter.Value
+					case "recordName":
+						matches = matches && record.Name == filter.Value
+					case "recordType":
+						matches = matches && record.Type == filter.Value
+					}
+				}
+				if matches {
+					findResponse.Response.Data = append(findResponse.Response.Data, record)
+				}
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	importID := func(id string) (string, diag.Diagnostics) {
+		t.Helper()
+
+		resp := &fwresource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, resp)
+		if resp.Diagnostics.HasError() {
+			return "", resp.Diagnostics
+		}
+		var imported types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &imported)...)
+		return imported.ValueString(), resp.Diagnostics
+	}
+
+	for id, expected := range map[string]string{
+		"record-id":                             "record-id",
+		"zone-id/www.example.test/A/192.0.2.2":  "2",
+		"zone-id/www.example.test./A/192.0.2.1": "1",
+		"zone-id/example.test/TXT/v=spf1 include:_spf.example.test/24 -all": "3",
+	} {
+		imported, diags := importID(id)
+		if diags.HasError() {
+			t.Errorf("%s: unexpected error: %v", id, diags)
+			continue
+		}
+		if imported != expected {
+			t.Errorf("%s: expected record ID %s, got %s", id, expected, imported)
+		}
+	}
+
+	for id, summary := range map[string]string{
+		"zone-id/www.example.test/A":           "Several hosting.de DNS records found",
+		"zone-id/mail.example.test/A":          "hosting.de DNS record not found",
+		"zone-id/www.example.test/A/192.0.2.3": "hosting.de DNS record not found",
+		"zone-id/www.example.test":             "Invalid import ID",
+		"zone-id//A":                           "Invalid import ID",
+	} {
+		_, diags := importID(id)
+		if !diags.HasError() || diags.Errors()[0].Summary() != summary {
+			t.Errorf("%s: expected error %q, got %v", id, summary, diags)
+		}
+	}
+}
+
+func TestRecordResourceReadAfterPassthroughImport(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = []DNSRecord{{
+				ID: "record-id", ZoneID: "zone-id",
+				Name: "www.example.test", Type: "TXT", Content: `"v=spf1 " "-all"`, TTL: 3600,
+			}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", AccountID: "account-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	// Records imported by ID only have the ID in state.
+	importResp := &fwresource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "record-id"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatal(importResp.Diagnostics)
+	}
+
+	readResp := &fwresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	var state recordResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	for name, value := range map[string]attr.Value{
+		"zone_id": state.ZoneID, "zone_name": state.ZoneName, "zone_status": state.ZoneStatus,
+		"name": state.Name, "fqdn": state.FQDN, "type": state.Type, "content": state.Content,
+		"ttl": state.TTL, "comments": state.Comments,
+		"content_hash": state.ContentHash, "account_id": state.AccountID,
+		"terraform_managed": state.TerraformManaged, "upsert": state.Upsert, "txt_join": state.TXTJoin,
+		"auto_split": state.AutoSplit,
+	} {
+		if value.IsNull() || value.IsUnknown() {
+			t.Errorf("expected %s to be set by the read after the import, got %s", name, value)
+		}
+	}
+	if !state.Priority.IsNull() {
+		t.Errorf("expected no priority for a TXT record, got %s", state.Priority)
+	}
+
+	// The plan for the matching configuration, with the defaults of unset
+	// attributes, keeps the read state.
+	config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"zone_id": "zone-id",
+		"name":    "www.example.test",
+		"type":    "TXT",
+		"content": "v=spf1 -all",
+		"ttl":     3600,
+	})
+	plan := tfsdk.Plan{Schema: readResp.State.Schema, Raw: readResp.State.Raw}
+	modifyResp := &fwresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: readResp.State}, modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatal(modifyResp.Diagnostics)
+	}
+	if len(modifyResp.RequiresReplace) != 0 {
+		t.Errorf("expected no replacement after the import, got %v", modifyResp.RequiresReplace)
+	}
+	if !modifyResp.Plan.Raw.Equal(readResp.State.Raw) {
+		t.Errorf("expected no changes after the import, got plan %v for state %v", modifyResp.Plan.Raw, readResp.State.Raw)
+	}
+	if state.Content.ValueString() != "v=spf1 -all" || state.TXTJoin.ValueString() != txtJoinConcatenate || state.Upsert.ValueBool() {
+		t.Errorf("expected the defaults of the configuration, got content %s, txt_join %s, upsert %s", state.Content, state.TXTJoin, state.Upsert)
+	}
+
+	// The account of the record is the account of its zone, so a zone moved
+	// to another account is reported.
+	state.AccountID = types.StringValue("old-account-id")
+	movedState := tfsdk.State{Schema: readResp.State.Schema}
+	if diags := movedState.Set(ctx, &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	readResp = &fwresource.ReadResponse{State: movedState}
+	r.Read(ctx, fwresource.ReadRequest{State: movedState}, readResp)
+	if warnings := readResp.Diagnostics.Warnings(); len(warnings) == 0 || warnings[0].Summary() != "hosting.de record moved to another account" {
+		t.Errorf("expected a warning about the moved record, got %v", readResp.Diagnostics)
+	}
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+	if state.AccountID.ValueString() != "account-id" {
+		t.Errorf("expected account_id of the zone, got %s", state.AccountID)
+	}
+}
+
+func TestRecordResourceTXTSize(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := func(content string) diag.Diagnostics {
+		t.Helper()
+
+		config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"zone_id": "zone-id",
+			"name":    "selector._domainkey.example.test",
+			"type":    "TXT",
+			"content": content,
+			"ttl":     3600,
+		})
+		plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   plan,
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}, resp)
+		return resp.Diagnostics
+	}
+
+	// 600 bytes of text are sent as three character strings.
+	oversized := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 582)
+	if size := txtDataSize(oversized); size != 603 {
+		t.Errorf("expected record data of 603 bytes, got %d", size)
+	}
+	if size := txtDataSize(`"v=spf1 " "-all"`); size != 13 {
+		t.Errorf("expected record data of 13 bytes, got %d", size)
+	}
+
+	diags := plan(oversized)
+	if diags.HasError() || len(diags.Warnings()) != 1 || diags.Warnings()[0].Summary() != "Large TXT record" {
+		t.Errorf("expected a warning for the oversized TXT record, got %v", diags)
+	}
+	if diags := plan("v=spf1 -all"); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for a small TXT record, got %v", diags)
+	}
+
+	client.strictValidation = true
+	if diags := plan(oversized); !diags.HasError() || diags.Errors()[0].Summary() != "Large TXT record" {
+		t.Errorf("expected an error in strict mode, got %v", diags)
+	}
+
+	client.txtSizeThreshold = 0
+	if diags := plan(oversized); len(diags) != 0 {
+		t.Errorf("expected no diagnostics with the check disabled, got %v", diags)
+	}
+}
+
+func TestRecordPriorityJSON(t *testing.T) {
+	for record, expected := range map[DNSRecord]string{
+		{Name: "www.example.test", Type: "A", Content: "192.0.2.1"}:                        `{"name":"www.example.test","type":"A","content":"192.0.2.1"}`,
+		{Name: "example.test", Type: "MX", Content: "mail.example.test"}:                   `{"name":"example.test","type":"MX","content":"mail.example.test","priority":0}`,
+		{Name: "_sip._tcp.example.test", Type: "SRV", Content: "0 5060 sip", Priority: 10}: `{"name":"_sip._tcp.example.test","type":"SRV","content":"0 5060 sip","priority":10}`,
+	} {
+		body, err := json.Marshal(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != expected {
+			t.Errorf("%s: expected %s, got %s", record.Type, expected, body)
+		}
+	}
+}
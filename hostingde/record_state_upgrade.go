@@ -0,0 +1,87 @@
+package hostingde
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// recordResourceModelV0 maps the record resource schema of version 0, which
+// only had the attributes sent to the API.
+type recordResourceModelV0 struct {
+	ID       types.String `tfsdk:"id"`
+	ZoneID   types.String `tfsdk:"zone_id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Comments types.String `tfsdk:"comments"`
+}
+
+// recordSchemaV0 returns the record resource schema of version 0. Only the
+// attribute types matter to decode prior state.
+func recordSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":       schema.StringAttribute{Computed: true},
+			"zone_id":  schema.StringAttribute{Required: true},
+			"name":     schema.StringAttribute{Required: true},
+			"type":     schema.StringAttribute{Required: true},
+			"content":  schema.StringAttribute{Required: true},
+			"ttl":      schema.Int64Attribute{Optional: true, Computed: true},
+			"priority": schema.Int64Attribute{Optional: true, Computed: true},
+			"comments": schema.StringAttribute{Optional: true, Computed: true},
+		},
+	}
+}
+
+// UpgradeState migrates the state of prior schema versions to the current
+// schema.
+func (r *recordResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   recordSchemaV0(),
+			StateUpgrader: upgradeRecordStateV0,
+		},
+	}
+}
+
+// upgradeRecordStateV0 keeps the attributes of version 0 and derives the
+// added attributes from them where possible. The name of version 0 was always
+// absolute, so it is also the fqdn. Attributes that are only known from the
+// API, such as zone_name and account_id, are null until the next read.
+func upgradeRecordStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior recordResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := recordResourceModel{
+		ID:               prior.ID,
+		ZoneID:           prior.ZoneID,
+		ZoneName:         types.StringNull(),
+		ZoneStatus:       types.StringNull(),
+		Name:             prior.Name,
+		FQDN:             prior.Name,
+		Type:             prior.Type,
+		Content:          prior.Content,
+		TTL:              prior.TTL,
+		Priority:         prior.Priority,
+		Comments:         prior.Comments,
+		RecordTemplateID: types.StringNull(),
+		AccountID:        types.StringNull(),
+		OwnerAccountID:   types.StringNull(),
+		TerraformManaged: types.BoolNull(),
+		Upsert:           types.BoolValue(false),
+		TXTJoin:          types.StringValue(txtJoinConcatenate),
+		AutoSplit:        types.BoolValue(false),
+	}
+	state.setStructuredContent()
+	state.setContentHash()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,87 @@
+package hostingde
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func TestRecordResourceUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	r := &recordResource{}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	upgraders := r.UpgradeState(ctx)
+	if schemaResp.Schema.Version != 1 || len(upgraders) != int(schemaResp.Schema.Version) {
+		t.Fatalf("expected an upgrader for every prior version, got %d for version %d", len(upgraders), schemaResp.Schema.Version)
+	}
+
+	upgrade := func(values map[string]any) recordResourceModel {
+		t.Helper()
+
+		upgrader := upgraders[0]
+		prior := newTestConfig(t, tfsdk.State{Schema: *upgrader.PriorSchema}, values)
+		resp := &fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+
+		var state recordResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		return state
+	}
+
+	state := upgrade(map[string]any{
+		"id":       "record-id",
+		"zone_id":  "zone-id",
+		"name":     "www.example.test",
+		"type":     "A",
+		"content":  "192.0.2.1",
+		"ttl":      300,
+		"priority": 0,
+		"comments": "web server",
+	})
+	if state.ID.ValueString() != "record-id" || state.ZoneID.ValueString() != "zone-id" || state.Name.ValueString() != "www.example.test" ||
+		state.Content.ValueString() != "192.0.2.1" || state.TTL.ValueInt64() != 300 || state.Comments.ValueString() != "web server" {
+		t.Errorf("expected the attributes of version 0 to be kept, got %+v", state)
+	}
+	if state.FQDN.ValueString() != "www.example.test" || state.ContentHash.IsNull() {
+		t.Errorf("expected the fqdn and content hash to be derived, got %s %s", state.FQDN, state.ContentHash)
+	}
+	if !state.ZoneName.IsNull() || !state.AccountID.IsNull() || !state.TerraformManaged.IsNull() {
+		t.Errorf("expected attributes only known from the API to be null until the next read, got %+v", state)
+	}
+	if state.Upsert.ValueBool() || state.TXTJoin.ValueString() != txtJoinConcatenate {
+		t.Errorf("expected attributes with defaults to get their default, got %s %s", state.Upsert, state.TXTJoin)
+	}
+
+	hash := state.ContentHash
+	state.setContentHash()
+	if !state.ContentHash.Equal(hash) {
+		t.Errorf("expected the content hash of a read record, got %s instead of %s", hash, state.ContentHash)
+	}
+
+	state = upgrade(map[string]any{
+		"id":       "record-id",
+		"zone_id":  "zone-id",
+		"name":     "example.test",
+		"type":     "DS",
+		"content":  "12345 13 2 ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789",
+		"ttl":      3600,
+		"priority": 0,
+		"comments": "",
+	})
+	if state.DSKeyTag.ValueInt64() != 12345 || state.DSAlgorithm.ValueInt64() != 13 || state.DSDigestType.ValueInt64() != 2 {
+		t.Errorf("expected the structured content of DS records to be derived, got %s %s %s", state.DSKeyTag, state.DSAlgorithm, state.DSDigestType)
+	}
+	if !state.TLSAUsage.IsNull() || !state.SSHFPAlgorithm.IsNull() {
+		t.Errorf("expected the structured content of other types to be null, got %s %s", state.TLSAUsage, state.SSHFPAlgorithm)
+	}
+}
@@ -0,0 +1,697 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/idna"
+)
+
+// Bounds of the record TTL accepted by the API.
+const (
+	minRecordTTL = 60
+	maxRecordTTL = 31556926
+)
+
+// fallbackRecordTTL is the TTL of records without a configured TTL if neither
+// the provider nor the zone defines a default.
+const fallbackRecordTTL = 3600
+
+// Length limits of DNS names in wire form, see RFC 1035 section 2.3.4.
+const (
+	maxLabelLength = 63
+	maxNameLength  = 255
+)
+
+// recordTypes lists the record types supported by the API.
+var recordTypes = []string{
+	"A", "AAAA", "ALIAS", "CAA", "CERT", "CNAME", "DNSKEY", "DS", "MX", "NS", "NSEC", "NSEC3", "NSEC3PARAM",
+	"NULLMX", "OPENPGPKEY", "PTR", "RRSIG", "SPF", "SRV", "SSHFP", "TLSA", "TXT",
+}
+
+// immutableContentRecordTypes lists the record types whose content is not
+// modified in place. These DNSSEC records are identified by their content, so
+// changing it re-creates the record instead.
+var immutableContentRecordTypes = map[string]bool{
+	"DNSKEY":     true,
+	"NSEC":       true,
+	"NSEC3":      true,
+	"NSEC3PARAM": true,
+	"RRSIG":      true,
+}
+
+// recordConfig holds the record attributes subject to validation. Values may
+// be null or unknown, in which case checks depending on them are skipped.
+type recordConfig struct {
+	Name     types.String
+	Type     types.String
+	Content  types.String
+	TTL      types.Int64
+	Priority types.Int64
+
+	// Strict reports likely misconfigurations, such as SRV records not named
+	// after a service and protocol, as errors instead of warnings.
+	Strict bool
+}
+
+// validateRecord checks a record configuration against the per-type rules of
+// the provider. It is shared by the record resource and the validate_record
+// function.
+func validateRecord(record recordConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if record.Type.IsUnknown() {
+		return diags
+	}
+	recordType := record.Type.ValueString()
+	contentKnown := !record.Content.IsNull() && !record.Content.IsUnknown()
+
+	if !record.Name.IsNull() && !record.Name.IsUnknown() {
+		if err := validateRecordName(record.Name.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("name"), "Invalid record name", err.Error())
+		}
+		if recordType == "SRV" {
+			diags.Append(srvNameDiagnostics(record.Name.ValueString(), record.Strict)...)
+		}
+	}
+
+	if !record.Type.IsNull() && !slices.Contains(recordTypes, recordType) {
+		detail := "The hosting.de API does not support records of type " + recordType + ". Valid types are " + strings.Join(recordTypes, ", ") + "."
+		// NAPTR is commonly used for SIP and ENUM, but not offered by hosting.de.
+		if recordType == "NAPTR" {
+			detail = "The hosting.de API does not support NAPTR records, so SIP and ENUM services cannot be published in hosting.de zones. " +
+				"Valid types are " + strings.Join(recordTypes, ", ") + "."
+		}
+		diags.AddAttributeError(path.Root("type"), "Unsupported record type", detail)
+		return diags
+	}
+
+	// SPF has its own, deprecated record type, but is usually published as TXT.
+	if recordType == "SPF" {
+		diags.AddAttributeWarning(
+			path.Root("type"),
+			"Deprecated record type",
+			"The SPF record type is deprecated in favor of TXT records (RFC 7208). "+
+				"Set type = \"TXT\", or set convert_spf_to_txt = true in the provider configuration "+
+				"to publish SPF records as TXT records.",
+		)
+	}
+	if contentKnown &&
+		(recordType == "SPF" || recordType == "TXT" && isSPFContent(record.Content.ValueString())) {
+		if err := validateSPF(record.Content.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid SPF policy",
+				"The content is not a valid SPF policy: "+err.Error(),
+			)
+		}
+	}
+
+	if recordType == "ALIAS" && contentKnown {
+		if err := validateALIAS(record.Name, record.Content.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid ALIAS target",
+				"The content of an ALIAS record must be the host name to resolve: "+err.Error(),
+			)
+		}
+	}
+
+	// ALIAS and SRV content is checked with the rest of its content.
+	if (recordType == "CNAME" || recordType == "MX" || recordType == "NS" || recordType == "PTR") && contentKnown {
+		if _, err := aceHostName(record.Content.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid internationalized host name",
+				"The host name in the content cannot be converted to its ASCII compatible form: "+err.Error(),
+			)
+		}
+	}
+
+	// Names that are not absolute are checked again once the zone name is
+	// known, see cnameSelfReferenceDiagnostics.
+	if recordType == "CNAME" && contentKnown && !record.Name.IsNull() && !record.Name.IsUnknown() {
+		diags.Append(cnameSelfReferenceDiagnostics(record.Name.ValueString(), record.Content.ValueString(), "")...)
+	}
+
+	if (recordType == "A" || recordType == "AAAA") && contentKnown {
+		if _, err := parseAddressContent(recordType, record.Content.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid "+recordType+" content",
+				"The content of an "+recordType+" record must be an IP address: "+err.Error(),
+			)
+		}
+	}
+
+	if recordType == "CAA" && contentKnown {
+		if _, err := parseCAAContent(record.Content.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid CAA content",
+				"The content of a CAA record must be flags, tag and quoted value, e.g. 0 issue \"letsencrypt.org\": "+err.Error(),
+			)
+		}
+	}
+
+	if recordType == "SRV" && contentKnown {
+		if _, err := parseSRVContent(record.Content.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid SRV content",
+				"The content of an SRV record must be weight, port and target, e.g. 10 5060 sip.example.com., with the priority set separately: "+err.Error(),
+			)
+		}
+	}
+
+	if recordType == "DS" && contentKnown {
+		if _, err := parseDSContent(record.Content.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid DS content",
+				"The content of a DS record must be key tag, algorithm, digest type and digest: "+err.Error(),
+			)
+		}
+	}
+
+	// A NULLMX record is an MX record with the root as target and priority 0.
+	if recordType == "NULLMX" {
+		if contentKnown && strings.TrimSpace(record.Content.ValueString()) != "." {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid NULLMX content",
+				"The content of a NULLMX record must be \".\", the root, as the record declares that the domain does not accept email (RFC 7505). "+
+					"Got "+strconv.Quote(record.Content.ValueString())+".",
+			)
+		}
+		if !record.Priority.IsNull() && !record.Priority.IsUnknown() && record.Priority.ValueInt64() != 0 {
+			diags.AddAttributeError(
+				path.Root("priority"),
+				"Invalid NULLMX priority",
+				fmt.Sprintf("The priority of a NULLMX record must be 0 (RFC 7505), got %d. Remove priority or set it to 0.", record.Priority.ValueInt64()),
+			)
+		}
+	}
+
+	if contentKnown {
+		if err := validateEncodedContent(recordType, record.Content.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid "+recordType+" content",
+				"The content of the record is not encoded correctly: "+err.Error(),
+			)
+		}
+	}
+
+	if !record.TTL.IsNull() && !record.TTL.IsUnknown() {
+		if err := validateTTL(record.TTL.ValueInt64()); err != nil {
+			diags.AddAttributeError(path.Root("ttl"), "Invalid TTL", err.Error())
+		}
+	}
+
+	// Priority is required for MX and SRV records, checked above for NULLMX
+	// records, and not allowed otherwise.
+	if recordType == "MX" || recordType == "SRV" {
+		if record.Priority.IsNull() {
+			diags.AddAttributeError(
+				path.Root("priority"),
+				"Missing attribute",
+				"Setting priority is required for records of type MX or SRV. "+
+					"Please add a priority to the resource, for example priority = 0.",
+			)
+		}
+	} else if !record.Priority.IsNull() && !record.Priority.IsUnknown() && recordType != "NULLMX" {
+		diags.AddAttributeError(
+			path.Root("type"),
+			"Unexpected combination of attributes",
+			"Priority is only relevant for records of type MX or SRV. "+
+				"Please remove priority from the resource or change its type.",
+		)
+	}
+
+	return diags
+}
+
+// cnameCoexistingTypes are the record types allowed at the name of a CNAME
+// record (RFC 2181, section 10.1 and RFC 4035, section 2.5).
+var cnameCoexistingTypes = []string{"NSEC", "RRSIG"}
+
+// validateCNAMECoexistence checks a list of records of a zone for names with a
+// CNAME record and other records, and for names with several CNAME records.
+// Records with unknown names or types are skipped.
+func validateCNAMECoexistence(records types.List, recordsPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Indexes of the records by name and whether they are CNAME records.
+	cnames := map[string][]int{}
+	others := map[string][]int{}
+	for i, element := range records.Elements() {
+		key, recordType, ok := recordNameType(element)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case recordType == "CNAME":
+			cnames[key] = append(cnames[key], i)
+		case !slices.Contains(cnameCoexistingTypes, recordType):
+			others[key] = append(others[key], i)
+		}
+	}
+
+	names := make([]string, 0, len(cnames))
+	for name := range cnames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if len(cnames[name]) > 1 {
+			diags.AddAttributeError(
+				recordsPath.AtListIndex(cnames[name][1]).AtName("type"),
+				"Duplicate CNAME record",
+				fmt.Sprintf("The name %s has %d CNAME records, but a name may only have a single CNAME record (RFC 1034, section 3.6.2).", name, len(cnames[name])),
+			)
+		}
+		if len(others[name]) > 0 {
+			diags.AddAttributeError(
+				recordsPath.AtListIndex(cnames[name][0]).AtName("type"),
+				"CNAME record conflicts with other records",
+				fmt.Sprintf("The name %s has a CNAME record and %d other records, but a name with a CNAME record may not have other records "+
+					"(RFC 1034, section 3.6.2). Remove the CNAME record or the other records of the name.", name, len(others[name])),
+			)
+		}
+	}
+
+	return diags
+}
+
+// validateNullMXExclusivity checks a list of records of a zone for names with
+// both a NULLMX record and MX records. A NULLMX record declares that a domain
+// does not accept email, so it must be the only MX record of its name (RFC
+// 7505, section 3). Records with unknown names or types are skipped.
+func validateNullMXExclusivity(records types.List, recordsPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Indexes of the NULLMX and MX records by name.
+	nullMXs := map[string][]int{}
+	mxs := map[string][]int{}
+	for i, element := range records.Elements() {
+		key, recordType, ok := recordNameType(element)
+		if !ok {
+			continue
+		}
+
+		switch recordType {
+		case "NULLMX":
+			nullMXs[key] = append(nullMXs[key], i)
+		case "MX":
+			mxs[key] = append(mxs[key], i)
+		}
+	}
+
+	names := make([]string, 0, len(nullMXs))
+	for name := range nullMXs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if len(mxs[name]) > 0 {
+			diags.AddAttributeError(
+				recordsPath.AtListIndex(nullMXs[name][0]).AtName("type"),
+				"NULLMX record conflicts with MX records",
+				fmt.Sprintf("The name %s has a NULLMX record and %d MX records, but a name with a NULLMX record, which declares that it "+
+					"does not accept email, may not have other MX records (RFC 7505, section 3). "+
+					"Remove the NULLMX record or the MX records of the name.", name, len(mxs[name])),
+			)
+		}
+	}
+
+	return diags
+}
+
+// validateNoSOARecords rejects SOA records in a list of records, as the SOA
+// record of a zone is maintained by hosting.de.
+func validateNoSOARecords(records types.List, recordsPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for i, element := range records.Elements() {
+		if _, recordType, ok := recordNameType(element); ok && recordType == "SOA" {
+			diags.AddAttributeError(
+				recordsPath.AtListIndex(i).AtName("type"),
+				"Protected hosting.de DNS record",
+				"The SOA record of a zone is maintained by hosting.de and cannot be managed by this resource.",
+			)
+		}
+	}
+	return diags
+}
+
+// recordNameType returns the lower case name without trailing dot and the
+// upper case type of a record of a list of records. It returns false if the
+// record, its name or its type is null or unknown.
+func recordNameType(element attr.Value) (string, string, bool) {
+	record, ok := element.(types.Object)
+	if !ok || record.IsNull() || record.IsUnknown() {
+		return "", "", false
+	}
+	name, nameOk := record.Attributes()["name"].(types.String)
+	recordType, typeOk := record.Attributes()["type"].(types.String)
+	if !nameOk || !typeOk || name.IsNull() || name.IsUnknown() || recordType.IsNull() || recordType.IsUnknown() {
+		return "", "", false
+	}
+
+	return strings.ToLower(strings.TrimSuffix(name.ValueString(), ".")), strings.ToUpper(recordType.ValueString()), true
+}
+
+// validateEncodedContent checks the encoding of the binary data of TLSA,
+// SSHFP and OPENPGPKEY records, and the escape sequences of quoted TXT and SPF
+// content.
+func validateEncodedContent(recordType, content string) error {
+	switch recordType {
+	case "TXT", "SPF":
+		if isQuotedContent(content) {
+			_, err := parseTXTContent(content)
+			return err
+		}
+	case "TLSA":
+		numbers, data, err := parseHexContent(content, "usage", "selector", "matching type")
+		if err != nil {
+			return err
+		}
+		return validateHashLength("matching type", numbers[2], tlsaDataLengths, data)
+	case "SSHFP":
+		numbers, data, err := parseHexContent(content, "algorithm", "fingerprint type")
+		if err != nil {
+			return err
+		}
+		return validateHashLength("fingerprint type", numbers[1], sshfpFingerprintLengths, data)
+	case "OPENPGPKEY":
+		_, err := decodeBase64(content)
+		return err
+	}
+	return nil
+}
+
+// validateTTL checks that a record TTL is within the bounds accepted by the API.
+func validateTTL(ttl int64) error {
+	if ttl < minRecordTTL || ttl > maxRecordTTL {
+		return fmt.Errorf("TTL must be between %d and %d seconds, got %d", minRecordTTL, maxRecordTTL, ttl)
+	}
+	return nil
+}
+
+// hostName matches a fully qualified host name, with an optional trailing dot.
+var hostName = regexp.MustCompile(`^([a-zA-Z0-9_]([a-zA-Z0-9_\-]{0,61}[a-zA-Z0-9_])?\.)+[a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?\.?$`)
+
+// validateRecordName checks a record name, which may be "@" for the zone
+// apex or relative to the zone.
+func validateRecordName(name string) error {
+	if name == "" || name == "." {
+		return fmt.Errorf("the name must not be empty, use \"@\" for the zone apex")
+	}
+	if name != "@" && strings.Contains(name, "@") {
+		return fmt.Errorf("%q contains \"@\", which may only be used on its own to refer to the zone apex", name)
+	}
+	if strings.Contains(name, "..") || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("%q contains an empty label", name)
+	}
+	if name == "@" {
+		return nil
+	}
+	for i, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if err := validateRecordNameLabel(label, i == 0); err != nil {
+			return fmt.Errorf("label %q of %q %w", label, name, err)
+		}
+	}
+	return nil
+}
+
+// validateRecordNameLabel checks the characters of a label of a record name:
+// letters, digits and hyphens, which must not start or end the label,
+// underscores as used in service labels such as _dmarc or _sip._tcp, and "*"
+// as the whole leftmost label of a wildcard name. Labels with other Unicode
+// characters must be valid internationalized labels.
+func validateRecordNameLabel(label string, leftmost bool) error {
+	if label == "*" {
+		if !leftmost {
+			return fmt.Errorf("is a wildcard, which is only allowed as the leftmost label, such as *.example.com")
+		}
+		return nil
+	}
+	if !isASCII(label) {
+		if _, err := idna.Lookup.ToASCII(label); err != nil {
+			return fmt.Errorf("is not a valid internationalized label: %w", err)
+		}
+		return nil
+	}
+	for i, char := range label {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case char == '-' && i > 0 && i < len(label)-1:
+		case char == '-':
+			return fmt.Errorf("starts or ends with a hyphen")
+		case char == '_':
+		case char == '*':
+			return fmt.Errorf("contains \"*\", which is only allowed as the whole leftmost label of a wildcard name, such as *.example.com")
+		default:
+			return fmt.Errorf("contains the invalid character %q at position %d, labels may only contain letters, digits, hyphens and underscores", char, i+1)
+		}
+	}
+	return nil
+}
+
+// validateRecordNameLength checks that each label of a record name fits into
+// 63 bytes and the whole name into 255 bytes in wire form, with
+// internationalized labels encoded as punycode. Names relative to the zone
+// only account for their own labels.
+func validateRecordNameLength(name string) error {
+	if name == "@" {
+		return nil
+	}
+	// The wire form ends with the empty root label.
+	wireLength := 1
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		encoded, err := idna.Punycode.ToASCII(label)
+		if err != nil {
+			return fmt.Errorf("label %q of %q cannot be encoded as punycode: %w", label, name, err)
+		}
+		if len(encoded) > maxLabelLength {
+			return fmt.Errorf("label %q of %q is %d bytes long in wire form, but DNS labels are limited to %d bytes",
+				encoded, name, len(encoded), maxLabelLength)
+		}
+		wireLength += 1 + len(encoded)
+	}
+	if wireLength > maxNameLength {
+		return fmt.Errorf("%q is %d bytes long in wire form, but DNS names are limited to %d bytes",
+			name, wireLength, maxNameLength)
+	}
+	return nil
+}
+
+// srvServiceLabel matches the service and protocol labels of SRV record names.
+var srvServiceLabel = regexp.MustCompile(`^_[a-zA-Z0-9]([a-zA-Z0-9\-]*[a-zA-Z0-9])?$`)
+
+// validateSRVName checks that an SRV record name starts with the service and
+// protocol labels, e.g. _sip._tcp.example.com (RFC 2782).
+func validateSRVName(name string) error {
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 || !srvServiceLabel.MatchString(labels[0]) || !srvServiceLabel.MatchString(labels[1]) {
+		return fmt.Errorf("%q does not start with a service and a protocol label, such as _sip._tcp", name)
+	}
+	return nil
+}
+
+// txtSizeDiagnostics reports TXT content whose record data exceeds threshold
+// bytes, as an error in strict mode and as a warning otherwise. Responses
+// larger than 512 bytes do not fit into plain UDP DNS messages, so resolvers
+// retry over TCP or EDNS, which some networks block.
+func txtSizeDiagnostics(content string, threshold int, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	size := txtDataSize(content)
+	if threshold <= 0 || size <= threshold {
+		return diags
+	}
+
+	summary := "Large TXT record"
+	detail := fmt.Sprintf("The TXT record data has %d bytes, more than the txt_size_threshold of %d bytes. "+
+		"Large responses may force resolvers to retry over TCP and cause resolution failures on networks blocking it. "+
+		"Shorten the content, e.g. by splitting an SPF policy with include mechanisms.", size, threshold)
+	if strict {
+		diags.AddAttributeError(path.Root("content"), summary, detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("content"), summary, detail+" Set strict_validation = true in the provider configuration to treat this as an error.")
+	}
+	return diags
+}
+
+// srvNameDiagnostics reports an SRV record name not following the
+// _service._proto convention, as an error in strict mode and as a warning
+// otherwise.
+func srvNameDiagnostics(name string, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	err := validateSRVName(name)
+	if err == nil {
+		return diags
+	}
+
+	summary := "Unconventional SRV record name"
+	detail := "SRV records are looked up by names of the form _service._proto.name, e.g. _sip._tcp.example.com, " +
+		"so clients will not discover this record: " + err.Error() + "."
+	if strict {
+		diags.AddAttributeError(path.Root("name"), summary, detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("name"), summary, detail+" Set strict_validation = true in the provider configuration to treat this as an error.")
+	}
+	return diags
+}
+
+// expandRecordName expands a record name like in a zone file: "@" is the zone
+// apex, names ending with a dot are absolute, and other names are relative to
+// the zone unless they already end with the zone name.
+func expandRecordName(name, zoneName string) string {
+	switch {
+	case name == "@":
+		return zoneName
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	case strings.EqualFold(name, zoneName) || strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(zoneName)):
+		return name
+	default:
+		return name + "." + zoneName
+	}
+}
+
+// isCNAMESelfReference reports whether a CNAME record points to its own name.
+// Names and targets that are not absolute are qualified with the zone name,
+// if known.
+func isCNAMESelfReference(name, target, zoneName string) bool {
+	qualify := func(name string) string {
+		if zoneName != "" {
+			name = expandRecordName(name, zoneName)
+		}
+		return strings.TrimSuffix(strings.TrimSpace(name), ".")
+	}
+	return strings.EqualFold(qualify(name), qualify(target))
+}
+
+// cnameSelfReferenceDiagnostics reports a CNAME record pointing to its own
+// name, which makes every lookup of the name loop.
+func cnameSelfReferenceDiagnostics(name, target, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if isCNAMESelfReference(name, target, zoneName) {
+		diags.AddAttributeError(
+			path.Root("content"),
+			"CNAME record points to itself",
+			"The CNAME record "+name+" has its own name "+strconv.Quote(target)+" as target, so resolving the name loops. "+
+				"Set content to the name the record should be an alias for.",
+		)
+	}
+	return diags
+}
+
+// validateALIAS checks the target of an ALIAS record. hosting.de resolves the
+// target at query time and answers with its A and AAAA records, so the target
+// must be a host name other than the record itself.
+func validateALIAS(name types.String, target string) error {
+	if net.ParseIP(target) != nil {
+		return fmt.Errorf("%q is an IP address, use an A or AAAA record instead", target)
+	}
+	encoded, err := aceHostName(target)
+	if err != nil {
+		return err
+	}
+	if !hostName.MatchString(encoded) {
+		return fmt.Errorf("%q is not a fully qualified host name", target)
+	}
+	if !name.IsNull() && !name.IsUnknown() &&
+		strings.EqualFold(strings.TrimSuffix(target, "."), strings.TrimSuffix(name.ValueString(), ".")) {
+		return fmt.Errorf("%q points to the record itself", target)
+	}
+	return nil
+}
+
+// spfMechanism matches SPF mechanisms with an optional qualifier.
+// https://www.rfc-editor.org/rfc/rfc7208#section-5
+var spfMechanism = regexp.MustCompile(`^[+\-~?]?(all|include:\S+|exists:\S+|a(:[^/\s]+)?(/\d{1,2})?(//\d{1,3})?|mx(:[^/\s]+)?(/\d{1,2})?(//\d{1,3})?|ptr(:\S+)?|ip4:[^/\s]+(/\d{1,2})?|ip6:[^/\s]+(/\d{1,3})?)$`)
+
+// spfModifier matches SPF modifiers like redirect= and exp=.
+// https://www.rfc-editor.org/rfc/rfc7208#section-6
+var spfModifier = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.\-]*=\S*$`)
+
+// isSPFContent reports whether the content of a TXT record is an SPF policy.
+func isSPFContent(content string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.Trim(content, `"`)), "v=spf1")
+}
+
+// validateSPF checks the syntax of an SPF policy, returning an error for the
+// first invalid term.
+func validateSPF(content string) error {
+	terms := strings.Fields(normalizeRecordContent(content))
+	if len(terms) == 0 || !strings.EqualFold(terms[0], "v=spf1") {
+		return fmt.Errorf("SPF policy must start with v=spf1")
+	}
+
+	for _, term := range terms[1:] {
+		lowerTerm := strings.ToLower(term)
+		if !spfMechanism.MatchString(lowerTerm) && !spfModifier.MatchString(term) {
+			return fmt.Errorf("invalid SPF term %q", term)
+		}
+
+		mechanism := strings.TrimLeft(lowerTerm, "+-~?")
+		if address, ok := strings.CutPrefix(mechanism, "ip4:"); ok {
+			address, _, _ = strings.Cut(address, "/")
+			if ip := net.ParseIP(address); ip == nil || ip.To4() == nil {
+				return fmt.Errorf("invalid IPv4 address in SPF term %q", term)
+			}
+		}
+		if address, ok := strings.CutPrefix(mechanism, "ip6:"); ok {
+			address, _, _ = strings.Cut(address, "/")
+			if ip := net.ParseIP(address); ip == nil || ip.To4() != nil {
+				return fmt.Errorf("invalid IPv6 address in SPF term %q", term)
+			}
+		}
+	}
+
+	return nil
+}
+
+// hostResolver looks up host names. It is satisfied by *net.Resolver and
+// replaced in tests.
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// resolvedTargetTypes are the record types whose content is a host name
+// checked by validate_targets_resolve.
+var resolvedTargetTypes = map[string]bool{
+	"CNAME": true,
+	"MX":    true,
+	"NS":    true,
+}
+
+// checkTargetResolves looks up the target host of MX, NS and CNAME records
+// and returns an error if it does not resolve. Other record types are not
+// checked.
+func (c *Client) checkTargetResolves(ctx context.Context, recordType, content string) error {
+	if !resolvedTargetTypes[recordType] {
+		return nil
+	}
+
+	target := strings.TrimSuffix(strings.TrimSpace(content), ".")
+	if target == "" {
+		return nil
+	}
+	if _, err := c.resolver.LookupHost(ctx, target); err != nil {
+		return fmt.Errorf("%s target %s does not resolve: %w", recordType, target, err)
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateSPF(t *testing.T) {
+	valid := []string{
+		"v=spf1 -all",
+		"v=spf1 mx a:mail.example.test ip4:192.0.2.0/24 ip6:2001:db8::/32 include:_spf.example.com ~all",
+		`"v=spf1 include:spf.protection.outlook.com -all"`,
+		"v=spf1 redirect=_spf.example.com",
+		"V=SPF1 ?MX -ALL",
+	}
+	for _, content := range valid {
+		if err := validateSPF(content); err != nil {
+			t.Errorf("%q: unexpected error: %s", content, err)
+		}
+	}
+
+	invalid := []string{
+		"spf1 -all",
+		"v=spf1 include -all",
+		"v=spf1 ip4:192.0.2.300 -all",
+		"v=spf1 ip6:192.0.2.1 -all",
+		"v=spf1 +foo -all",
+	}
+	for _, content := range invalid {
+		if err := validateSPF(content); err == nil {
+			t.Errorf("%q: expected error", content)
+		}
+	}
+}
+
+func TestValidateALIAS(t *testing.T) {
+	name := types.StringValue("example.test")
+
+	for _, target := range []string{"lb.example.net", "lb.example.net.", "_edge.cdn.example.net"} {
+		if err := validateALIAS(name, target); err != nil {
+			t.Errorf("%q: unexpected error: %s", target, err)
+		}
+	}
+
+	for _, target := range []string{"192.0.2.1", "2001:db8::1", "localhost", "lb example.net", "example.test."} {
+		if err := validateALIAS(name, target); err == nil {
+			t.Errorf("%q: expected error", target)
+		}
+	}
+
+	if err := validateALIAS(types.StringNull(), "example.test"); err != nil {
+		t.Errorf("expected self reference check to be skipped without a name, got %s", err)
+	}
+}
+
+// testResolver resolves the host names in its map and fails for others.
+type testResolver map[string][]string
+
+func (r testResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if addrs, ok := r[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func TestCheckTargetResolves(t *testing.T) {
+	client := NewClient(nil, nil, nil)
+	client.resolver = testResolver{"mail.example.test": {"192.0.2.1"}}
+	ctx := context.Background()
+
+	for _, record := range []struct{ recordType, content string }{
+		{"MX", "mail.example.test"},
+		{"CNAME", "mail.example.test."},
+		// Only host name targets are looked up.
+		{"TXT", "gmai.com"},
+		{"A", "192.0.2.1"},
+	} {
+		if err := client.checkTargetResolves(ctx, record.recordType, record.content); err != nil {
+			t.Errorf("%s %s: unexpected error: %v", record.recordType, record.content, err)
+		}
+	}
+
+	for _, recordType := range []string{"MX", "NS", "CNAME"} {
+		if err := client.checkTargetResolves(ctx, recordType, "gmai.com"); err == nil {
+			t.Errorf("%s: expected unresolvable target to be reported", recordType)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := client.checkTargetResolves(cancelled, "MX", "mail.example.test"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected lookup to respect the context, got %v", err)
+	}
+}
+
+func TestExpandRecordName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"@":                    "example.test",
+		"www":                  "www.example.test",
+		"_dmarc.mail":          "_dmarc.mail.example.test",
+		"www.example.test":     "www.example.test",
+		"example.test":         "example.test",
+		"www.other.test.":      "www.other.test",
+		"www.notexample.test2": "www.notexample.test2.example.test",
+	} {
+		if expanded := expandRecordName(name, "example.test"); expanded != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, expanded)
+		}
+	}
+
+	for _, name := range []string{
+		"@", "www", "www.example.test", "www.example.test.", "*", "*.example.test",
+		"_dmarc", "_sip._tcp.example.test", "_acme-challenge.www", "selector1._domainkey", "müller", "xn--mller-kva.example.test",
+	} {
+		if err := validateRecordName(name); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+	for name, expected := range map[string]string{
+		"":                  "must not be empty",
+		".":                 "must not be empty",
+		"www.@":             "contains \"@\"",
+		"www..example.test": "empty label",
+		".www":              "empty label",
+		"www example":       "invalid character ' ' at position 4",
+		"${var}.www":        "invalid character '$' at position 1",
+		"www,mail":          "invalid character ','",
+		"www-":              "starts or ends with a hyphen",
+		"-www":              "starts or ends with a hyphen",
+		"www.*.example":     "only allowed as the leftmost label",
+		"*www":              "whole leftmost label",
+		"mü ller":           "not a valid internationalized label",
+	} {
+		err := validateRecordName(name)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%q: expected an error containing %q, got %v", name, expected, err)
+		}
+	}
+}
+
+func TestRecordResourceSRVName(t *testing.T) {
+	values := func(name string) map[string]any {
+		return map[string]any{
+			"zone_id":  "zone-id",
+			"name":     name,
+			"type":     "SRV",
+			"content":  "10 5060 sip.example.test",
+			"priority": 10,
+		}
+	}
+
+	for _, name := range []string{"_sip._tcp.example.test", "_sip._tcp", "_xmpp-server._tcp.example.test."} {
+		if diags := validateRecordResourceConfig(t, values(name)); diags.HasError() || diags.WarningsCount() != 0 {
+			t.Errorf("%s: expected well-formed SRV name, got %v", name, diags)
+		}
+	}
+
+	for _, name := range []string{"sip.example.test", "_sip.example.test", "@", "_sip_tcp.example.test"} {
+		diags := validateRecordResourceConfig(t, values(name))
+		if diags.HasError() || diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Unconventional SRV record name" {
+			t.Errorf("%s: expected a warning for a malformed SRV name, got %v", name, diags)
+		}
+	}
+
+	// In strict mode, malformed names are errors.
+	client := NewClient(nil, nil, nil)
+	client.strictValidation = true
+	ctx := context.Background()
+	r := &recordResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	resp := &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+		Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values("sip.example.test")),
+	}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error for a malformed SRV name in strict mode, got %v", resp.Diagnostics)
+	}
+}
+
+func TestRecordResourceSRVContent(t *testing.T) {
+	values := func(content string) map[string]any {
+		return map[string]any{
+			"zone_id":  "zone-id",
+			"name":     "_sip._tcp.example.test",
+			"type":     "SRV",
+			"content":  content,
+			"priority": 10,
+		}
+	}
+
+	for _, content := range []string{"10 5060 sip.example.test.", "0 5060 sip.example.test", "65535 0 _sip.example.test", "0 0 ."} {
+		if diags := validateRecordResourceConfig(t, values(content)); diags.HasError() {
+			t.Errorf("%s: expected well-formed SRV content, got %v", content, diags)
+		}
+	}
+
+	for _, content := range []string{
+		"10 sip.example.test",
+		"10 10 5060 sip.example.test",
+		"ten 5060 sip.example.test",
+		"10 65536 sip.example.test",
+		"-1 5060 sip.example.test",
+		"10 5060 sip..example.test",
+		"10 5060 sip",
+	} {
+		diags := validateRecordResourceConfig(t, values(content))
+		if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Invalid SRV content" {
+			t.Errorf("%s: expected invalid SRV content, got %v", content, diags)
+		}
+	}
+}
+
+func TestRecordResourceNullMX(t *testing.T) {
+	values := func(content string, priority any) map[string]any {
+		return map[string]any{
+			"zone_id":  "zone-id",
+			"name":     "example.test",
+			"type":     "NULLMX",
+			"content":  content,
+			"priority": priority,
+		}
+	}
+
+	for _, value := range []map[string]any{values(".", types.Int64Null()), values(".", 0)} {
+		if diags := validateRecordResourceConfig(t, value); diags.HasError() {
+			t.Errorf("expected well-formed NULLMX record, got %v", diags)
+		}
+	}
+
+	for _, test := range []struct {
+		values  map[string]any
+		summary string
+	}{
+		{values("mail.example.test", types.Int64Null()), "Invalid NULLMX content"},
+		{values("", types.Int64Null()), "Invalid NULLMX content"},
+		{values(".", 10), "Invalid NULLMX priority"},
+	} {
+		diags := validateRecordResourceConfig(t, test.values)
+		if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != test.summary {
+			t.Errorf("%v: expected %q, got %v", test.values, test.summary, diags)
+		}
+	}
+}
+
+func TestRecordResourceCAA(t *testing.T) {
+	values := func(content string) map[string]any {
+		return map[string]any{
+			"zone_id": "zone-id",
+			"name":    "example.test",
+			"type":    "CAA",
+			"content": content,
+		}
+	}
+
+	for _, content := range []string{
+		`0 issue "letsencrypt.org"`,
+		`128 issuewild ";"`,
+		`0 iodef "mailto:security@example.test"`,
+		`0 issue "ca.example.test; account=230123"`,
+	} {
+		if diags := validateRecordResourceConfig(t, values(content)); diags.HasError() {
+			t.Errorf("%s: expected well-formed CAA content, got %v", content, diags)
+		}
+	}
+
+	for _, content := range []string{
+		`issue "letsencrypt.org"`,
+		`256 issue "letsencrypt.org"`,
+		`-1 issue "letsencrypt.org"`,
+		`0 isue "letsencrypt.org"`,
+		`0 issue letsencrypt.org`,
+		`0 issue "letsencrypt.org`,
+		`0 issue`,
+	} {
+		diags := validateRecordResourceConfig(t, values(content))
+		if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Invalid CAA content" {
+			t.Errorf("%s: expected invalid CAA content, got %v", content, diags)
+		}
+	}
+}
@@ -1,47 +1,631 @@
 package hostingde
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
 )
 
+// defaultRecordsPageLimit is the number of records requested per page when
+// listing all records.
+const defaultRecordsPageLimit = 100
+
 // https://www.hosting.de/api/?json#list-recordconfigs
-func (d *Client) listRecords(findRequest RecordsFindRequest) (*RecordsFindResponse, error) {
-	uri := d.baseURL + "/recordsFind"
+func (d *Client) listRecords(ctx context.Context, findRequest RecordsFindRequest) (*RecordsFindResponse, error) {
+	uri := d.endpoint(serviceDNS, "recordsFind")
 
 	findResponse := &RecordsFindResponse{}
 
-	rawResp, err := d.doRequest(http.MethodPost, uri, findRequest, findResponse)
+	rawResp, err := d.doRequest(ctx, http.MethodPost, uri, findRequest, findResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(findResponse.Response.Data) == 0 {
-		return nil, fmt.Errorf("%v: %s", err, toErrorWithNewlines(uri, rawResp))
+	if findResponse.Status != "success" {
+		return findResponse, responseError(uri, rawResp, findResponse.BaseResponse)
+	}
+
+	return findResponse, nil
+}
+
+// getRecord returns the record with the given ID. It returns an error
+// wrapping ErrNotFound if the record does not exist, or if a record with
+// another ID is returned, e.g. from a stale cache.
+func (c *Client) getRecord(ctx context.Context, recordId string) (DNSRecord, error) {
+	findResponse, err := c.listRecords(ctx, RecordsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "RecordId",
+			Value: recordId,
+		}},
+		Limit: 1,
+		Page:  1,
+	})
+	if err != nil {
+		return DNSRecord{}, err
+	}
+	if len(findResponse.Response.Data) == 0 || findResponse.Response.Data[0].ID != recordId {
+		return DNSRecord{}, fmt.Errorf("record ID %s: %w", recordId, ErrNotFound)
+	}
+	return findResponse.Response.Data[0], nil
+}
+
+// getRecordTemplate returns the record template with the given ID. It
+// returns an error wrapping ErrNotFound if the template does not exist.
+// https://www.hosting.de/api/?json#listing-record-templates
+func (c *Client) getRecordTemplate(ctx context.Context, recordTemplateId string) (*RecordTemplate, error) {
+	uri := c.endpoint(serviceDNS, "recordTemplatesFind")
+
+	findResponse := &RecordTemplatesFindResponse{}
+	findRequest := RecordTemplatesFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "RecordTemplateId",
+			Value: recordTemplateId,
+		}},
+		Limit: 1,
+		Page:  1,
+	}
+
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest, findResponse)
+	if err != nil {
+		return nil, err
 	}
 
 	if findResponse.Status != "success" {
-		return findResponse, errors.New(toErrorWithNewlines(uri, rawResp))
+		return nil, responseError(uri, rawResp, findResponse.BaseResponse)
 	}
 
-	return findResponse, nil
+	return &findResponse.Response.Data[0], nil
+}
+
+// countRecords returns the number of records of a zone, listing a single
+// record only.
+func (c *Client) countRecords(ctx context.Context, zoneId string) (int, error) {
+	return c.countMatchingRecords(ctx, recordsFilter(zoneId, "", ""))
+}
+
+// countMatchingRecords returns the number of records matching the filter,
+// listing a single record only.
+func (c *Client) countMatchingRecords(ctx context.Context, filter FilterOrChain) (int, error) {
+	count, err := c.listRecords(ctx, RecordsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter:      filter,
+		Limit:       1,
+		Page:        1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count.Response.TotalEntries, nil
+}
+
+// recordLimitDetail describes an error caused by a zone having reached its
+// record limit, including the limit and the zone's current number of
+// records. It reports false for other errors.
+func (c *Client) recordLimitDetail(ctx context.Context, zoneId string, err error) (string, bool) {
+	var limitErr *recordLimitError
+	if !errors.As(err, &limitErr) {
+		return "", false
+	}
+
+	limit := "its record limit"
+	if limitErr.limit > 0 {
+		limit = fmt.Sprintf("its limit of %d records", limitErr.limit)
+	} else if c.zoneRecordLimit > 0 {
+		limit = fmt.Sprintf("its limit of %d records", c.zoneRecordLimit)
+	}
+	current := ""
+	if count, countErr := c.countRecords(ctx, zoneId); countErr == nil {
+		current = fmt.Sprintf(" and currently has %d records", count)
+	}
+
+	return fmt.Sprintf("The zone %s has reached %s%s. Remove unused records or split the zone before adding more.\n\n%s",
+		zoneId, limit, current, err.Error()), true
+}
+
+// recordLimitWarning returns a warning if the zone has reached 90% of the
+// configured record limit, or an empty string otherwise.
+func (c *Client) recordLimitWarning(ctx context.Context, zoneId string) string {
+	count, err := c.countRecords(ctx, zoneId)
+	if err != nil || count*10 < c.zoneRecordLimit*9 {
+		return ""
+	}
+	return fmt.Sprintf("The zone %s has %d of at most %d records. Adding records may fail once the limit is reached.",
+		zoneId, count, c.zoneRecordLimit)
+}
+
+// listAllRecords returns the records of all pages matching the request. The
+// first page is fetched to learn the number of pages, then the remaining pages
+// are fetched in parallel, bounded by the configured page concurrency. Once a
+// page fails, no further pages are requested and pending requests are
+// cancelled.
+func (c *Client) listAllRecords(ctx context.Context, findRequest RecordsFindRequest) ([]DNSRecord, error) {
+	if findRequest.Limit == 0 {
+		findRequest.Limit = defaultRecordsPageLimit
+	}
+	findRequest.Page = 1
+
+	firstPage, err := c.listRecords(ctx, findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := firstPage.Response.TotalPages
+	if totalPages <= 1 {
+		return firstPage.Response.Data, nil
+	}
+
+	concurrency := c.pageConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	pages := make([][]DNSRecord, totalPages)
+	pages[0] = firstPage.Response.Data
+	errs := make([]error, totalPages)
+
+	pageCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	for page := 2; page <= totalPages && pageCtx.Err() == nil; page++ {
+		pageRequest := findRequest
+		pageRequest.Page = page
+		// Each request gets its own BaseRequest, as the client sets the
+		// auth token on it.
+		pageRequest.BaseRequest = c.newBaseRequest(ctx)
+		if findRequest.BaseRequest != nil {
+			*pageRequest.BaseRequest = *findRequest.BaseRequest
+		}
+
+		semaphore <- struct{}{}
+		if pageCtx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			pageResponse, err := c.listRecords(pageCtx, pageRequest)
+			if err != nil {
+				// Requests cancelled because of another page's error are
+				// not reported.
+				if pageCtx.Err() == nil || ctx.Err() != nil {
+					errs[pageRequest.Page-1] = fmt.Errorf("page %d: %w", pageRequest.Page, err)
+				}
+				cancel()
+				return
+			}
+			pages[pageRequest.Page-1] = pageResponse.Response.Data
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	// Pages not requested because the operation was cancelled are missing.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	records := make([]DNSRecord, 0, firstPage.Response.TotalEntries)
+	for _, page := range pages {
+		records = append(records, page...)
+	}
+
+	return records, nil
 }
 
 // https://www.hosting.de/api/?json#updating-records-in-a-zone
-func (c *Client) updateRecords(updateRequest RecordsUpdateRequest) (*RecordsUpdateResponse, error) {
-	uri := c.baseURL + "/recordsUpdate"
+func (c *Client) updateRecords(ctx context.Context, updateRequest RecordsUpdateRequest) (*RecordsUpdateResponse, error) {
+	uri := c.endpoint(serviceDNS, "recordsUpdate")
 
 	updateResponse := &RecordsUpdateResponse{}
 
-	rawResp, err := c.doRequest(http.MethodPost, uri, updateRequest, updateResponse)
+	c.invalidateZoneRecords(updateRequest.ZoneConfigId)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, updateRequest, updateResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	if updateResponse.Status != "success" && updateResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	if checkResponseErrors(updateResponse.BaseResponse) != nil {
+		return nil, responseError(uri, rawResp, updateResponse.BaseResponse)
+	}
+	c.changeArtifact.recordUpdate(updateRequest, updateResponse)
+
+	if updateResponse.Status == "pending" {
+		if _, err := c.waitZoneNotPending(ctx, updateRequest.ZoneConfigId); err != nil {
+			return nil, fmt.Errorf("waiting for records update: %w", err)
+		}
 	}
 
 	return updateResponse, nil
 }
+
+// partialUpdateError is returned if a records update split into several
+// requests fails after some of the requests were applied.
+type partialUpdateError struct {
+	applied int
+	total   int
+	err     error
+}
+
+func (e *partialUpdateError) Error() string {
+	return fmt.Sprintf("the update was split into batches and failed after %d of %d record changes were applied, "+
+		"the remaining changes were not applied: %s", e.applied, e.total, e.err)
+}
+
+func (e *partialUpdateError) Unwrap() error {
+	return e.err
+}
+
+// updateRecordsChunked sends a records update as several requests with at
+// most maxBatchSize record changes each. Deletions are sent first, followed
+// by modifications and additions, so that deleted records make room for
+// added ones. The returned response combines the records of all responses.
+func (c *Client) updateRecordsChunked(ctx context.Context, updateRequest RecordsUpdateRequest) (*RecordsUpdateResponse, error) {
+	total := len(updateRequest.RecordsToDelete) + len(updateRequest.RecordsToModify) + len(updateRequest.RecordsToAdd)
+	if c.maxBatchSize <= 0 || total <= c.maxBatchSize {
+		return c.updateRecords(ctx, updateRequest)
+	}
+
+	newChunk := func() RecordsUpdateRequest {
+		return RecordsUpdateRequest{
+			BaseRequest:  updateRequest.BaseRequest,
+			ZoneConfigId: updateRequest.ZoneConfigId,
+			ZoneName:     updateRequest.ZoneName,
+		}
+	}
+	var chunks []RecordsUpdateRequest
+	chunk, size := newChunk(), 0
+	for _, changes := range []struct {
+		records []DNSRecord
+		field   func(*RecordsUpdateRequest) *[]DNSRecord
+	}{
+		{updateRequest.RecordsToDelete, func(r *RecordsUpdateRequest) *[]DNSRecord { return &r.RecordsToDelete }},
+		{updateRequest.RecordsToModify, func(r *RecordsUpdateRequest) *[]DNSRecord { return &r.RecordsToModify }},
+		{updateRequest.RecordsToAdd, func(r *RecordsUpdateRequest) *[]DNSRecord { return &r.RecordsToAdd }},
+	} {
+		for _, record := range changes.records {
+			if size == c.maxBatchSize {
+				chunks = append(chunks, chunk)
+				chunk, size = newChunk(), 0
+			}
+			field := changes.field(&chunk)
+			*field = append(*field, record)
+			size++
+		}
+	}
+	chunks = append(chunks, chunk)
+
+	var combined *RecordsUpdateResponse
+	applied := 0
+	for _, chunk := range chunks {
+		chunkResponse, err := c.updateRecords(ctx, chunk)
+		if err != nil {
+			if applied == 0 {
+				return nil, err
+			}
+			return nil, &partialUpdateError{applied: applied, total: total, err: err}
+		}
+		applied += len(chunk.RecordsToDelete) + len(chunk.RecordsToModify) + len(chunk.RecordsToAdd)
+
+		// Records returned by later requests replace those of earlier ones.
+		if combined == nil {
+			combined = chunkResponse
+			continue
+		}
+		records := chunkResponse.Response.Records
+		for _, record := range combined.Response.Records {
+			if !slices.ContainsFunc(records, func(other DNSRecord) bool { return other.ID == record.ID }) {
+				records = append(records, record)
+			}
+		}
+		combined.BaseResponse = chunkResponse.BaseResponse
+		combined.Response = chunkResponse.Response
+		combined.Response.Records = records
+	}
+
+	return combined, nil
+}
+
+// recordsFilter returns a filter matching all records of a zone, optionally
+// restricted to a record name and type. Empty values are not filtered on.
+func recordsFilter(zoneId, name, recordType string) FilterOrChain {
+	filters := []Filter{{Field: "zoneConfigId", Value: zoneId}}
+	if name != "" {
+		filters = append(filters, Filter{Field: "recordName", Value: name})
+	}
+	if recordType != "" {
+		filters = append(filters, Filter{Field: "recordType", Value: recordType})
+	}
+
+	return FilterOrChain{
+		SubFilterConnective: "AND",
+		SubFilter:           filters,
+	}
+}
+
+// findRecordsByID returns the records with the given IDs, found with a single
+// filter chain. IDs of records that do not exist are skipped.
+func (c *Client) findRecordsByID(ctx context.Context, recordIds []string) ([]DNSRecord, error) {
+	filters := make([]Filter, 0, len(recordIds))
+	for _, recordId := range recordIds {
+		filters = append(filters, Filter{Field: "RecordId", Value: recordId})
+	}
+
+	return c.listAllRecords(ctx, RecordsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter: FilterOrChain{
+			SubFilterConnective: "OR",
+			SubFilter:           filters,
+		},
+	})
+}
+
+// findRecords returns all records of a zone with the given name and type.
+// If content is not empty, only records with matching content are returned.
+func (c *Client) findRecords(ctx context.Context, zoneId, name, recordType, content string) ([]DNSRecord, error) {
+	findRequest := RecordsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter:      recordsFilter(zoneId, name, recordType),
+	}
+
+	found, err := c.listAllRecords(ctx, findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	for _, record := range found {
+		if content != "" && record.Content != content && normalizeRecordContent(record.Content) != content {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// errRecordNotFound is returned if a created record cannot be found.
+var errRecordNotFound = fmt.Errorf("record %w", ErrNotFound)
+
+// findCreatedRecord finds a record right after it was created. As the API is
+// eventually consistent, an empty result is retried up to the configured
+// number of times before the record is reported as not found. Other errors
+// are returned immediately.
+func (c *Client) findCreatedRecord(ctx context.Context, record DNSRecord) (DNSRecord, error) {
+	for attempt := 0; ; attempt++ {
+		records, err := c.findRecords(ctx, record.ZoneID, record.Name, record.Type, record.Content)
+		if err != nil {
+			return DNSRecord{}, err
+		}
+		if len(records) > 0 {
+			return records[0], nil
+		}
+
+		if attempt >= c.readAfterWriteRetries {
+			return DNSRecord{}, fmt.Errorf("%w after %d attempts", errRecordNotFound, attempt+1)
+		}
+		if err := c.backoff(ctx, "created record not yet listed", c.readAfterWriteDelay); err != nil {
+			return DNSRecord{}, err
+		}
+	}
+}
+
+// ensureRecordsAbsent deletes all records matching name, type and (optionally)
+// content from a zone. It returns the deleted records, which is empty if no
+// matching record existed.
+func (c *Client) ensureRecordsAbsent(ctx context.Context, zoneId, name, recordType, content string) ([]DNSRecord, error) {
+	records, err := c.findRecords(ctx, zoneId, name, recordType, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	recordsToDelete := make([]DNSRecord, 0, len(records))
+	for _, record := range records {
+		recordsToDelete = append(recordsToDelete, DNSRecord{
+			ID:   record.ID,
+			Name: record.Name,
+			Type: record.Type,
+		})
+	}
+
+	updateRequest := RecordsUpdateRequest{
+		BaseRequest:     c.newBaseRequest(ctx),
+		ZoneConfigId:    zoneId,
+		RecordsToDelete: recordsToDelete,
+	}
+	c.logRecordMutations(ctx, updateRequest, records)
+	_, err = c.updateRecordsChunked(ctx, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// isZoneManagedRecord reports whether a record is maintained by the zone
+// itself rather than by the user: SOA records, NS records at the zone apex
+// and records created from a record template.
+func isZoneManagedRecord(record DNSRecord, zoneName string) bool {
+	switch {
+	case record.Type == "SOA":
+		return true
+	case record.Type == "NS" && record.Name == zoneName:
+		return true
+	case record.RecordTemplateID != "":
+		return true
+	}
+	return false
+}
+
+// errProtectedApexRecord is returned for records that define the authority
+// of a zone, which are maintained by hosting.de.
+var errProtectedApexRecord = errors.New("record defines the authority of the zone and is maintained by hosting.de")
+
+// isProtectedApexRecord reports whether a record must not be changed by bulk
+// updates: the SOA record and, unless manageApexNS is set, the NS records at
+// the zone apex.
+func isProtectedApexRecord(record DNSRecord, zoneName string, manageApexNS bool) bool {
+	switch record.Type {
+	case "SOA":
+		return true
+	case "NS":
+		return !manageApexNS && strings.EqualFold(strings.TrimSuffix(record.Name, "."), strings.TrimSuffix(zoneName, "."))
+	}
+	return false
+}
+
+// withoutProtectedApexRecords removes the protected apex records from the
+// current records of a bulk update, so they are never deleted. It returns an
+// error wrapping errProtectedApexRecord if any desired record is protected.
+// The zone name is only looked up if there are NS records to check.
+func (c *Client) withoutProtectedApexRecords(ctx context.Context, zoneId string, current, desired []DNSRecord, manageApexNS bool) ([]DNSRecord, error) {
+	isNS := func(record DNSRecord) bool { return record.Type == "NS" }
+	var zoneName string
+	if !manageApexNS && (slices.ContainsFunc(current, isNS) || slices.ContainsFunc(desired, isNS)) {
+		zoneConfig, err := c.getZoneConfig(ctx, zoneId)
+		if err != nil {
+			return nil, err
+		}
+		zoneName = zoneConfig.Name
+	}
+
+	for _, record := range desired {
+		if isProtectedApexRecord(record, zoneName, manageApexNS) {
+			return nil, fmt.Errorf("%s record %s: %w", record.Type, record.Name, errProtectedApexRecord)
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(current), func(record DNSRecord) bool {
+		return isProtectedApexRecord(record, zoneName, manageApexNS)
+	}), nil
+}
+
+// listUserRecords returns all records of a zone that are not managed by the
+// zone itself.
+func (c *Client) listUserRecords(ctx context.Context, zoneId string) ([]DNSRecord, error) {
+	records, err := c.listAllRecords(ctx, RecordsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter:      recordsFilter(zoneId, "", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The zone apex is the name of the SOA record.
+	var zoneName string
+	for _, record := range records {
+		if record.Type == "SOA" {
+			zoneName = record.Name
+			break
+		}
+	}
+
+	var userRecords []DNSRecord
+	for _, record := range records {
+		if !isZoneManagedRecord(record, zoneName) {
+			userRecords = append(userRecords, record)
+		}
+	}
+
+	return userRecords, nil
+}
+
+// modifyRecordsTTL sets the TTL of records to the value returned by ttlFor in
+// a single batch. Records for which ttlFor returns false, or whose TTL already
+// matches, are not modified. It returns the TTLs of the modified records
+// before the change, keyed by record ID.
+func (c *Client) modifyRecordsTTL(ctx context.Context, zoneId string, records []DNSRecord, ttlFor func(DNSRecord) (int, bool)) (map[string]int, error) {
+	previous := map[string]int{}
+	var recordsToModify []DNSRecord
+	for _, record := range records {
+		ttl, ok := ttlFor(record)
+		if !ok || record.TTL == ttl {
+			continue
+		}
+		previous[record.ID] = record.TTL
+		record.TTL = ttl
+		recordsToModify = append(recordsToModify, record)
+	}
+
+	if len(recordsToModify) == 0 {
+		return previous, nil
+	}
+
+	updateRequest := RecordsUpdateRequest{
+		BaseRequest:     c.newBaseRequest(ctx),
+		ZoneConfigId:    zoneId,
+		RecordsToModify: recordsToModify,
+	}
+	c.logRecordMutations(ctx, updateRequest, records)
+	_, err := c.updateRecordsChunked(ctx, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// findRecordsByContent returns all records of a zone with the given content,
+// compared in canonical form, optionally restricted to a name and type.
+func (c *Client) findRecordsByContent(ctx context.Context, zoneId, name, recordType, content string) ([]DNSRecord, error) {
+	found, err := c.findRecords(ctx, zoneId, name, recordType, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	for _, record := range found {
+		if sameRecordContent(record.Type, record.Content, content) {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// modifyRecordsContent sets the content of records to newContent in a single
+// batch. Records whose content already matches are not modified. It returns
+// the modified records with their content before the change.
+func (c *Client) modifyRecordsContent(ctx context.Context, zoneId string, records []DNSRecord, newContent string) ([]DNSRecord, error) {
+	var previous, recordsToModify []DNSRecord
+	for _, record := range records {
+		if sameRecordContent(record.Type, record.Content, newContent) {
+			continue
+		}
+		previous = append(previous, record)
+		record.Content = canonicalContent(record.Type, newContent)
+		recordsToModify = append(recordsToModify, record)
+	}
+
+	if len(recordsToModify) == 0 {
+		return nil, nil
+	}
+
+	updateRequest := RecordsUpdateRequest{
+		BaseRequest:     c.newBaseRequest(ctx),
+		ZoneConfigId:    zoneId,
+		RecordsToModify: recordsToModify,
+	}
+	c.logRecordMutations(ctx, updateRequest, records)
+	_, err := c.updateRecordsChunked(ctx, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
@@ -0,0 +1,158 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// recordsBatchPart is a single update request contributing to a batch,
+// together with its result. canceled is the done channel of the context of the
+// operation waiting for the result.
+type recordsBatchPart struct {
+	request  RecordsUpdateRequest
+	canceled <-chan struct{}
+	response *RecordsUpdateResponse
+	err      error
+}
+
+// recordsBatch collects the update requests for a zone submitted within the
+// batch window on behalf of the same account. done is closed once the results
+// of all parts are set. flushing is set once the batch is sent, from then on
+// parts are no longer added or removed. events collects the backoffs of the
+// batch's requests, which are reported to every operation contributing a part.
+type recordsBatch struct {
+	key      string
+	zoneId   string
+	parts    []*recordsBatchPart
+	flushing bool
+	events   *operationEvents
+	done     chan struct{}
+}
+
+// updateZoneRecords updates the records of a zone while holding the zone's
+// lock. If a batch window is configured, requests for the same zone submitted
+// within the window on behalf of the same account are coalesced into a single
+// API call.
+func (c *Client) updateZoneRecords(ctx context.Context, updateRequest RecordsUpdateRequest) (*RecordsUpdateResponse, error) {
+	if c.batchWindow <= 0 {
+		unlock, err := c.lockZone(ctx, updateRequest.ZoneConfigId)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
+		return c.updateRecords(ctx, updateRequest)
+	}
+
+	part := &recordsBatchPart{request: updateRequest, canceled: ctx.Done()}
+	key := updateRequest.ZoneConfigId + "/" + updateRequest.getAccountId()
+
+	c.batchesMu.Lock()
+	if c.batches == nil {
+		c.batches = map[string]*recordsBatch{}
+	}
+	batch, ok := c.batches[key]
+	if !ok {
+		batch = &recordsBatch{key: key, zoneId: updateRequest.ZoneConfigId, done: make(chan struct{})}
+		c.batches[key] = batch
+		time.AfterFunc(c.batchWindow, func() { c.flushRecordsBatch(batch) })
+	}
+	batch.parts = append(batch.parts, part)
+	c.batchesMu.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		// A part not sent yet is dropped. Once the batch is sent, its result
+		// is awaited, so changes applied are not lost to the caller.
+		c.batchesMu.Lock()
+		flushing := batch.flushing
+		if !flushing {
+			batch.parts = slices.DeleteFunc(batch.parts, func(p *recordsBatchPart) bool { return p == part })
+		}
+		c.batchesMu.Unlock()
+		if !flushing {
+			return nil, ctx.Err()
+		}
+		<-batch.done
+	}
+	operationEventsFrom(ctx).merge(batch.events)
+	return part.response, part.err
+}
+
+// flushRecordsBatch sends the requests of a batch as one API call, split by
+// the maximum batch size. The batch is shared by several operations, so it is
+// only canceled once the contexts of all of them are done. If the API rejects
+// the call and the batch has several parts, each part is sent on its own, so
+// that errors are reported for the requests causing them only.
+func (c *Client) flushRecordsBatch(batch *recordsBatch) {
+	defer close(batch.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, events := withOperationEvents(ctx)
+	batch.events = events
+
+	// Requests submitted from now on start a new batch.
+	c.batchesMu.Lock()
+	delete(c.batches, batch.key)
+	batch.flushing = true
+	c.batchesMu.Unlock()
+
+	if len(batch.parts) == 0 {
+		return
+	}
+	go func() {
+		for _, part := range batch.parts {
+			select {
+			case <-part.canceled:
+			case <-ctx.Done():
+				return
+			}
+		}
+		cancel()
+	}()
+
+	unlock, err := c.lockZone(ctx, batch.zoneId)
+	if err != nil {
+		for _, part := range batch.parts {
+			part.err = err
+		}
+		return
+	}
+	defer unlock()
+
+	if len(batch.parts) == 1 {
+		part := batch.parts[0]
+		part.response, part.err = c.updateRecordsChunked(ctx, part.request)
+		return
+	}
+
+	merged := RecordsUpdateRequest{
+		BaseRequest:  batch.parts[0].request.BaseRequest,
+		ZoneConfigId: batch.zoneId,
+	}
+	for _, part := range batch.parts {
+		merged.RecordsToAdd = append(merged.RecordsToAdd, part.request.RecordsToAdd...)
+		merged.RecordsToModify = append(merged.RecordsToModify, part.request.RecordsToModify...)
+		merged.RecordsToDelete = append(merged.RecordsToDelete, part.request.RecordsToDelete...)
+	}
+
+	// Parts must not be sent again unless the API rejected the call without
+	// applying any of the changes.
+	response, err := c.updateRecordsChunked(ctx, merged)
+	var rejected *rejectedError
+	var partialErr *partialUpdateError
+	if !errors.As(err, &rejected) || errors.As(err, &partialErr) {
+		for _, part := range batch.parts {
+			part.response, part.err = response, err
+		}
+		return
+	}
+
+	for _, part := range batch.parts {
+		part.response, part.err = c.updateRecordsChunked(ctx, part.request)
+	}
+}
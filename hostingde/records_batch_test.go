@@ -0,0 +1,293 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdateZoneRecordsBatched(t *testing.T) {
+	var mu sync.Mutex
+	var requests []RecordsUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			mu.Lock()
+			requests = append(requests, updateRequest)
+			mu.Unlock()
+
+			// Requests with invalid content fail as a whole.
+			for _, record := range updateRequest.RecordsToAdd {
+				if record.Content == "invalid" {
+					return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "error"}}
+				}
+			}
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.Records = updateRequest.RecordsToAdd
+			return updateResponse
+		},
+	})
+	client.batchWindow = 50 * time.Millisecond
+
+	update := func(zoneId, content string) (*RecordsUpdateResponse, error) {
+		return client.updateZoneRecords(context.Background(), RecordsUpdateRequest{
+			BaseRequest:  &BaseRequest{},
+			ZoneConfigId: zoneId,
+			RecordsToAdd: []DNSRecord{{Name: content + ".example.test", Type: "A", Content: content}},
+		})
+	}
+	run := func(updates map[string]string) map[string]error {
+		var wg sync.WaitGroup
+		var resultsMu sync.Mutex
+		results := map[string]error{}
+		for content, zoneId := range updates {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				response, err := update(zoneId, content)
+				if err == nil {
+					if _, ok := matchReturnedRecord(response.Response.Records, DNSRecord{Name: content + ".example.test", Type: "A", Content: content}); !ok {
+						t.Errorf("expected response to contain the record with content %s", content)
+					}
+				}
+				resultsMu.Lock()
+				results[content] = err
+				resultsMu.Unlock()
+			}()
+		}
+		wg.Wait()
+		return results
+	}
+
+	results := run(map[string]string{
+		"192.0.2.1": "zone-one",
+		"192.0.2.2": "zone-one",
+		"192.0.2.3": "zone-one",
+		"192.0.2.4": "zone-two",
+	})
+	for content, err := range results {
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", content, err)
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected one request per zone, got %d", len(requests))
+	}
+	for _, request := range requests {
+		if request.ZoneConfigId == "zone-one" && len(request.RecordsToAdd) != 3 {
+			t.Errorf("expected the records of zone-one to be coalesced, got %v", request.RecordsToAdd)
+		}
+	}
+
+	// A failing batch is split up, so only the invalid record reports an error.
+	requests = nil
+	results = run(map[string]string{
+		"192.0.2.5": "zone-one",
+		"invalid":   "zone-one",
+	})
+	if results["192.0.2.5"] != nil {
+		t.Errorf("expected valid record to be added, got %v", results["192.0.2.5"])
+	}
+	if results["invalid"] == nil {
+		t.Errorf("expected invalid record to report an error")
+	}
+	if len(requests) != 3 {
+		t.Errorf("expected the failed batch to be retried per record, got %d requests", len(requests))
+	}
+
+	// Updates on behalf of different accounts are sent separately.
+	requests = nil
+	var wg sync.WaitGroup
+	for _, accountId := range []string{"account-one", "account-two"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.updateZoneRecords(context.Background(), RecordsUpdateRequest{
+				BaseRequest:  &BaseRequest{AccountId: accountId},
+				ZoneConfigId: "zone-one",
+				RecordsToAdd: []DNSRecord{{Name: accountId + ".example.test", Type: "A", Content: "192.0.2.6"}},
+			}); err != nil {
+				t.Errorf("%s: unexpected error: %v", accountId, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(requests) != 2 {
+		t.Fatalf("expected one request per account, got %d", len(requests))
+	}
+	for _, request := range requests {
+		if len(request.RecordsToAdd) != 1 || request.RecordsToAdd[0].Name != request.AccountId+".example.test" {
+			t.Errorf("expected the record to be sent on behalf of its account, got %+v", request)
+		}
+	}
+}
+
+func TestUpdateZoneRecordsBatchedBackoff(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// The first attempt is rate limited.
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	client := NewClient(nil, &token, &server.URL)
+	client.batchWindow = 50 * time.Millisecond
+	client.rateLimitDelay = 10 * time.Millisecond
+
+	// The backoff of the shared request is reported to both operations.
+	var wg sync.WaitGroup
+	var events [2]*operationEvents
+	for i := range events {
+		var ctx context.Context
+		ctx, events[i] = withOperationEvents(context.Background())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.updateZoneRecords(ctx, RecordsUpdateRequest{BaseRequest: &BaseRequest{}, ZoneConfigId: "zone-id"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if requests != 2 {
+		t.Errorf("expected a single retried batch request, got %d requests", requests)
+	}
+	for i, operation := range events {
+		if summary := operation.backoffs(); summary.reasons["rate limited (HTTP 429)"] != 1 {
+			t.Errorf("operation %d: expected the batch's backoff, got %v", i, summary.reasons)
+		}
+	}
+}
+
+func TestUpdateZoneRecordsBatchedCancel(t *testing.T) {
+	var mu sync.Mutex
+	var requests []RecordsUpdateRequest
+	sent := make(chan struct{}, 1)
+	release := make(chan struct{})
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var updateRequest RecordsUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		requests = append(requests, updateRequest)
+		mu.Unlock()
+		select {
+		case sent <- struct{}{}:
+		default:
+		}
+		<-release
+
+		w.WriteHeader(status)
+		updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		updateResponse.Response.Records = updateRequest.RecordsToAdd
+		if err := json.NewEncoder(w).Encode(updateResponse); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	client := NewClient(nil, &token, &server.URL)
+	client.batchWindow = 50 * time.Millisecond
+	client.maxRetries = 0
+
+	type result struct {
+		response *RecordsUpdateResponse
+		err      error
+	}
+	update := func(ctx context.Context, content string) <-chan result {
+		results := make(chan result, 1)
+		go func() {
+			response, err := client.updateZoneRecords(ctx, RecordsUpdateRequest{
+				BaseRequest:  &BaseRequest{},
+				ZoneConfigId: "zone-id",
+				RecordsToAdd: []DNSRecord{{Name: "www.example.test", Type: "A", Content: content}},
+			})
+			results <- result{response, err}
+		}()
+		return results
+	}
+
+	// A part canceled before the batch is sent is dropped.
+	close(release)
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := update(ctx, "192.0.2.1")
+	kept := update(context.Background(), "192.0.2.2")
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if r := <-canceled; !errors.Is(r.err, context.Canceled) {
+		t.Errorf("expected the canceled part to fail, got %v", r.err)
+	}
+	if r := <-kept; r.err != nil {
+		t.Fatal(r.err)
+	}
+	if len(requests) != 1 || len(requests[0].RecordsToAdd) != 1 || requests[0].RecordsToAdd[0].Content != "192.0.2.2" {
+		t.Errorf("expected only the remaining part to be sent, got %+v", requests)
+	}
+
+	// Once the batch is sent, a canceled part awaits its result.
+	<-sent
+	requests = nil
+	release = make(chan struct{})
+	ctx, cancel = context.WithCancel(context.Background())
+	canceled = update(ctx, "192.0.2.3")
+	kept = update(context.Background(), "192.0.2.4")
+	<-sent
+	cancel()
+	close(release)
+	if r := <-canceled; r.err != nil || len(r.response.Response.Records) != 2 {
+		t.Errorf("expected the result of the sent batch, got %+v", r)
+	}
+	<-kept
+
+	// A batch waiting for the zone lock is canceled once all of its
+	// operations are.
+	unlock, err := client.lockZone(context.Background(), "zone-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	canceled = update(ctx, "192.0.2.7")
+	time.Sleep(2 * client.batchWindow)
+	cancel()
+	select {
+	case r := <-canceled:
+		if !errors.Is(r.err, context.Canceled) {
+			t.Errorf("expected the batch to be canceled, got %v", r.err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the batch to stop waiting for the zone lock")
+	}
+	unlock()
+
+	// A batch failing without being rejected by the API may have been
+	// applied, so its parts are not sent again.
+	requests = nil
+	status = http.StatusInternalServerError
+	first := update(context.Background(), "192.0.2.5")
+	second := update(context.Background(), "192.0.2.6")
+	for _, results := range []<-chan result{first, second} {
+		if r := <-results; r.err == nil {
+			t.Error("expected the server error to be reported for all parts")
+		}
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected a single request, got %d", len(requests))
+	}
+}
@@ -0,0 +1,644 @@
+package hostingde
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &recordsDataSource{}
+	_ datasource.DataSourceWithConfigure        = &recordsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &recordsDataSource{}
+	_ datasource.DataSourceWithValidateConfig   = &recordsDataSource{}
+)
+
+// NewRecordsDataSource is a helper function to simplify the provider implementation.
+func NewRecordsDataSource() datasource.DataSource {
+	return &recordsDataSource{}
+}
+
+// recordsDataSource is the data source implementation.
+type recordsDataSource struct {
+	client *Client
+}
+
+// recordsDataSourceModel maps the records data source schema data.
+type recordsDataSourceModel struct {
+	ZoneID           types.String             `tfsdk:"zone_id"`
+	IDs              []types.String           `tfsdk:"ids"`
+	Name             types.String             `tfsdk:"name"`
+	Type             types.String             `tfsdk:"type"`
+	ChangedAfter     types.String             `tfsdk:"changed_after"`
+	ChangedBefore    types.String             `tfsdk:"changed_before"`
+	FailOnEmpty      types.Bool               `tfsdk:"fail_on_empty"`
+	TerraformManaged types.Bool               `tfsdk:"terraform_managed"`
+	Sort             *recordsSortModel        `tfsdk:"sort"`
+	GroupRRsets      types.Bool               `tfsdk:"group_rrsets"`
+	Records          []recordDataSourceRecord `tfsdk:"records"`
+	RRsets           []recordsRRsetModel      `tfsdk:"rrsets"`
+}
+
+// recordsRRsetModel maps the records with the same name and type.
+type recordsRRsetModel struct {
+	Name   types.String   `tfsdk:"name"`
+	Type   types.String   `tfsdk:"type"`
+	TTL    types.Int64    `tfsdk:"ttl"`
+	Values []types.String `tfsdk:"values"`
+}
+
+// recordsSortModel maps the sort order of records in data source results.
+type recordsSortModel struct {
+	Fields     []types.String `tfsdk:"fields"`
+	Descending types.Bool     `tfsdk:"descending"`
+}
+
+// recordDataSourceRecord maps a single DNSRecord in data source results.
+type recordDataSourceRecord struct {
+	ID       types.String `tfsdk:"id"`
+	ZoneID   types.String `tfsdk:"zone_id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Comments types.String `tfsdk:"comments"`
+
+	LastChangeDate   types.String `tfsdk:"last_change_date"`
+	TerraformManaged types.Bool   `tfsdk:"terraform_managed"`
+}
+
+// newRecordDataSourceRecord maps an API record to its data source representation.
+func (c *Client) newRecordDataSourceRecord(record DNSRecord) recordDataSourceRecord {
+	comments, managed := splitCommentPrefix(c.commentPrefix, record.Comments)
+	return recordDataSourceRecord{
+		ID:       types.StringValue(record.ID),
+		ZoneID:   types.StringValue(record.ZoneID),
+		Name:     types.StringValue(record.Name),
+		Type:     types.StringValue(record.Type),
+		Content:  types.StringValue(c.normalizeContent(record.Type, record.Content)),
+		TTL:      types.Int64Value(int64(record.TTL)),
+		Priority: types.Int64Value(int64(record.Priority)),
+		Comments: types.StringValue(comments),
+
+		LastChangeDate:   types.StringValue(record.LastChangeDate),
+		TerraformManaged: types.BoolValue(managed),
+	}
+}
+
+// defaultRecordsSortFields is the order of records in data source results,
+// unless configured otherwise. The record ID is always compared last, so the
+// order is deterministic for records with identical attributes.
+var defaultRecordsSortFields = []string{"name", "type", "content"}
+
+// compareRecordsBy compares records by a single attribute.
+func compareRecordsBy(field string, a, b recordDataSourceRecord) int {
+	switch field {
+	case "name":
+		return cmp.Compare(a.Name.ValueString(), b.Name.ValueString())
+	case "type":
+		return cmp.Compare(a.Type.ValueString(), b.Type.ValueString())
+	case "content":
+		return cmp.Compare(a.Content.ValueString(), b.Content.ValueString())
+	case "ttl":
+		return cmp.Compare(a.TTL.ValueInt64(), b.TTL.ValueInt64())
+	case "priority":
+		return cmp.Compare(a.Priority.ValueInt64(), b.Priority.ValueInt64())
+	case "comments":
+		return cmp.Compare(a.Comments.ValueString(), b.Comments.ValueString())
+	case "last_change_date":
+		return parseChangeDate(a.LastChangeDate.ValueString()).Compare(parseChangeDate(b.LastChangeDate.ValueString()))
+	default:
+		return cmp.Compare(a.ID.ValueString(), b.ID.ValueString())
+	}
+}
+
+// parseChangeDate parses a last change date returned by the API. Dates that
+// cannot be parsed are zero, so they sort first.
+func parseChangeDate(date string) time.Time {
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// sortDataSourceRecords sorts records by the configured fields, followed by
+// the default fields and the record ID. A nil order sorts by the default
+// fields.
+func sortDataSourceRecords(records []recordDataSourceRecord, order *recordsSortModel) {
+	var fields []string
+	descending := false
+	if order != nil {
+		for _, field := range order.Fields {
+			fields = append(fields, field.ValueString())
+		}
+		descending = order.Descending.ValueBool()
+	}
+	fields = append(fields, defaultRecordsSortFields...)
+	fields = append(fields, "id")
+
+	slices.SortFunc(records, func(a, b recordDataSourceRecord) int {
+		for _, field := range fields {
+			if c := compareRecordsBy(field, a, b); c != 0 {
+				if descending {
+					return -c
+				}
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// recordsSortAttribute returns the schema attribute configuring the order of
+// records in data source results.
+func recordsSortAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Order of the listed records. By default, records are sorted by name, then type, then content, " +
+			"and finally by ID, so the order does not depend on the order returned by the API.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"fields": schema.ListAttribute{
+				Description: "Record attributes to sort by, in order of precedence. Valid fields are name, type, content, ttl, priority, comments, last_change_date, and id. " +
+					"Ties are broken by the default order.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf("name", "type", "content", "ttl", "priority", "comments", "last_change_date", "id")),
+				},
+			},
+			"descending": schema.BoolAttribute{
+				Description: "Sort in descending order. Defaults to false.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// rrsetValue returns the value of a record in an RRset: its content,
+// preceded by the priority for MX and SRV records, as in a zone file.
+func rrsetValue(record recordDataSourceRecord) string {
+	switch record.Type.ValueString() {
+	case "MX", "SRV":
+		return strconv.FormatInt(record.Priority.ValueInt64(), 10) + " " + record.Content.ValueString()
+	}
+	return record.Content.ValueString()
+}
+
+// groupRRsets groups records into RRsets by name and type, ordered by name
+// and type. The values of an RRset keep the order of the records. RRsets
+// whose records have different TTLs are reported as a warning and have the
+// lowest TTL, which resolvers may use for all records of the RRset.
+func groupRRsets(records []recordDataSourceRecord) ([]recordsRRsetModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rrsets := []recordsRRsetModel{}
+	index := map[string]int{}
+	ttls := map[string][]int64{}
+	for _, record := range records {
+		key := record.Name.ValueString() + "\x00" + record.Type.ValueString()
+		i, ok := index[key]
+		if !ok {
+			i = len(rrsets)
+			index[key] = i
+			rrsets = append(rrsets, recordsRRsetModel{
+				Name: record.Name,
+				Type: record.Type,
+				TTL:  record.TTL,
+			})
+		}
+		rrsets[i].Values = append(rrsets[i].Values, types.StringValue(rrsetValue(record)))
+		if !slices.Contains(ttls[key], record.TTL.ValueInt64()) {
+			ttls[key] = append(ttls[key], record.TTL.ValueInt64())
+		}
+		if record.TTL.ValueInt64() < rrsets[i].TTL.ValueInt64() {
+			rrsets[i].TTL = record.TTL
+		}
+	}
+
+	slices.SortStableFunc(rrsets, func(a, b recordsRRsetModel) int {
+		return cmp.Or(
+			cmp.Compare(a.Name.ValueString(), b.Name.ValueString()),
+			cmp.Compare(a.Type.ValueString(), b.Type.ValueString()),
+		)
+	})
+
+	for _, rrset := range rrsets {
+		rrsetTTLs := ttls[rrset.Name.ValueString()+"\x00"+rrset.Type.ValueString()]
+		if len(rrsetTTLs) < 2 {
+			continue
+		}
+		slices.Sort(rrsetTTLs)
+		values := make([]string, 0, len(rrsetTTLs))
+		for _, ttl := range rrsetTTLs {
+			values = append(values, strconv.FormatInt(ttl, 10))
+		}
+		diags.AddWarning(
+			"Inconsistent TTL in hosting.de DNS RRset",
+			fmt.Sprintf("The %s records of %s have different TTLs (%s). Resolvers may use the lowest TTL for all records "+
+				"of an RRset (RFC 2181, section 5.2), which is reported as the ttl of the RRset. Set the same TTL on all records.",
+				rrset.Type.ValueString(), rrset.Name.ValueString(), strings.Join(values, ", ")),
+		)
+	}
+
+	return rrsets, diags
+}
+
+// setRRsets groups the listed records into RRsets if group_rrsets is set,
+// leaving records empty.
+func (m *recordsDataSourceModel) setRRsets() diag.Diagnostics {
+	if !m.GroupRRsets.ValueBool() {
+		return nil
+	}
+	rrsets, diags := groupRRsets(m.Records)
+	m.RRsets = rrsets
+	m.Records = []recordDataSourceRecord{}
+	return diags
+}
+
+// recordDataSourceAttributes returns the schema attributes of a single record in data source results.
+func recordDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "DNS record ID",
+			Computed:    true,
+		},
+		"zone_id": schema.StringAttribute{
+			Description: "ID of DNS zone that the record belongs to.",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Name of the record.",
+			Computed:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "Type of the DNS record.",
+			Computed:    true,
+		},
+		"content": schema.StringAttribute{
+			Description: "Content of the DNS record.",
+			Computed:    true,
+		},
+		"ttl": schema.Int64Attribute{
+			Description: "TTL of the DNS record in seconds.",
+			Computed:    true,
+		},
+		"priority": schema.Int64Attribute{
+			Description: "Priority of MX and SRV records.",
+			Computed:    true,
+		},
+		"comments": schema.StringAttribute{
+			Description: "Comment to the record.",
+			Computed:    true,
+		},
+		"last_change_date": schema.StringAttribute{
+			Description: "Date of the last change of the record.",
+			Computed:    true,
+		},
+		"terraform_managed": schema.BoolAttribute{
+			Description: "Whether the record's comment carries the provider's comment_prefix marker.",
+			Computed:    true,
+		},
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *recordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_records"
+}
+
+// Schema defines the schema for the data source.
+func (d *recordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the DNS records of a zone, optionally filtered by name and type, or the records with the given IDs.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone to list the records of. Either zone_id or ids is required.",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "IDs of the records to list, looked up in a single request. Records that do not exist are skipped, " +
+					"unless fail_on_empty is set, which reports the missing IDs. Either zone_id or ids is required.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Only list records with this name. Example: mail.example.com. Cannot be combined with ids.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Only list records of this type. Cannot be combined with ids.",
+				Optional:    true,
+			},
+			"changed_after": schema.StringAttribute{
+				Description: "Only list records last changed at or after this time, in RFC 3339 format, e.g. 2024-01-02T15:04:05Z. " +
+					"Records of a change date range are sorted by change date, unless sort is set. Cannot be combined with ids.",
+				Optional: true,
+			},
+			"changed_before": schema.StringAttribute{
+				Description: "Only list records last changed before this time, in RFC 3339 format. Cannot be combined with ids.",
+				Optional:    true,
+			},
+			"terraform_managed": schema.BoolAttribute{
+				Description: "Only list records managed (true) or not managed (false) by Terraform, as indicated by the provider's comment_prefix.",
+				Optional:    true,
+			},
+			"fail_on_empty": schema.BoolAttribute{
+				Description: "Return an error if no record matches, or if any of the ids does not exist. Defaults to false, returning the found records.",
+				Optional:    true,
+			},
+			"sort": recordsSortAttribute(),
+			"group_rrsets": schema.BoolAttribute{
+				Description: "Return the matching records grouped into RRsets by name and type in rrsets, leaving records empty. " +
+					"RRsets whose records have different TTLs are reported as a warning. Defaults to false.",
+				Optional: true,
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "Matching DNS records.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: recordDataSourceAttributes(),
+				},
+			},
+			"rrsets": schema.ListNestedAttribute{
+				Description: "Matching DNS records grouped into RRsets, ordered by name and type, if group_rrsets is set.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the records.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the records.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "TTL of the RRset in seconds, the lowest TTL of its records.",
+							Computed:    true,
+						},
+						"values": schema.ListAttribute{
+							Description: "Contents of the records, in the order of records. The contents of MX and SRV records are preceded by their priority, " +
+								"e.g. 10 mail.example.com.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires exactly one of zone_id and ids. Records listed by
+// ID cannot be filtered by name, type and change date.
+func (d *recordsDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("zone_id"),
+			path.MatchRoot("ids"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("ids"),
+			path.MatchRoot("name"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("ids"),
+			path.MatchRoot("type"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("ids"),
+			path.MatchRoot("changed_after"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("ids"),
+			path.MatchRoot("changed_before"),
+		),
+	}
+}
+
+// ValidateConfig checks the change date bounds.
+func (d *recordsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config recordsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, _, diags := config.changeDateRange()
+	resp.Diagnostics.Append(diags...)
+}
+
+// changeDateRange returns the configured bounds of the last change date.
+// Bounds that are not set, or not known yet, are zero.
+func (m recordsDataSourceModel) changeDateRange() (after, before time.Time, diags diag.Diagnostics) {
+	parse := func(attribute string, value types.String) time.Time {
+		if value.IsNull() || value.IsUnknown() {
+			return time.Time{}
+		}
+		t, err := time.Parse(time.RFC3339, value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				"Invalid change date",
+				attribute+" must be a date in RFC 3339 format, e.g. 2024-01-02T15:04:05Z: "+err.Error(),
+			)
+		}
+		return t
+	}
+	after = parse("changed_after", m.ChangedAfter)
+	before = parse("changed_before", m.ChangedBefore)
+
+	if !after.IsZero() && !before.IsZero() && !after.Before(before) {
+		diags.AddAttributeError(
+			path.Root("changed_before"),
+			"Invalid change date range",
+			"changed_before must be later than changed_after.",
+		)
+	}
+	return after, before, diags
+}
+
+// changeDateFilter restricts a records filter chain to records last changed
+// in the given range. Zero bounds are not filtered.
+func changeDateFilter(filter FilterOrChain, after, before time.Time) FilterOrChain {
+	if !after.IsZero() {
+		filter.SubFilter = append(filter.SubFilter, Filter{
+			Field:    "recordLastChangeDate",
+			Value:    after.UTC().Format(time.RFC3339),
+			Relation: "greaterEqual",
+		})
+	}
+	if !before.IsZero() {
+		filter.SubFilter = append(filter.SubFilter, Filter{
+			Field:    "recordLastChangeDate",
+			Value:    before.UTC().Format(time.RFC3339),
+			Relation: "less",
+		})
+	}
+	return filter
+}
+
+// inChangeDateRange reports whether a record was last changed in the given
+// range. Records without a parsable change date are kept, as the API already
+// filtered them.
+func inChangeDateRange(record DNSRecord, after, before time.Time) bool {
+	changed := parseChangeDate(record.LastChangeDate)
+	if changed.IsZero() {
+		return true
+	}
+	return (after.IsZero() || !changed.Before(after)) && (before.IsZero() || changed.Before(before))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *recordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer d.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var config recordsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.IDs != nil {
+		d.readByID(ctx, &config, resp)
+		return
+	}
+
+	changedAfter, changedBefore, diags := config.changeDateRange()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordReq := RecordsFindRequest{
+		BaseRequest: d.client.newBaseRequest(ctx),
+		Filter: changeDateFilter(
+			recordsFilter(config.ZoneID.ValueString(), config.Name.ValueString(), config.Type.ValueString()),
+			changedAfter, changedBefore,
+		),
+	}
+
+	records, err := d.client.listAllRecords(ctx, recordReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records of zone ID "+config.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	config.Records = []recordDataSourceRecord{}
+	for _, record := range records {
+		if !inChangeDateRange(record, changedAfter, changedBefore) {
+			continue
+		}
+		dataSourceRecord := d.client.newRecordDataSourceRecord(record)
+		if !config.TerraformManaged.IsNull() && !dataSourceRecord.TerraformManaged.Equal(config.TerraformManaged) {
+			continue
+		}
+		config.Records = append(config.Records, dataSourceRecord)
+	}
+
+	order := config.Sort
+	if order == nil && (!changedAfter.IsZero() || !changedBefore.IsZero()) {
+		order = &recordsSortModel{Fields: []types.String{types.StringValue("last_change_date")}}
+	}
+	sortDataSourceRecords(config.Records, order)
+
+	if len(config.Records) == 0 && config.FailOnEmpty.ValueBool() {
+		resp.Diagnostics.AddError(
+			"No hosting.de DNS records found",
+			"No DNS records of zone ID "+config.ZoneID.ValueString()+" matched the given filters. "+
+				"Set fail_on_empty = false to return an empty list instead.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(config.setRRsets()...)
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// readByID lists the records with the configured IDs.
+func (d *recordsDataSource) readByID(ctx context.Context, config *recordsDataSourceModel, resp *datasource.ReadResponse) {
+	var recordIds []string
+	for _, id := range config.IDs {
+		if !slices.Contains(recordIds, id.ValueString()) {
+			recordIds = append(recordIds, id.ValueString())
+		}
+	}
+
+	records, err := d.client.findRecordsByID(ctx, recordIds)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records by ID: "+err.Error(),
+		)
+		return
+	}
+
+	config.Records = []recordDataSourceRecord{}
+	var missing []string
+	for _, recordId := range recordIds {
+		index := slices.IndexFunc(records, func(record DNSRecord) bool {
+			return record.ID == recordId
+		})
+		if index < 0 {
+			missing = append(missing, recordId)
+			continue
+		}
+		dataSourceRecord := d.client.newRecordDataSourceRecord(records[index])
+		if !config.TerraformManaged.IsNull() && !dataSourceRecord.TerraformManaged.Equal(config.TerraformManaged) {
+			continue
+		}
+		config.Records = append(config.Records, dataSourceRecord)
+	}
+
+	sortDataSourceRecords(config.Records, config.Sort)
+
+	if len(missing) > 0 && config.FailOnEmpty.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ids"),
+			"hosting.de DNS records not found",
+			"No DNS records with the IDs "+strings.Join(missing, ", ")+" exist. "+
+				"Set fail_on_empty = false to skip missing records instead.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(config.setRRsets()...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *recordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,352 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRecordsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example4.test"
+  type = "NATIVE"
+  email = "hostmaster@example4.test"
+}
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "test.example4.test"
+  type = "CNAME"
+  content = "www.example.com"
+}
+data "hostingde_records" "test" {
+  zone_id = hostingde_zone.test.id
+  name = hostingde_record.test.name
+  type = "CNAME"
+}
+data "hostingde_records" "empty" {
+  zone_id = hostingde_zone.test.id
+  name = "missing.example4.test"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hostingde_records.test", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.hostingde_records.test", "records.0.content", "www.example.com"),
+					resource.TestCheckResourceAttr("data.hostingde_records.empty", "records.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// readRecordsDataSource reads the records data source with the given configuration.
+func readRecordsDataSource(t *testing.T, client *Client, values map[string]any) (recordsDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := &recordsDataSource{client: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	req := datasource.ReadRequest{
+		Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values),
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+
+	var state recordsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	}
+
+	return state, resp.Diagnostics
+}
+
+// newRecordsFindClient returns a test client answering every find with the given records.
+func newRecordsFindClient(t *testing.T, records []DNSRecord) *Client {
+	t.Helper()
+
+	return newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = records
+			return findResponse
+		},
+	})
+}
+
+func TestRecordsDataSourceFailOnEmpty(t *testing.T) {
+	client := newRecordsFindClient(t, nil)
+
+	for _, failOnEmpty := range []bool{false, true} {
+		state, diags := readRecordsDataSource(t, client, map[string]any{
+			"zone_id":       "zone-id",
+			"fail_on_empty": failOnEmpty,
+		})
+
+		if diags.HasError() != failOnEmpty {
+			t.Fatalf("fail_on_empty = %t: unexpected diagnostics %v", failOnEmpty, diags)
+		}
+		if failOnEmpty {
+			continue
+		}
+		if state.Records == nil || len(state.Records) != 0 {
+			t.Errorf("expected empty record list, got %v", state.Records)
+		}
+	}
+}
+
+func TestRecordsDataSourceTerraformManaged(t *testing.T) {
+	client := newRecordsFindClient(t, []DNSRecord{
+		{ID: "managed", Name: "a.example.test", Type: "A", Content: "192.0.2.1", Comments: "[terraform] web"},
+		{ID: "manual", Name: "b.example.test", Type: "A", Content: "192.0.2.2", Comments: "web"},
+	})
+	client.commentPrefix = "[terraform] "
+
+	state, diags := readRecordsDataSource(t, client, map[string]any{"zone_id": "zone-id"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(state.Records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(state.Records))
+	}
+	for _, record := range state.Records {
+		if record.Comments.ValueString() != "web" {
+			t.Errorf("%s: expected comment without prefix, got %q", record.ID, record.Comments.ValueString())
+		}
+		if managed := record.ID.ValueString() == "managed"; record.TerraformManaged.ValueBool() != managed {
+			t.Errorf("%s: expected terraform_managed = %t", record.ID, managed)
+		}
+	}
+
+	for _, managed := range []bool{true, false} {
+		state, diags = readRecordsDataSource(t, client, map[string]any{
+			"zone_id":           "zone-id",
+			"terraform_managed": managed,
+		})
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if len(state.Records) != 1 || state.Records[0].TerraformManaged.ValueBool() != managed {
+			t.Errorf("terraform_managed = %t: unexpected records %v", managed, state.Records)
+		}
+	}
+}
+
+func TestRecordsDataSourceSort(t *testing.T) {
+	records := []DNSRecord{
+		{ID: "4", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "2", Name: "mail.example.test", Type: "A", Content: "192.0.2.2", TTL: 60},
+		{ID: "3", Name: "example.test", Type: "TXT", Content: "\"v=spf1 -all\"", TTL: 300},
+		{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+	}
+	reads := 0
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			// The API returns the records in a different order on every read.
+			reads++
+			data := append(slices.Clone(records[reads%len(records):]), records[:reads%len(records)]...)
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = data
+			return findResponse
+		},
+	})
+
+	ids := func(values map[string]any) string {
+		state, diags := readRecordsDataSource(t, client, values)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		var order []string
+		for _, record := range state.Records {
+			order = append(order, record.ID.ValueString())
+		}
+		return strings.Join(order, ",")
+	}
+
+	for range 3 {
+		if order := ids(map[string]any{"zone_id": "zone-id"}); order != "3,2,1,4" {
+			t.Errorf("expected records sorted by name, type, content and ID, got %s", order)
+		}
+	}
+
+	order := ids(map[string]any{
+		"zone_id": "zone-id",
+		"sort":    &recordsSortModel{Fields: []types.String{types.StringValue("ttl")}, Descending: types.BoolValue(true)},
+	})
+	if order != "4,1,3,2" {
+		t.Errorf("expected records sorted by descending TTL, got %s", order)
+	}
+}
+
+func TestRecordsDataSourceChangeDate(t *testing.T) {
+	var filters []Filter
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			filters = findRequest.Filter.SubFilter
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", LastChangeDate: "2024-01-02T12:00:00Z"},
+				{ID: "2", Name: "mail.example.test", Type: "A", Content: "192.0.2.2", LastChangeDate: "2024-01-02T08:00:00Z"},
+				{ID: "3", Name: "example.test", Type: "A", Content: "192.0.2.3", LastChangeDate: "2024-01-01T08:00:00Z"},
+			}
+			return findResponse
+		},
+	})
+
+	state, diags := readRecordsDataSource(t, client, map[string]any{
+		"zone_id":        "zone-id",
+		"changed_after":  "2024-01-02T01:00:00+01:00",
+		"changed_before": "2024-01-03T00:00:00Z",
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	expected := []Filter{
+		{Field: "zoneConfigId", Value: "zone-id"},
+		{Field: "recordLastChangeDate", Value: "2024-01-02T00:00:00Z", Relation: "greaterEqual"},
+		{Field: "recordLastChangeDate", Value: "2024-01-03T00:00:00Z", Relation: "less"},
+	}
+	if !slices.Equal(filters, expected) {
+		t.Errorf("expected change date filters %v, got %v", expected, filters)
+	}
+	var order []string
+	for _, record := range state.Records {
+		order = append(order, record.ID.ValueString())
+	}
+	if strings.Join(order, ",") != "2,1" {
+		t.Errorf("expected records in range sorted by change date, got %v", order)
+	}
+
+	for _, values := range []map[string]any{
+		{"zone_id": "zone-id", "changed_after": "yesterday"},
+		{"zone_id": "zone-id", "changed_after": "2024-01-03T00:00:00Z", "changed_before": "2024-01-02T00:00:00Z"},
+	} {
+		if _, diags := readRecordsDataSource(t, client, values); !diags.HasError() {
+			t.Errorf("expected an error for invalid change dates %v", values)
+		}
+	}
+}
+
+func TestRecordsDataSourceIDs(t *testing.T) {
+	stored := []DNSRecord{
+		{ID: "1", ZoneID: "zone-one", Name: "www.one.test", Type: "A", Content: "192.0.2.1"},
+		{ID: "2", ZoneID: "zone-two", Name: "www.two.test", Type: "A", Content: "192.0.2.2"},
+	}
+	requests := 0
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			requests++
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			if findRequest.Filter.SubFilterConnective != "OR" {
+				t.Errorf("expected an OR filter chain, got %+v", findRequest.Filter)
+			}
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			for _, filter := range findRequest.Filter.SubFilter {
+				for _, record := range stored {
+					if filter.Field == "RecordId" && filter.Value == record.ID {
+						findResponse.Response.Data = append(findResponse.Response.Data, record)
+					}
+				}
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+	})
+
+	ids := []string{"2", "1", "missing"}
+	state, diags := readRecordsDataSource(t, client, map[string]any{"ids": ids})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if requests != 1 {
+		t.Errorf("expected a single request for all IDs, got %d", requests)
+	}
+	if len(state.Records) != 2 || state.Records[0].ID.ValueString() != "1" || state.Records[1].ID.ValueString() != "2" {
+		t.Errorf("expected existing records to be listed, got %v", state.Records)
+	}
+
+	_, diags = readRecordsDataSource(t, client, map[string]any{"ids": ids, "fail_on_empty": true})
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "missing") {
+		t.Errorf("expected missing ID to be reported with fail_on_empty, got %v", diags)
+	}
+}
+
+func TestRecordsDataSourceGroupRRsets(t *testing.T) {
+	client := newRecordsFindClient(t, []DNSRecord{
+		{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		{ID: "2", Name: "example.test", Type: "MX", Content: "mail.example.test", TTL: 3600, Priority: 10},
+		{ID: "3", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "4", Name: "www.example.test", Type: "AAAA", Content: "2001:db8::1", TTL: 3600},
+		{ID: "5", Name: "www.example.test", Type: "A", Content: "192.0.2.3", TTL: 300},
+	})
+
+	state, diags := readRecordsDataSource(t, client, map[string]any{
+		"zone_id":      "zone-id",
+		"group_rrsets": true,
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(state.Records) != 0 {
+		t.Errorf("expected no flat records, got %v", state.Records)
+	}
+
+	var rrsets []string
+	for _, rrset := range state.RRsets {
+		var values []string
+		for _, value := range rrset.Values {
+			values = append(values, value.ValueString())
+		}
+		rrsets = append(rrsets, rrset.Name.ValueString()+" "+rrset.Type.ValueString()+" "+
+			rrset.TTL.String()+" ["+strings.Join(values, ", ")+"]")
+	}
+	expected := []string{
+		"example.test MX 3600 [10 mail.example.test]",
+		"www.example.test A 300 [192.0.2.1, 192.0.2.2, 192.0.2.3]",
+		"www.example.test AAAA 3600 [2001:db8::1]",
+	}
+	if !slices.Equal(rrsets, expected) {
+		t.Errorf("expected RRsets %v, got %v", expected, rrsets)
+	}
+
+	warnings := diags.Warnings()
+	if len(warnings) != 1 || warnings[0].Summary() != "Inconsistent TTL in hosting.de DNS RRset" ||
+		!strings.Contains(warnings[0].Detail(), "A records of www.example.test have different TTLs (300, 3600)") {
+		t.Errorf("expected an inconsistent TTL warning for the A records, got %v", warnings)
+	}
+}
@@ -0,0 +1,358 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEnsureRecordsAbsent(t *testing.T) {
+	legacyMX := DNSRecord{
+		ID:      "record-id",
+		ZoneID:  "zone-id",
+		Name:    "example.test",
+		Type:    "MX",
+		Content: "legacy.example.test",
+	}
+
+	var existing []DNSRecord
+	var deleted []DNSRecord
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = existing
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			deleted = append(deleted, updateRequest.RecordsToDelete...)
+			existing = nil
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+
+	// Initial apply removes the existing record.
+	existing = []DNSRecord{legacyMX}
+	records, err := client.ensureRecordsAbsent(context.Background(), "zone-id", "example.test", "MX", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || len(deleted) != 1 || deleted[0].ID != legacyMX.ID {
+		t.Fatalf("expected record %s to be deleted, got %v", legacyMX.ID, deleted)
+	}
+
+	// Repeated apply without a matching record does not issue a delete.
+	records, err = client.ensureRecordsAbsent(context.Background(), "zone-id", "example.test", "MX", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 || len(deleted) != 1 {
+		t.Fatalf("expected no further deletion, got %v", deleted)
+	}
+
+	// The record reappears and is deleted again.
+	existing = []DNSRecord{legacyMX}
+	records, err = client.ensureRecordsAbsent(context.Background(), "zone-id", "example.test", "MX", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || len(deleted) != 2 {
+		t.Fatalf("expected reappeared record to be deleted again, got %v", deleted)
+	}
+
+	// Records with other content are left untouched.
+	existing = []DNSRecord{legacyMX}
+	records, err = client.ensureRecordsAbsent(context.Background(), "zone-id", "example.test", "MX", "mail.example.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 || len(deleted) != 2 {
+		t.Fatalf("expected record with other content to be kept, got %v", deleted)
+	}
+}
+
+func TestListAllRecords(t *testing.T) {
+	const totalPages = 5
+	const limit = 2
+
+	var mu sync.Mutex
+	requestedPages := map[int]int{}
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			if findRequest.AuthToken == "" {
+				t.Errorf("page %d: missing auth token", findRequest.Page)
+			}
+
+			mu.Lock()
+			requestedPages[findRequest.Page]++
+			mu.Unlock()
+
+			// Answer later pages first to shuffle the arrival order.
+			time.Sleep(time.Duration(totalPages-findRequest.Page) * 5 * time.Millisecond)
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Limit = findRequest.Limit
+			findResponse.Response.Page = findRequest.Page
+			findResponse.Response.TotalPages = totalPages
+			findResponse.Response.TotalEntries = totalPages * limit
+			for i := 0; i < limit; i++ {
+				findResponse.Response.Data = append(findResponse.Response.Data, DNSRecord{
+					ID: fmt.Sprintf("record-%d", (findRequest.Page-1)*limit+i),
+				})
+			}
+			return findResponse
+		},
+	})
+	client.pageConcurrency = 2
+
+	records, err := client.listAllRecords(context.Background(), RecordsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter:      recordsFilter("zone-id", "", ""),
+		Limit:       limit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for page := 1; page <= totalPages; page++ {
+		if requestedPages[page] != 1 {
+			t.Errorf("expected page %d to be fetched once, got %d", page, requestedPages[page])
+		}
+	}
+	if len(records) != totalPages*limit {
+		t.Fatalf("expected %d records, got %d", totalPages*limit, len(records))
+	}
+	for i, record := range records {
+		if want := fmt.Sprintf("record-%d", i); record.ID != want {
+			t.Errorf("expected record %d to be %s, got %s", i, want, record.ID)
+		}
+	}
+}
+
+func TestListAllRecordsError(t *testing.T) {
+	var requestedPages []int
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			requestedPages = append(requestedPages, findRequest.Page)
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.TotalPages = 5
+			if findRequest.Page == 2 {
+				findResponse.Status = "error"
+				findResponse.Errors = []APIError{{Code: 10101, Text: "Internal error"}}
+			}
+			return findResponse
+		},
+	})
+	client.pageConcurrency = 1
+
+	_, err := client.listAllRecords(context.Background(), RecordsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter:      recordsFilter("zone-id", "", ""),
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "page 2: ") {
+		t.Errorf("expected an error of page 2, got %v", err)
+	}
+	if !slices.Equal(requestedPages, []int{1, 2}) {
+		t.Errorf("expected no pages to be requested after the error, got %v", requestedPages)
+	}
+}
+
+func TestModifyRecordsTTL(t *testing.T) {
+	existing := []DNSRecord{
+		{ID: "soa", Name: "example.test", Type: "SOA", Content: "ns1.example.test. hostmaster.example.test. 1 86400 7200 3600000 3600", TTL: 86400},
+		{ID: "ns", Name: "example.test", Type: "NS", Content: "ns1.example.test", TTL: 86400},
+		{ID: "template", Name: "example.test", Type: "MX", Content: "mx.example.test", TTL: 3600, RecordTemplateID: "template-id"},
+		{ID: "delegation", Name: "sub.example.test", Type: "NS", Content: "ns.other.test", TTL: 86400},
+		{ID: "www", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "low", Name: "low.example.test", Type: "A", Content: "192.0.2.2", TTL: 300},
+	}
+
+	var updates []RecordsUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = existing
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updates = append(updates, updateRequest)
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+
+	records, err := client.listUserRecords(context.Background(), "zone-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected zone managed records to be excluded, got %v", records)
+	}
+
+	previous, err := client.modifyRecordsTTL(context.Background(), "zone-id", records, func(DNSRecord) (int, bool) { return 300, true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected a single batch update, got %d", len(updates))
+	}
+	modified := updates[0].RecordsToModify
+	if len(modified) != 2 || modified[0].ID != "delegation" || modified[1].ID != "www" {
+		t.Fatalf("expected delegation and www records to be modified, got %v", modified)
+	}
+	for _, record := range modified {
+		if record.TTL != 300 {
+			t.Errorf("expected record %s to get TTL 300, got %d", record.ID, record.TTL)
+		}
+	}
+	if previous["delegation"] != 86400 || previous["www"] != 3600 || len(previous) != 2 {
+		t.Errorf("unexpected previous TTLs: %v", previous)
+	}
+
+	// Records already at the target TTL are not modified again.
+	_, err = client.modifyRecordsTTL(context.Background(), "zone-id", records[2:], func(DNSRecord) (int, bool) { return 300, true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected no further update, got %d", len(updates))
+	}
+}
+
+func TestFindCreatedRecord(t *testing.T) {
+	created := DNSRecord{ID: "record-id", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1"}
+
+	var attempts int
+	emptyAttempts := 2
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			attempts++
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.TotalPages = 1
+			if attempts > emptyAttempts {
+				findResponse.Response.Data = []DNSRecord{created}
+			}
+			return findResponse
+		},
+	})
+	client.readAfterWriteDelay = 0
+
+	// The record becomes visible after two empty results.
+	record, err := client.findCreatedRecord(context.Background(), created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.ID != created.ID || attempts != 3 {
+		t.Fatalf("expected record %s after 3 attempts, got %q after %d", created.ID, record.ID, attempts)
+	}
+
+	// A record that does not appear within the retries is not found.
+	attempts = 0
+	emptyAttempts = 10
+	_, err = client.findCreatedRecord(context.Background(), created)
+	if !errors.Is(err, errRecordNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if attempts != client.readAfterWriteRetries+1 {
+		t.Errorf("expected %d attempts, got %d", client.readAfterWriteRetries+1, attempts)
+	}
+}
+
+func TestUpdateRecordsChunked(t *testing.T) {
+	var requests []RecordsUpdateRequest
+	failAfter := -1
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			requests = append(requests, updateRequest)
+			if failAfter >= 0 && len(requests) > failAfter {
+				return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "error"}}
+			}
+
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			for _, record := range append(updateRequest.RecordsToModify, updateRequest.RecordsToAdd...) {
+				record.ID = record.Content
+				updateResponse.Response.Records = append(updateResponse.Response.Records, record)
+			}
+			return updateResponse
+		},
+	})
+	client.maxBatchSize = 2
+
+	updateRequest := RecordsUpdateRequest{
+		BaseRequest:     &BaseRequest{},
+		ZoneConfigId:    "zone-id",
+		RecordsToDelete: []DNSRecord{{ID: "deleted"}},
+		RecordsToModify: []DNSRecord{{ID: "modified", Content: "192.0.2.1"}},
+		RecordsToAdd: []DNSRecord{
+			{Content: "192.0.2.2"},
+			{Content: "192.0.2.3"},
+			{Content: "192.0.2.4"},
+		},
+	}
+
+	response, err := client.updateRecordsChunked(context.Background(), updateRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("expected 5 changes to be sent in 3 requests, got %d", len(requests))
+	}
+	for _, request := range requests {
+		if size := len(request.RecordsToDelete) + len(request.RecordsToModify) + len(request.RecordsToAdd); size > 2 {
+			t.Errorf("expected at most 2 changes per request, got %d", size)
+		}
+	}
+	if len(requests[0].RecordsToDelete) != 1 {
+		t.Errorf("expected deletions to be sent first, got %v", requests[0])
+	}
+	if len(response.Response.Records) != 4 {
+		t.Errorf("expected records of all responses to be combined, got %v", response.Response.Records)
+	}
+
+	// The third request fails after the first two were applied.
+	requests = nil
+	failAfter = 2
+	_, err = client.updateRecordsChunked(context.Background(), updateRequest)
+	var partialErr *partialUpdateError
+	if !errors.As(err, &partialErr) || partialErr.applied != 4 || partialErr.total != 5 {
+		t.Errorf("expected partial success to be reported, got %v", err)
+	}
+
+	// Nothing is applied if the first request fails.
+	requests = nil
+	failAfter = 0
+	_, err = client.updateRecordsChunked(context.Background(), updateRequest)
+	if err == nil || errors.As(err, &partialErr) {
+		t.Errorf("expected a plain error, got %v", err)
+	}
+}
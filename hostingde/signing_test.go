@@ -0,0 +1,73 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// recordingSigner records the bodies of signed requests and adds a header.
+type recordingSigner struct {
+	bodies [][]byte
+	err    error
+}
+
+func (s *recordingSigner) signRequest(req *http.Request, body []byte) error {
+	s.bodies = append(s.bodies, body)
+	req.Header.Set("X-Signature", "signature")
+	return s.err
+}
+
+func TestRequestSigner(t *testing.T) {
+	var received [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		received = append(received, body)
+		if r.Header.Get("X-Signature") != "signature" {
+			t.Errorf("expected the signature header, got %v", r.Header)
+		}
+		if err := json.NewEncoder(w).Encode(RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	client := NewClient(nil, &token, &server.URL)
+
+	// The default signer leaves requests unchanged.
+	if err := client.signer.signRequest(httptest.NewRequest(http.MethodPost, server.URL, nil), []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+
+	signer := &recordingSigner{}
+	client.signer = signer
+	if _, err := client.findRecords(context.Background(), "zone-id", "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(signer.bodies) != 1 || len(received) != 1 || string(signer.bodies[0]) != string(received[0]) {
+		t.Fatalf("expected the signer to be called with the sent body %s, got %s", received, signer.bodies)
+	}
+	var request RecordsFindRequest
+	if err := json.Unmarshal(signer.bodies[0], &request); err != nil {
+		t.Fatal(err)
+	}
+	if request.AuthToken != "test-token" {
+		t.Errorf("expected the body to be signed after setting the auth token, got %s", signer.bodies[0])
+	}
+
+	signer.err = errors.New("no key")
+	if _, err := client.findRecords(context.Background(), "zone-id", "", "", ""); err == nil {
+		t.Error("expected signing errors to fail the request")
+	}
+	if len(received) != 1 {
+		t.Errorf("expected requests failing to be signed not to be sent, got %d requests", len(received))
+	}
+}
@@ -0,0 +1,83 @@
+package hostingde
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &validateRecordFunction{}
+
+// NewValidateRecordFunction is a helper function to simplify the provider implementation.
+func NewValidateRecordFunction() function.Function {
+	return &validateRecordFunction{}
+}
+
+// validateRecordFunction validates a record configuration without calling the API.
+type validateRecordFunction struct{}
+
+// Metadata returns the function name.
+func (f *validateRecordFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_record"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *validateRecordFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate a DNS record configuration",
+		MarkdownDescription: "Validates a DNS record configuration offline, using the same per-type rules as the " +
+			"`hostingde_record` resource. Returns an empty string if the configuration is valid. Otherwise, " +
+			"returns one line per error in the format `<attribute>: <summary>: <detail>`. Warnings, like the " +
+			"deprecation of the SPF record type, are not reported.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "Type of the DNS record.",
+			},
+			function.StringParameter{
+				Name:        "content",
+				Description: "Content of the DNS record.",
+			},
+			function.Int64Parameter{
+				Name:           "ttl",
+				Description:    "TTL of the DNS record in seconds, or null to skip the TTL check.",
+				AllowNullValue: true,
+			},
+			function.Int64Parameter{
+				Name:           "priority",
+				Description:    "Priority of MX and SRV records, or null if not set.",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the record configuration and returns the formatted errors.
+func (f *validateRecordFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var record recordConfig
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &record.Type, &record.Content, &record.TTL, &record.Priority))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.StringValue(formatRecordDiagnostics(validateRecord(record)))))
+}
+
+// formatRecordDiagnostics renders the errors of diags one per line, prefixed
+// with the attribute they refer to.
+func formatRecordDiagnostics(diags diag.Diagnostics) string {
+	lines := make([]string, 0, diags.ErrorsCount())
+	for _, d := range diags.Errors() {
+		line := d.Summary() + ": " + d.Detail()
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			line = withPath.Path().String() + ": " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
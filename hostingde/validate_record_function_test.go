@@ -0,0 +1,75 @@
+package hostingde
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateRecordFunction(t *testing.T) {
+	tests := map[string]struct {
+		args []attr.Value
+		want []string
+	}{
+		"valid MX": {
+			args: []attr.Value{types.StringValue("MX"), types.StringValue("mail.example.test"), types.Int64Value(3600), types.Int64Value(10)},
+		},
+		"valid SPF with null TTL": {
+			args: []attr.Value{types.StringValue("SPF"), types.StringValue("v=spf1 mx -all"), types.Int64Null(), types.Int64Null()},
+		},
+		"missing priority": {
+			args: []attr.Value{types.StringValue("SRV"), types.StringValue("0 5060 sip.example.test"), types.Int64Null(), types.Int64Null()},
+			want: []string{"priority: Missing attribute: "},
+		},
+		"unexpected priority and invalid TTL": {
+			args: []attr.Value{types.StringValue("A"), types.StringValue("192.0.2.1"), types.Int64Value(30), types.Int64Value(10)},
+			want: []string{"ttl: Invalid TTL: ", "type: Unexpected combination of attributes: "},
+		},
+		"unsupported NAPTR": {
+			args: []attr.Value{types.StringValue("NAPTR"), types.StringValue(`100 10 "U" "E2U+sip" "!^.*$!sip:info@example.test!" .`), types.Int64Null(), types.Int64Null()},
+			want: []string{"type: Unsupported record type: The hosting.de API does not support NAPTR records"},
+		},
+		"unknown type": {
+			args: []attr.Value{types.StringValue("A6"), types.StringValue("0 2001:db8::1"), types.Int64Null(), types.Int64Null()},
+			want: []string{"type: Unsupported record type: "},
+		},
+		"invalid SPF policy": {
+			args: []attr.Value{types.StringValue("TXT"), types.StringValue("v=spf1 include -all"), types.Int64Null(), types.Int64Null()},
+			want: []string{"content: Invalid SPF policy: "},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			NewValidateRecordFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData(test.args),
+			}, resp)
+			if resp.Error != nil {
+				t.Fatalf("unexpected function error: %s", resp.Error)
+			}
+
+			result := resp.Result.Value().(types.String).ValueString()
+			if len(test.want) == 0 {
+				if result != "" {
+					t.Errorf("expected valid configuration, got %q", result)
+				}
+				return
+			}
+
+			lines := strings.Split(result, "\n")
+			if len(lines) != len(test.want) {
+				t.Fatalf("expected %d errors, got %q", len(test.want), result)
+			}
+			for i, prefix := range test.want {
+				if !strings.HasPrefix(lines[i], prefix) {
+					t.Errorf("expected error %d to start with %q, got %q", i, prefix, lines[i])
+				}
+			}
+		})
+	}
+}
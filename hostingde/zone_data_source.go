@@ -0,0 +1,208 @@
+package hostingde
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &zoneDataSource{}
+	_ datasource.DataSourceWithConfigure        = &zoneDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &zoneDataSource{}
+)
+
+// NewZoneDataSource is a helper function to simplify the provider implementation.
+func NewZoneDataSource() datasource.DataSource {
+	return &zoneDataSource{}
+}
+
+// zoneDataSource is the data source implementation.
+type zoneDataSource struct {
+	client *Client
+}
+
+// zoneDataSourceModel maps the zone data source schema data.
+type zoneDataSourceModel struct {
+	ID          types.String        `tfsdk:"id"`
+	Name        types.String        `tfsdk:"name"`
+	NameUnicode types.String        `tfsdk:"name_unicode"`
+	AccountID   types.String        `tfsdk:"account_id"`
+	Type        types.String        `tfsdk:"type"`
+	Status      types.String        `tfsdk:"status"`
+	DNSSecMode  types.String        `tfsdk:"dnssec_mode"`
+	SOAValues   *zoneSOAValuesModel `tfsdk:"soa_values"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a zone by name, e.g. to set the zone_id of records without hard-coding zone IDs. " +
+			"Use the hostingde_zone_full data source to also read the records of the zone.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the DNS zone.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Domain name of the DNS zone, e.g. example.com. Either name or name_unicode must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name_unicode": schema.StringAttribute{
+				Description: "Domain name of the DNS zone in unicode, e.g. bücher.example. Either name or name_unicode must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"account_id": schema.StringAttribute{
+				Description: "ID of the account that owns the zone. May be set to select one of several zones with the same name in sub-accounts.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the zone, NATIVE, MASTER or SLAVE.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the zone.",
+				Computed:    true,
+			},
+			"dnssec_mode": schema.StringAttribute{
+				Description: "DNSSEC mode of the zone, e.g. off or automatic.",
+				Computed:    true,
+			},
+			"soa_values": schema.SingleNestedAttribute{
+				Description: "Times in seconds used in the SOA record of the zone. Null if the zone has no SOA values.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"refresh": schema.Int64Attribute{
+						Description: "Refresh interval of secondary name servers.",
+						Computed:    true,
+					},
+					"retry": schema.Int64Attribute{
+						Description: "Retry interval of secondary name servers after a failed refresh.",
+						Computed:    true,
+					},
+					"expire": schema.Int64Attribute{
+						Description: "Time after which secondary name servers stop answering for the zone without a refresh.",
+						Computed:    true,
+					},
+					"ttl": schema.Int64Attribute{
+						Description: "TTL of the SOA record.",
+						Computed:    true,
+					},
+					"negative_ttl": schema.Int64Attribute{
+						Description: "TTL of negative answers.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires exactly one of name and name_unicode.
+func (d *zoneDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("name"),
+			path.MatchRoot("name_unicode"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer d.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var config zoneDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	field, name, namePath := "ZoneName", config.Name.ValueString(), path.Root("name")
+	if config.Name.IsNull() {
+		field, name, namePath = "ZoneNameUnicode", config.NameUnicode.ValueString(), path.Root("name_unicode")
+	}
+	matches, err := d.client.findZoneConfigsByNameField(ctx, field, name, config.AccountID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone "+name+": "+err.Error(),
+		)
+		return
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(
+			namePath,
+			"hosting.de DNS zone not found",
+			"No zone named "+name+" exists in the accounts accessible with the configured API key.",
+		)
+		return
+	case 1:
+	default:
+		var accountIds []string
+		for _, match := range matches {
+			accountIds = append(accountIds, match.AccountID)
+		}
+		resp.Diagnostics.AddAttributeError(
+			namePath,
+			"Ambiguous hosting.de DNS zone name",
+			"Found "+strconv.Itoa(len(matches))+" zones named "+name+" in the accounts "+strings.Join(accountIds, ", ")+". "+
+				"Set account_id to select one of them.",
+		)
+		return
+	}
+
+	zoneConfig := matches[0]
+	state := zoneDataSourceModel{
+		ID:          types.StringValue(zoneConfig.ID),
+		Name:        types.StringValue(zoneConfig.Name),
+		NameUnicode: types.StringValue(zoneConfig.NameUnicode),
+		AccountID:   types.StringValue(zoneConfig.AccountID),
+		Type:        types.StringValue(zoneConfig.Type),
+		Status:      types.StringValue(zoneConfig.Status),
+		DNSSecMode:  types.StringValue(zoneConfig.DNSSecMode),
+	}
+	if zoneConfig.SOAValues != nil {
+		state.SOAValues = &zoneSOAValuesModel{
+			Refresh:     types.Int64Value(int64(zoneConfig.SOAValues.Refresh)),
+			Retry:       types.Int64Value(int64(zoneConfig.SOAValues.Retry)),
+			Expire:      types.Int64Value(int64(zoneConfig.SOAValues.Expire)),
+			TTL:         types.Int64Value(int64(zoneConfig.SOAValues.TTL)),
+			NegativeTTL: types.Int64Value(int64(zoneConfig.SOAValues.NegativeTTL)),
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,112 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readZoneDataSource reads the zone data source with the given config values.
+func readZoneDataSource(t *testing.T, client *Client, values map[string]any) (zoneDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := &zoneDataSource{client: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	req := datasource.ReadRequest{
+		Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values),
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+
+	var state zoneDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	}
+
+	return state, resp.Diagnostics
+}
+
+func TestZoneDataSource(t *testing.T) {
+	zoneConfigs := []ZoneConfig{
+		{
+			ID: "zone-id", AccountID: "account-id", Name: "xn--bcher-kva.example", NameUnicode: "bücher.example",
+			Type: "NATIVE", Status: "active", DNSSecMode: "automatic",
+			SOAValues: &SOAValues{Refresh: 86400, Retry: 7200, Expire: 3600000, TTL: 3600, NegativeTTL: 900},
+		},
+		{ID: "sub-one", AccountID: "account-one", Name: "shared.example"},
+		{ID: "sub-two", AccountID: "account-two", Name: "shared.example"},
+	}
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(body []byte) any {
+			var findRequest ZoneConfigsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			filters := append([]Filter{findRequest.Filter.Filter}, findRequest.Filter.SubFilter...)
+
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+		zones:
+			for _, zoneConfig := range zoneConfigs {
+				for _, filter := range filters {
+					switch {
+					case filter.Field == "ZoneName" && filter.Value != zoneConfig.Name,
+						filter.Field == "ZoneNameUnicode" && filter.Value != zoneConfig.NameUnicode,
+						filter.Field == "AccountId" && filter.Value != zoneConfig.AccountID:
+						continue zones
+					}
+				}
+				findResponse.Response.Data = append(findResponse.Response.Data, zoneConfig)
+			}
+			return findResponse
+		},
+	})
+
+	for _, values := range []map[string]any{
+		{"name": "xn--bcher-kva.example"},
+		{"name_unicode": "bücher.example"},
+	} {
+		state, diags := readZoneDataSource(t, client, values)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if state.ID.ValueString() != "zone-id" || state.Name.ValueString() != "xn--bcher-kva.example" ||
+			state.Status.ValueString() != "active" || state.DNSSecMode.ValueString() != "automatic" {
+			t.Errorf("%v: expected the zone, got %+v", values, state)
+		}
+		if state.SOAValues == nil || state.SOAValues.TTL.ValueInt64() != 3600 {
+			t.Errorf("%v: expected the SOA values of the zone, got %+v", values, state.SOAValues)
+		}
+	}
+
+	// Zones with the same name in sub-accounts are selected by account ID.
+	if _, diags := readZoneDataSource(t, client, map[string]any{"name": "shared.example"}); !diags.HasError() ||
+		diags.Errors()[0].Summary() != "Ambiguous hosting.de DNS zone name" {
+		t.Errorf("expected an error for an ambiguous name, got %v", diags)
+	}
+	state, diags := readZoneDataSource(t, client, map[string]any{"name": "shared.example", "account_id": "account-two"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.ID.ValueString() != "sub-two" || state.SOAValues != nil {
+		t.Errorf("expected the zone of account-two without SOA values, got %+v", state)
+	}
+
+	if _, diags := readZoneDataSource(t, client, map[string]any{"name": "missing.example"}); !diags.HasError() {
+		t.Errorf("expected an error for a missing zone")
+	}
+}
@@ -0,0 +1,347 @@
+package hostingde
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &zoneFullDataSource{}
+	_ datasource.DataSourceWithConfigure        = &zoneFullDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &zoneFullDataSource{}
+)
+
+// NewZoneFullDataSource is a helper function to simplify the provider implementation.
+func NewZoneFullDataSource() datasource.DataSource {
+	return &zoneFullDataSource{}
+}
+
+// zoneFullDataSource is the data source implementation.
+type zoneFullDataSource struct {
+	client *Client
+}
+
+// Modes of handling several zones with the same name.
+const (
+	duplicateNamesError = "error"
+	duplicateNamesList  = "list"
+)
+
+// zoneFullDataSourceModel maps the zone_full data source schema data.
+type zoneFullDataSourceModel struct {
+	ID                    types.String             `tfsdk:"id"`
+	Name                  types.String             `tfsdk:"name"`
+	NameUnicode           types.String             `tfsdk:"name_unicode"`
+	Type                  types.String             `tfsdk:"type"`
+	Status                types.String             `tfsdk:"status"`
+	EMailAddress          types.String             `tfsdk:"email"`
+	AccountID             types.String             `tfsdk:"account_id"`
+	MasterIP              types.String             `tfsdk:"master_ip"`
+	DNSServerGroupID      types.String             `tfsdk:"dns_server_group_id"`
+	DNSSecMode            types.String             `tfsdk:"dnssec_mode"`
+	ZoneTransferWhitelist []types.String           `tfsdk:"zone_transfer_whitelist"`
+	LastChangeDate        types.String             `tfsdk:"last_change_date"`
+	SOAValues             *zoneSOAValuesModel      `tfsdk:"soa_values"`
+	Sort                  *recordsSortModel        `tfsdk:"sort"`
+	Records               []recordDataSourceRecord `tfsdk:"records"`
+	DuplicateNames        types.String             `tfsdk:"duplicate_names"`
+	MatchingZones         []zoneMatchModel         `tfsdk:"matching_zones"`
+}
+
+// zoneMatchModel maps a zone matching the name of the zone_full data source.
+type zoneMatchModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	AccountID types.String `tfsdk:"account_id"`
+}
+
+// zoneSOAValuesModel maps the SOA values of a zone.
+type zoneSOAValuesModel struct {
+	Refresh     types.Int64 `tfsdk:"refresh"`
+	Retry       types.Int64 `tfsdk:"retry"`
+	Expire      types.Int64 `tfsdk:"expire"`
+	TTL         types.Int64 `tfsdk:"ttl"`
+	NegativeTTL types.Int64 `tfsdk:"negative_ttl"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneFullDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_full"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneFullDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the complete zone object, the zone config and all records of a zone, by ID or name. " +
+			"Records are ordered by name, type, content and ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the DNS zone. Either id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Domain name of the DNS zone. Either id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name_unicode": schema.StringAttribute{
+				Description: "Domain name of the DNS zone in unicode.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the zone, NATIVE, MASTER or SLAVE.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the zone.",
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "Hostmaster email address of the zone.",
+				Computed:    true,
+			},
+			"account_id": schema.StringAttribute{
+				Description: "ID of the account that owns the zone. May be set to select one of several zones with the same name in sub-accounts.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"duplicate_names": schema.StringAttribute{
+				Description: "Handling of several zones with the same name, e.g. in sub-accounts of a reseller account, if the zone is read by name. " +
+					"error fails listing the account IDs of the zones, set account_id to select one of them. " +
+					"list lists the zones in matching_zones and leaves the other attributes unset. Defaults to error.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(duplicateNamesError, duplicateNamesList),
+				},
+			},
+			"matching_zones": schema.ListNestedAttribute{
+				Description: "Zones matching the name and account_id, or the zone with the given id.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the DNS zone.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Domain name of the DNS zone.",
+							Computed:    true,
+						},
+						"account_id": schema.StringAttribute{
+							Description: "ID of the account that owns the zone.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"master_ip": schema.StringAttribute{
+				Description: "IP address of the master name server of SLAVE zones.",
+				Computed:    true,
+			},
+			"dns_server_group_id": schema.StringAttribute{
+				Description: "ID of the DNS server group of the zone.",
+				Computed:    true,
+			},
+			"dnssec_mode": schema.StringAttribute{
+				Description: "DNSSEC mode of the zone, e.g. off or automatic.",
+				Computed:    true,
+			},
+			"zone_transfer_whitelist": schema.ListAttribute{
+				Description: "IP addresses allowed to transfer the zone.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"last_change_date": schema.StringAttribute{
+				Description: "Date of the last change of the zone.",
+				Computed:    true,
+			},
+			"soa_values": schema.SingleNestedAttribute{
+				Description: "Times in seconds used in the SOA record of the zone.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"refresh": schema.Int64Attribute{
+						Description: "Refresh interval of secondary name servers.",
+						Computed:    true,
+					},
+					"retry": schema.Int64Attribute{
+						Description: "Retry interval of secondary name servers after a failed refresh.",
+						Computed:    true,
+					},
+					"expire": schema.Int64Attribute{
+						Description: "Time after which secondary name servers stop answering for the zone without a refresh.",
+						Computed:    true,
+					},
+					"ttl": schema.Int64Attribute{
+						Description: "TTL of the SOA record.",
+						Computed:    true,
+					},
+					"negative_ttl": schema.Int64Attribute{
+						Description: "TTL of negative answers.",
+						Computed:    true,
+					},
+				},
+			},
+			"sort": recordsSortAttribute(),
+			"records": schema.ListNestedAttribute{
+				Description: "All DNS records of the zone.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: recordDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires exactly one of id and name.
+func (d *zoneFullDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneFullDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer d.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var config zoneFullDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := config.ID.ValueString()
+	var matches []ZoneConfig
+	if zoneId == "" {
+		var err error
+		matches, err = d.client.findZoneConfigsByName(ctx, config.Name.ValueString(), config.AccountID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS zone",
+				"Could not read hosting.de DNS zone "+config.Name.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		if len(matches) > 1 && config.DuplicateNames.ValueString() != duplicateNamesList {
+			var accountIds []string
+			for _, match := range matches {
+				accountIds = append(accountIds, match.AccountID)
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Ambiguous hosting.de DNS zone name",
+				"Found "+strconv.Itoa(len(matches))+" zones named "+config.Name.ValueString()+" in the accounts "+strings.Join(accountIds, ", ")+". "+
+					"Set account_id to select one of them, or set duplicate_names = \"list\" to list all of them.",
+			)
+			return
+		}
+		if len(matches) == 1 {
+			zoneId = matches[0].ID
+		}
+	}
+
+	// Several zones match in list mode, so only the matches are set.
+	state := zoneFullDataSourceModel{
+		Name:                  config.Name,
+		AccountID:             config.AccountID,
+		ZoneTransferWhitelist: []types.String{},
+		Records:               []recordDataSourceRecord{},
+	}
+	if zoneId != "" {
+		zone, err := d.client.getZone(ctx, zoneId, "")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS zone",
+				"Could not read hosting.de DNS zone "+zoneId+": "+err.Error(),
+			)
+			return
+		}
+		state = d.client.newZoneFullDataSourceModel(zone)
+		matches = []ZoneConfig{zone.ZoneConfig}
+	}
+
+	state.DuplicateNames = config.DuplicateNames
+	state.Sort = config.Sort
+	sortDataSourceRecords(state.Records, config.Sort)
+	state.MatchingZones = []zoneMatchModel{}
+	for _, match := range matches {
+		state.MatchingZones = append(state.MatchingZones, zoneMatchModel{
+			ID:        types.StringValue(match.ID),
+			Name:      types.StringValue(match.Name),
+			AccountID: types.StringValue(match.AccountID),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// newZoneFullDataSourceModel maps an API zone to the data source model.
+func (c *Client) newZoneFullDataSourceModel(zone *Zone) zoneFullDataSourceModel {
+	zoneConfig := zone.ZoneConfig
+	model := zoneFullDataSourceModel{
+		ID:                    types.StringValue(zoneConfig.ID),
+		Name:                  types.StringValue(zoneConfig.Name),
+		NameUnicode:           types.StringValue(zoneConfig.NameUnicode),
+		Type:                  types.StringValue(zoneConfig.Type),
+		Status:                types.StringValue(zoneConfig.Status),
+		EMailAddress:          types.StringValue(zoneConfig.EMailAddress),
+		AccountID:             types.StringValue(zoneConfig.AccountID),
+		MasterIP:              types.StringValue(zoneConfig.MasterIP),
+		DNSServerGroupID:      types.StringValue(zoneConfig.DNSServerGroupID),
+		DNSSecMode:            types.StringValue(zoneConfig.DNSSecMode),
+		ZoneTransferWhitelist: []types.String{},
+		LastChangeDate:        types.StringValue(zoneConfig.LastChangeDate),
+		Records:               []recordDataSourceRecord{},
+	}
+
+	for _, address := range zoneConfig.ZoneTransferWhitelist {
+		model.ZoneTransferWhitelist = append(model.ZoneTransferWhitelist, types.StringValue(address))
+	}
+
+	if zoneConfig.SOAValues != nil {
+		model.SOAValues = &zoneSOAValuesModel{
+			Refresh:     types.Int64Value(int64(zoneConfig.SOAValues.Refresh)),
+			Retry:       types.Int64Value(int64(zoneConfig.SOAValues.Retry)),
+			Expire:      types.Int64Value(int64(zoneConfig.SOAValues.Expire)),
+			TTL:         types.Int64Value(int64(zoneConfig.SOAValues.TTL)),
+			NegativeTTL: types.Int64Value(int64(zoneConfig.SOAValues.NegativeTTL)),
+		}
+	}
+
+	for _, record := range zone.Records {
+		model.Records = append(model.Records, c.newRecordDataSourceRecord(record))
+	}
+	sortDataSourceRecords(model.Records, nil)
+
+	return model
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneFullDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,227 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccZoneFullDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example5.test"
+  type = "NATIVE"
+  email = "hostmaster@example5.test"
+}
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "test.example5.test"
+  type = "CNAME"
+  content = "www.example.com"
+}
+data "hostingde_zone_full" "test" {
+  name = hostingde_zone.test.name
+  depends_on = [hostingde_record.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.hostingde_zone_full.test", "id", "hostingde_zone.test", "id"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_full.test", "type", "NATIVE"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.hostingde_zone_full.test", "records.*", map[string]string{
+						"name":    "test.example5.test",
+						"type":    "CNAME",
+						"content": "www.example.com",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestGetZone(t *testing.T) {
+	zoneRecords := []DNSRecord{
+		{ID: "2", Name: "www.example.test", Type: "A", Content: "192.0.2.1"},
+		{ID: "1", Name: "example.test", Type: "SOA", Content: "ns1.example.test."},
+	}
+	allRecords := append(zoneRecords, DNSRecord{ID: "3", Name: "example.test", Type: "MX", Content: "mail.example.test"})
+
+	var recordsRequests []RecordsFindRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zonesFind": func(body []byte) any {
+			var findRequest ZonesFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			if findRequest.Filter.Field != "ZoneName" || findRequest.Filter.Value != "example.test" {
+				t.Errorf("expected lookup by zone name, got %v", findRequest.Filter)
+			}
+
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []Zone{{
+				ZoneConfig: ZoneConfig{ID: "zone-id", Name: "example.test", Type: "NATIVE"},
+				Records:    zoneRecords,
+			}}
+			return findResponse
+		},
+		"recordsFind": func(body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			recordsRequests = append(recordsRequests, findRequest)
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.TotalEntries = len(allRecords)
+			findResponse.Response.TotalPages = 1
+			findResponse.Response.Data = allRecords
+			return findResponse
+		},
+	})
+
+	zone, err := client.getZone(context.Background(), "", "example.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordsRequests) != 2 || len(zone.Records) != len(allRecords) {
+		t.Fatalf("expected incomplete zone records to be listed separately, got %d records in %d requests", len(zone.Records), len(recordsRequests))
+	}
+
+	model := NewClient(nil, nil, nil).newZoneFullDataSourceModel(zone)
+	var order []string
+	for _, record := range model.Records {
+		order = append(order, record.ID.ValueString())
+	}
+	if len(order) != 3 || order[0] != "3" || order[1] != "1" || order[2] != "2" {
+		t.Errorf("expected records ordered by name and type, got %v", order)
+	}
+
+	// A complete zone object needs no further listing.
+	zoneRecords = allRecords
+	recordsRequests = nil
+	if _, err := client.getZone(context.Background(), "", "example.test"); err != nil {
+		t.Fatal(err)
+	}
+	if len(recordsRequests) != 1 {
+		t.Errorf("expected only the record count to be requested, got %d requests", len(recordsRequests))
+	}
+}
+
+// readZoneFullDataSource reads the zone_full data source with the given configuration.
+func readZoneFullDataSource(t *testing.T, client *Client, values map[string]any) (zoneFullDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := &zoneFullDataSource{client: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	req := datasource.ReadRequest{
+		Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values),
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+
+	var state zoneFullDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	}
+
+	return state, resp.Diagnostics
+}
+
+func TestZoneFullDataSourceDuplicateNames(t *testing.T) {
+	zoneConfigs := []ZoneConfig{
+		{ID: "zone-one", AccountID: "account-one", Name: "example.test", Type: "NATIVE"},
+		{ID: "zone-two", AccountID: "account-two", Name: "example.test", Type: "NATIVE"},
+	}
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(body []byte) any {
+			var findRequest ZoneConfigsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			accountId := ""
+			for _, filter := range findRequest.Filter.SubFilter {
+				if filter.Field == "AccountId" {
+					accountId = filter.Value
+				}
+			}
+
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			for _, zoneConfig := range zoneConfigs {
+				if accountId == "" || zoneConfig.AccountID == accountId {
+					findResponse.Response.Data = append(findResponse.Response.Data, zoneConfig)
+				}
+			}
+			return findResponse
+		},
+		"zonesFind": func(body []byte) any {
+			var findRequest ZonesFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatal(err)
+			}
+			if findRequest.Filter.Field != "ZoneConfigId" {
+				t.Errorf("expected lookup by zone ID, got %v", findRequest.Filter)
+			}
+
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			for _, zoneConfig := range zoneConfigs {
+				if zoneConfig.ID == findRequest.Filter.Value {
+					findResponse.Response.Data = []Zone{{ZoneConfig: zoneConfig}}
+				}
+			}
+			return findResponse
+		},
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+	})
+
+	_, diags := readZoneFullDataSource(t, client, map[string]any{"name": "example.test"})
+	if !diags.HasError() {
+		t.Fatal("expected error for duplicate zone names")
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "account-one") || !strings.Contains(detail, "account-two") {
+		t.Errorf("expected error to list the account IDs, got %q", detail)
+	}
+
+	state, diags := readZoneFullDataSource(t, client, map[string]any{"name": "example.test", "duplicate_names": "list"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !state.ID.IsNull() || len(state.MatchingZones) != 2 || state.MatchingZones[1].AccountID.ValueString() != "account-two" {
+		t.Errorf("expected only the matching zones to be set, got ID %s and %v", state.ID, state.MatchingZones)
+	}
+
+	state, diags = readZoneFullDataSource(t, client, map[string]any{"name": "example.test", "account_id": "account-two"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.ID.ValueString() != "zone-two" || len(state.MatchingZones) != 1 {
+		t.Errorf("expected the zone of account-two, got %s and %v", state.ID, state.MatchingZones)
+	}
+}
@@ -0,0 +1,301 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &zoneRecordsResource{}
+	_ resource.ResourceWithConfigure      = &zoneRecordsResource{}
+	_ resource.ResourceWithValidateConfig = &zoneRecordsResource{}
+)
+
+// NewZoneRecordsResource is a helper function to simplify the provider implementation.
+func NewZoneRecordsResource() resource.Resource {
+	return &zoneRecordsResource{}
+}
+
+// zoneRecordsResource manages all records of a zone with a single batch
+// update.
+type zoneRecordsResource struct {
+	client *Client
+}
+
+// zoneRecordsResourceModel maps the zone_records resource schema data.
+type zoneRecordsResourceModel struct {
+	ID      types.String           `tfsdk:"id"`
+	ZoneID  types.String           `tfsdk:"zone_id"`
+	Records []recordSetRecordModel `tfsdk:"records"`
+}
+
+// Metadata returns the resource type name.
+func (r *zoneRecordsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_records"
+}
+
+// Schema defines the schema for the resource.
+func (r *zoneRecordsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete set of records of a zone. " +
+			"On apply, the configured records are compared with the records currently in the zone, " +
+			"and all changes are sent with a single batch call: records are matched by name, type and content, " +
+			"matched records with other TTL, priority or comments are modified, and all other records of the zone are deleted. " +
+			"Records added outside of Terraform show up as changes on the next plan. " +
+			"The SOA record, the NS records at the zone apex and records created from a record template are maintained by hosting.de: " +
+			"they are never deleted by this resource, and configuring the SOA record or apex NS records is an error. " +
+			"Names with a CNAME record and other records, or with several CNAME records, are rejected when validating the configuration, " +
+			"as are names with a NULLMX record and MX records.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the resource, the zone ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of the zone whose records are managed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "All records of the zone.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: recordSetRecordAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that no name has a CNAME record together with other
+// records, or a NULLMX record together with MX records, and that no SOA
+// record is configured.
+func (r *zoneRecordsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var records types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("records"), &records)...)
+	if resp.Diagnostics.HasError() || records.IsNull() || records.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateCNAMECoexistence(records, path.Root("records"))...)
+	resp.Diagnostics.Append(validateNullMXExclusivity(records, path.Root("records"))...)
+	resp.Diagnostics.Append(validateNoSOARecords(records, path.Root("records"))...)
+}
+
+// Create replaces the records of the zone with the planned records.
+func (r *zoneRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Retrieve values from plan
+	var plan zoneRecordsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, ok := r.apply(ctx, &resp.Diagnostics, plan.ZoneID.ValueString(), plan.Records)
+	if !ok {
+		return
+	}
+
+	// Set state to the stored records
+	plan.ID = plan.ZoneID
+	plan.Records = records
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the records of the zone. Records
+// in state keep their position, records added outside of Terraform are
+// appended, so they are deleted by the next apply.
+func (r *zoneRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Get current state
+	var state zoneRecordsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.listUserRecords(ctx, state.ZoneID.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records of zone ID "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	var found []DNSRecord
+	var configured []recordSetRecordModel
+	for _, record := range state.Records {
+		index := slices.IndexFunc(stored, func(storedRecord DNSRecord) bool {
+			return storedRecord.ID == record.ID.ValueString()
+		})
+		if index < 0 {
+			continue
+		}
+		found = append(found, stored[index])
+		configured = append(configured, record)
+		stored = slices.Delete(stored, index, index+1)
+	}
+
+	// Set refreshed state
+	state.Records = r.client.newRecordSetRecords(append(found, stored...), configured)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update replaces the records of the zone with the planned records.
+func (r *zoneRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var plan zoneRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, ok := r.apply(ctx, &resp.Diagnostics, plan.ZoneID.ValueString(), plan.Records)
+	if !ok {
+		return
+	}
+
+	plan.Records = records
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the records in state. Records added to the zone since the
+// last refresh are kept.
+func (r *zoneRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Retrieve values from state
+	var state zoneRecordsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := state.ZoneID.ValueString()
+	unlock, err := r.client.lockZone(ctx, zoneId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Locking hosting.de DNS zone", err.Error())
+		return
+	}
+	defer unlock()
+
+	current, err := r.client.withoutProtectedApexRecords(ctx, zoneId, r.client.apiRecords(zoneId, state.Records), nil, false)
+	if err == nil {
+		_, _, err = r.client.applyRecordsRetrying(ctx, zoneId, current, nil, func(ctx context.Context) ([]DNSRecord, error) {
+			stored, err := r.client.listUserRecords(ctx, zoneId)
+			return managedRecords(stored, current, nil), err
+		})
+	}
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting records",
+			"Could not delete records of zone ID "+zoneId+", unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// apply turns the records currently in the zone into the planned records
+// with a single batch update and returns the records as stored. It reports
+// false if the update failed. The zone's lock is held from reading the
+// records until the update, so records added by other resources in between
+// are not deleted.
+func (r *zoneRecordsResource) apply(ctx context.Context, diags *diag.Diagnostics, zoneId string, planned []recordSetRecordModel) ([]recordSetRecordModel, bool) {
+	unlock, err := r.client.lockZone(ctx, zoneId)
+	if err != nil {
+		diags.AddError("Error Locking hosting.de DNS zone", err.Error())
+		return nil, false
+	}
+	defer unlock()
+
+	desired := r.client.apiRecords(zoneId, planned)
+	current, err := r.client.listUserRecords(ctx, zoneId)
+	if err == nil {
+		current, err = r.client.withoutProtectedApexRecords(ctx, zoneId, current, desired, false)
+	}
+
+	var applied []DNSRecord
+	var changes recordChanges
+	if err == nil {
+		applied, changes, err = r.client.applyRecordsRetrying(ctx, zoneId, current, desired, func(ctx context.Context) ([]DNSRecord, error) {
+			stored, err := r.client.listUserRecords(ctx, zoneId)
+			if err != nil {
+				return nil, err
+			}
+			return r.client.withoutProtectedApexRecords(ctx, zoneId, stored, desired, false)
+		})
+	}
+	if errors.Is(err, errProtectedApexRecord) {
+		diags.AddAttributeError(
+			path.Root("records"),
+			"Protected hosting.de DNS record",
+			"Could not update records of zone ID "+zoneId+": "+err.Error()+". Remove the record from the configuration.",
+		)
+		return nil, false
+	} else if detail, ok := r.client.recordLimitDetail(ctx, zoneId, err); ok {
+		diags.AddAttributeError(path.Root("records"), "hosting.de DNS zone record limit reached", detail)
+		return nil, false
+	} else if detail, ok := r.client.zoneRestorableErrorDetail(ctx, zoneId, err); ok {
+		diags.AddAttributeError(path.Root("zone_id"), "hosting.de DNS zone is deleted", detail)
+		return nil, false
+	} else if err != nil {
+		diags.AddError(
+			"Error updating records",
+			"Could not update records of zone ID "+zoneId+", unexpected error: "+err.Error(),
+		)
+		return nil, false
+	}
+
+	if r.client.changeSummary && changes != (recordChanges{}) {
+		diags.AddWarning(
+			"hosting.de DNS change summary",
+			fmt.Sprintf("Changed records in zone %s: %d added, %d modified, %d deleted",
+				zoneId, changes.added, changes.modified, changes.deleted),
+		)
+	}
+
+	return r.client.newRecordSetRecords(applied, planned), true
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *zoneRecordsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
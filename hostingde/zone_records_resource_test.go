@@ -0,0 +1,260 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestZoneRecordsResource(t *testing.T) {
+	stored := []DNSRecord{
+		{ID: "soa", ZoneID: "zone-id", Name: "example.test", Type: "SOA", Content: "ns1.example.test. hostmaster.example.test. 1 86400 7200 3600000 3600", TTL: 3600},
+		{ID: "ns", ZoneID: "zone-id", Name: "example.test", Type: "NS", Content: "ns1.example.test", TTL: 3600},
+		{ID: "www", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "old", ZoneID: "zone-id", Name: "old.example.test", Type: "A", Content: "192.0.2.9", TTL: 3600},
+	}
+	var updates []RecordsUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = stored
+			findResponse.Response.TotalEntries = len(stored)
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updates = append(updates, updateRequest)
+
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			for _, record := range updateRequest.RecordsToModify {
+				updateResponse.Response.Records = append(updateResponse.Response.Records, record)
+			}
+			for _, record := range updateRequest.RecordsToAdd {
+				record.ID = "new"
+				updateResponse.Response.Records = append(updateResponse.Response.Records, record)
+			}
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneRecordsResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	www := testRecordSetRecord("www.example.test", "A", "192.0.2.1")
+	www.TTL = types.Int64Value(300)
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":      types.StringUnknown(),
+		"zone_id": "zone-id",
+		"records": []recordSetRecordModel{www, testRecordSetRecord("mail.example.test", "A", "192.0.2.2")},
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	// All changes are sent in a single update, and the SOA and apex NS
+	// records are kept.
+	if len(updates) != 1 {
+		t.Fatalf("expected a single batch update, got %v", updates)
+	}
+	update := updates[0]
+	if len(update.RecordsToAdd) != 1 || update.RecordsToAdd[0].Name != "mail.example.test" {
+		t.Errorf("expected the mail record to be added, got %v", update.RecordsToAdd)
+	}
+	if len(update.RecordsToModify) != 1 || update.RecordsToModify[0].ID != "www" || update.RecordsToModify[0].TTL != 300 {
+		t.Errorf("expected the TTL of the www record to be modified, got %v", update.RecordsToModify)
+	}
+	if len(update.RecordsToDelete) != 1 || update.RecordsToDelete[0].ID != "old" {
+		t.Errorf("expected only the unconfigured record to be deleted, got %v", update.RecordsToDelete)
+	}
+
+	var state zoneRecordsResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if state.ID.ValueString() != "zone-id" || len(state.Records) != 2 ||
+		state.Records[0].ID.ValueString() != "www" || state.Records[1].ID.ValueString() != "new" {
+		t.Fatalf("expected the records to be stored with their IDs, got %v", state)
+	}
+
+	// Records added outside of Terraform are appended on refresh.
+	stored = []DNSRecord{
+		stored[0],
+		{ID: "new", ZoneID: "zone-id", Name: "mail.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		{ID: "stray", ZoneID: "zone-id", Name: "stray.example.test", Type: "A", Content: "192.0.2.3", TTL: 3600},
+	}
+	readResp := &fwresource.ReadResponse{State: resp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: resp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	state = zoneRecordsResourceModel{}
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+	if len(state.Records) != 2 || state.Records[0].ID.ValueString() != "new" || state.Records[1].ID.ValueString() != "stray" {
+		t.Errorf("expected the deleted record to be dropped and the stray record to be appended, got %v", state.Records)
+	}
+}
+
+func TestZoneRecordsResourceConflictRetry(t *testing.T) {
+	stored := []DNSRecord{
+		{ID: "soa", ZoneID: "zone-id", Name: "example.test", Type: "SOA", Content: "ns1.example.test. hostmaster.example.test. 1 86400 7200 3600000 3600", TTL: 3600},
+		{ID: "old", ZoneID: "zone-id", Name: "old.example.test", Type: "A", Content: "192.0.2.9", TTL: 3600},
+	}
+	var updates []RecordsUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = stored
+			findResponse.Response.TotalEntries = len(stored)
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updates = append(updates, updateRequest)
+
+			// The first update conflicts with the www record created
+			// concurrently.
+			if len(updates) == 1 {
+				stored = append(stored, DNSRecord{ID: "www", ZoneID: "zone-id", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600})
+				return RecordsUpdateResponse{BaseResponse: BaseResponse{
+					Status: "error",
+					Errors: []APIError{{Value: "conflict", Text: "The zone has been modified"}},
+				}}
+			}
+
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.Records = stored[2:]
+			return updateResponse
+		},
+		"zoneConfigsFind": func(_ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "zone-id", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+	client.rateLimitDelay = time.Millisecond
+
+	ctx := context.Background()
+	r := &zoneRecordsResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":      types.StringUnknown(),
+		"zone_id": "zone-id",
+		"records": []recordSetRecordModel{testRecordSetRecord("www.example.test", "A", "192.0.2.1")},
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	// The retry is computed from the zone as modified, so the concurrently
+	// created record is kept instead of being added again.
+	if len(updates) != 2 {
+		t.Fatalf("expected the update to be retried once, got %d updates", len(updates))
+	}
+	if retry := updates[1]; len(retry.RecordsToAdd) != 0 || len(retry.RecordsToDelete) != 1 || retry.RecordsToDelete[0].ID != "old" {
+		t.Errorf("expected the retry to only delete the old record, got %+v", retry)
+	}
+
+	// Updates still conflicting after conflict_retries attempts fail.
+	client.conflictRetries = 0
+	updates = nil
+	stored = stored[:2]
+	resp = &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if !resp.Diagnostics.HasError() || len(updates) != 1 {
+		t.Errorf("expected a single failed update, got %d updates and %v", len(updates), resp.Diagnostics)
+	}
+}
+
+func TestZoneRecordsResourceZoneLock(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			mu.Lock()
+			requests++
+			mu.Unlock()
+			return RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+		"recordsUpdate": func(body []byte) any {
+			mu.Lock()
+			requests++
+			mu.Unlock()
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updateResponse := RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			for _, record := range updateRequest.RecordsToAdd {
+				record.ID = "new"
+				updateResponse.Response.Records = append(updateResponse.Response.Records, record)
+			}
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneRecordsResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":      types.StringUnknown(),
+		"zone_id": "zone-id",
+		"records": []recordSetRecordModel{testRecordSetRecord("www.example.test", "A", "192.0.2.1")},
+	})
+
+	// The records are not even read while another update of the zone holds
+	// its lock.
+	unlock, err := client.lockZone(ctx, "zone-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan *fwresource.CreateResponse)
+	go func() {
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+		done <- resp
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("expected Create to wait for the zone lock")
+	case <-time.After(20 * time.Millisecond):
+	}
+	mu.Lock()
+	if requests != 0 {
+		t.Errorf("expected no requests while the zone is locked, got %d", requests)
+	}
+	mu.Unlock()
+
+	unlock()
+	if resp := <-done; resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 2 {
+		t.Errorf("expected the records to be read and updated once the lock is released, got %d requests", requests)
+	}
+}
@@ -2,13 +2,24 @@ package hostingde
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -16,6 +27,7 @@ var (
 	_ resource.Resource                = &zoneResource{}
 	_ resource.ResourceWithConfigure   = &zoneResource{}
 	_ resource.ResourceWithImportState = &zoneResource{}
+	_ resource.ResourceWithModifyPlan  = &zoneResource{}
 )
 
 // NewZoneResource is a helper function to simplify the provider implementation.
@@ -34,6 +46,166 @@ type zoneResourceModel struct {
 	Name         types.String `tfsdk:"name"`
 	Type         types.String `tfsdk:"type"`
 	EMailAddress types.String `tfsdk:"email"`
+	AccountID    types.String `tfsdk:"account_id"`
+
+	DNSServerGroupID types.String `tfsdk:"dns_server_group_id"`
+
+	MasterIP              types.String `tfsdk:"master_ip"`
+	ZoneTransferWhitelist types.List   `tfsdk:"zone_transfer_whitelist"`
+
+	DNSSecMode types.String `tfsdk:"dnssec_mode"`
+	SOAValues  types.Object `tfsdk:"soa_values"`
+}
+
+// Default SOA values of zones, in seconds, as used by hosting.de for new
+// zones.
+const (
+	defaultSOARefresh     = 86400
+	defaultSOARetry       = 7200
+	defaultSOAExpire      = 3600000
+	defaultSOATTL         = 172800
+	defaultSOANegativeTTL = 3600
+)
+
+// soaValuesAttributeTypes are the attribute types of soa_values.
+var soaValuesAttributeTypes = map[string]attr.Type{
+	"refresh":      types.Int64Type,
+	"retry":        types.Int64Type,
+	"expire":       types.Int64Type,
+	"ttl":          types.Int64Type,
+	"negative_ttl": types.Int64Type,
+}
+
+// soaValues returns the planned SOA values, or nil if they are not known.
+func (m *zoneResourceModel) soaValues(ctx context.Context) (*SOAValues, diag.Diagnostics) {
+	if m.SOAValues.IsNull() || m.SOAValues.IsUnknown() {
+		return nil, nil
+	}
+
+	var values zoneSOAValuesModel
+	diags := m.SOAValues.As(ctx, &values, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	return &SOAValues{
+		Refresh:     int(values.Refresh.ValueInt64()),
+		Retry:       int(values.Retry.ValueInt64()),
+		Expire:      int(values.Expire.ValueInt64()),
+		TTL:         int(values.TTL.ValueInt64()),
+		NegativeTTL: int(values.NegativeTTL.ValueInt64()),
+	}, nil
+}
+
+// setSOAValues sets the SOA values from a zone config. They are null if the
+// zone has no SOA values, e.g. a SLAVE zone.
+func (m *zoneResourceModel) setSOAValues(ctx context.Context, zoneConfig ZoneConfig) diag.Diagnostics {
+	if zoneConfig.SOAValues == nil {
+		m.SOAValues = types.ObjectNull(soaValuesAttributeTypes)
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	m.SOAValues, diags = types.ObjectValueFrom(ctx, soaValuesAttributeTypes, zoneSOAValuesModel{
+		Refresh:     types.Int64Value(int64(zoneConfig.SOAValues.Refresh)),
+		Retry:       types.Int64Value(int64(zoneConfig.SOAValues.Retry)),
+		Expire:      types.Int64Value(int64(zoneConfig.SOAValues.Expire)),
+		TTL:         types.Int64Value(int64(zoneConfig.SOAValues.TTL)),
+		NegativeTTL: types.Int64Value(int64(zoneConfig.SOAValues.NegativeTTL)),
+	})
+	return diags
+}
+
+// setTransferSettings sets the zone transfer attributes from a zone config.
+func (m *zoneResourceModel) setTransferSettings(ctx context.Context, zoneConfig ZoneConfig) diag.Diagnostics {
+	m.MasterIP = types.StringValue(zoneConfig.MasterIP)
+
+	whitelist := zoneConfig.ZoneTransferWhitelist
+	if whitelist == nil {
+		whitelist = []string{}
+	}
+	var diags diag.Diagnostics
+	m.ZoneTransferWhitelist, diags = types.ListValueFrom(ctx, types.StringType, whitelist)
+	return diags
+}
+
+// setDNSSecMode sets the DNSSEC mode from the zone config returned after
+// creating or updating the zone, and reports an error if the zone does not
+// have the planned mode. Zone configs without a mode keep the planned mode.
+func (m *zoneResourceModel) setDNSSecMode(diags *diag.Diagnostics, zoneConfig ZoneConfig) {
+	planned := m.DNSSecMode
+	if zoneConfig.DNSSecMode == "" && !planned.IsUnknown() {
+		return
+	}
+	m.DNSSecMode = stateDNSSecMode(zoneConfig.DNSSecMode)
+	if !planned.IsNull() && !planned.IsUnknown() && !planned.Equal(m.DNSSecMode) {
+		diags.AddAttributeError(
+			path.Root("dnssec_mode"),
+			"DNSSEC mode not applied",
+			fmt.Sprintf("Zone %s (ID %s) has DNSSEC mode %s instead of %s after the change. "+
+				"hosting.de may not support DNSSEC for the zone's top-level domain. Check the zone's DNSSEC settings in the hosting.de control panel.",
+				zoneConfig.Name, zoneConfig.ID, m.DNSSecMode.ValueString(), planned.ValueString()),
+		)
+	}
+}
+
+// stateDNSSecMode returns the DNSSEC mode stored in state for the mode
+// returned by the API, which is off if the API does not report a mode.
+func stateDNSSecMode(returned string) types.String {
+	if returned == "" {
+		return types.StringValue(dnsSecModeOff)
+	}
+	return types.StringValue(returned)
+}
+
+// transferWhitelist returns the configured zone transfer whitelist, or nil
+// if it is not known.
+func (m *zoneResourceModel) transferWhitelist(ctx context.Context) ([]string, diag.Diagnostics) {
+	if m.ZoneTransferWhitelist.IsNull() || m.ZoneTransferWhitelist.IsUnknown() {
+		return nil, nil
+	}
+
+	var whitelist []string
+	diags := m.ZoneTransferWhitelist.ElementsAs(ctx, &whitelist, false)
+	return whitelist, diags
+}
+
+// emailFromSOARName converts the SOA rname form of an email address, e.g.
+// hostmaster.example.test, to the address form, e.g. hostmaster@example.test.
+// Dots in the local part are escaped in the rname form, e.g. first\.last.
+// Addresses already in the address form are returned unchanged.
+func emailFromSOARName(rname string) string {
+	if rname == "" || strings.Contains(rname, "@") {
+		return rname
+	}
+	rname = strings.TrimSuffix(rname, ".")
+	for i := 0; i < len(rname); i++ {
+		switch rname[i] {
+		case '\\':
+			i++
+		case '.':
+			return strings.ReplaceAll(rname[:i], "\\.", ".") + "@" + rname[i+1:]
+		}
+	}
+	return rname
+}
+
+// sameEmailAddress reports whether two email addresses, each in address or
+// SOA rname form, are equal. Domains are compared case-insensitively.
+func sameEmailAddress(a, b string) bool {
+	a, b = emailFromSOARName(a), emailFromSOARName(b)
+	localA, domainA, _ := strings.Cut(a, "@")
+	localB, domainB, _ := strings.Cut(b, "@")
+	return localA == localB && strings.EqualFold(domainA, domainB)
+}
+
+// stateEmailAddress returns the email address stored in state for the
+// address returned by the API. The configured address is kept if it only
+// differs in its representation, so only actual changes show as drift.
+func stateEmailAddress(configured types.String, returned string) types.String {
+	if !configured.IsNull() && !configured.IsUnknown() && sameEmailAddress(configured.ValueString(), returned) {
+		return configured
+	}
+	return types.StringValue(emailFromSOARName(returned))
 }
 
 // Metadata returns the resource type name.
@@ -63,6 +235,115 @@ func (r *zoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"account_id": schema.StringAttribute{
+				Description: "ID of the account owning the zone. If set, the zone is created, updated and deleted on behalf of this account, " +
+					"which takes precedence over the provider's account_id. Otherwise the zone is created in the provider's account. " +
+					"Changing it replaces the zone.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dns_server_group_id": schema.StringAttribute{
+				Description: "ID of the DNS server group serving the zone. Include it in support requests about the zone.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"master_ip": schema.StringAttribute{
+				Description: "IP address of the primary name server to transfer the zone from. Only relevant if the type is SLAVE.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_transfer_whitelist": schema.ListAttribute{
+				Description: "IP addresses of secondary name servers allowed to transfer the zone, in addition to the hosting.de name servers. " +
+					"Only relevant if the type is NATIVE or MASTER.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dnssec_mode": schema.StringAttribute{
+				Description: "DNSSEC mode of the zone. Valid values are off and automatic, which signs the zone and manages its keys. " +
+					"Changing it updates the zone, and the apply fails if the zone does not have the mode afterwards. Defaults to the mode of the zone.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsSecModeOff, dnsSecModeAutomatic),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"soa_values": schema.SingleNestedAttribute{
+				Description: "Times in seconds used in the SOA record of the zone. Only relevant if the type is NATIVE or MASTER. " +
+					"If omitted, the zone keeps its SOA values, which are hosting.de's defaults for new zones. " +
+					"Values omitted in the block default to those of new zones.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"refresh": schema.Int64Attribute{
+						Description: "Refresh interval of secondary name servers, between 3600 (1 hour) and 2592000 (30 days). Defaults to 86400.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(defaultSOARefresh),
+						Validators: []validator.Int64{
+							int64validator.Between(3600, 2592000),
+						},
+					},
+					"retry": schema.Int64Attribute{
+						Description: "Retry interval of secondary name servers after a failed refresh, between 600 (10 minutes) and 2592000 (30 days). Defaults to 7200.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(defaultSOARetry),
+						Validators: []validator.Int64{
+							int64validator.Between(600, 2592000),
+						},
+					},
+					"expire": schema.Int64Attribute{
+						Description: "Time after which secondary name servers stop answering for the zone without a refresh, " +
+							"between 86400 (1 day) and 31556926 (1 year). Defaults to 3600000.",
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(defaultSOAExpire),
+						Validators: []validator.Int64{
+							int64validator.Between(86400, maxRecordTTL),
+						},
+					},
+					"ttl": schema.Int64Attribute{
+						Description: "TTL of the SOA record, between 60 and 31556926. Defaults to 172800.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(defaultSOATTL),
+						Validators: []validator.Int64{
+							int64validator.Between(minRecordTTL, maxRecordTTL),
+						},
+					},
+					"negative_ttl": schema.Int64Attribute{
+						Description: "TTL of negative answers, between 60 and 86400 (1 day). Defaults to 3600.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(defaultSOANegativeTTL),
+						Validators: []validator.Int64{
+							int64validator.Between(minRecordTTL, 86400),
+						},
+					},
+				},
+			},
 			"email": schema.StringAttribute{
 				Description: "The hostmaster email address. Only relevant if the type is NATIVE or MASTER. If the field is left empty, the default is hostmaster@name.",
 				Computed:    true,
@@ -78,6 +359,9 @@ func (r *zoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 
 // Create a new resource
 func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan zoneResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -85,6 +369,7 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withAccountID(ctx, plan.AccountID)
 
 	name := plan.Name.ValueString()
 	ztype := plan.Type.ValueString()
@@ -92,19 +377,31 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		ztype = "NATIVE"
 	}
 	email := plan.EMailAddress.ValueString()
+	whitelist, diags := plan.transferWhitelist(ctx)
+	resp.Diagnostics.Append(diags...)
+	soaValues, diags := plan.soaValues(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Generate API request body from plan
 	zoneReq := ZoneCreateRequest{
-		BaseRequest:             &BaseRequest{},
+		BaseRequest:             r.client.newBaseRequest(ctx),
 		UseDefaultNameserverSet: true,
 		ZoneConfig: ZoneConfig{
-			Name:         name,
-			Type:         ztype,
-			EMailAddress: email,
+			Name:                  name,
+			Type:                  ztype,
+			EMailAddress:          email,
+			MasterIP:              plan.MasterIP.ValueString(),
+			ZoneTransferWhitelist: whitelist,
+			DNSSecMode:            plan.DNSSecMode.ValueString(),
+			SOAValues:             soaValues,
 		},
 		Records: []DNSRecord{},
 	}
-	zone, err := r.client.createZone(zoneReq)
+	r.client.logZoneMutation(ctx, "Creating DNS zone", zoneReq.ZoneConfig)
+	zone, err := r.client.createZone(ctx, zoneReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating zone",
@@ -117,7 +414,12 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.ID = types.StringValue(zone.Response.ZoneConfig.ID)
 	plan.Name = types.StringValue(zone.Response.ZoneConfig.Name)
 	plan.Type = types.StringValue(zone.Response.ZoneConfig.Type)
-	plan.EMailAddress = types.StringValue(zone.Response.ZoneConfig.EMailAddress)
+	plan.EMailAddress = stateEmailAddress(plan.EMailAddress, zone.Response.ZoneConfig.EMailAddress)
+	plan.AccountID = types.StringValue(zone.Response.ZoneConfig.AccountID)
+	plan.DNSServerGroupID = types.StringValue(zone.Response.ZoneConfig.DNSServerGroupID)
+	resp.Diagnostics.Append(plan.setTransferSettings(ctx, zone.Response.ZoneConfig)...)
+	resp.Diagnostics.Append(plan.setSOAValues(ctx, zone.Response.ZoneConfig)...)
+	plan.setDNSSecMode(&resp.Diagnostics, zone.Response.ZoneConfig)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -127,8 +429,26 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 }
 
+// ModifyPlan warns if the configured account_id overrides the provider's
+// account_id.
+func (r *zoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var accountId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("account_id"), &accountId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.client.addAccountOverrideWarning(&resp.Diagnostics, path.Root("account_id"), accountId)
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
 	// Get current state
 	var state zoneResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -136,9 +456,10 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withAccountID(ctx, state.AccountID)
 
 	zoneReq := ZonesFindRequest{
-		BaseRequest: &BaseRequest{},
+		BaseRequest: r.client.newBaseRequest(ctx),
 		Filter: FilterOrChain{Filter: Filter{
 			Field: "ZoneConfigId",
 			Value: state.ID.ValueString(),
@@ -148,7 +469,7 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed zone value from hosting.de
-	zone, err := r.client.listZones(zoneReq)
+	zone, err := r.client.listZones(ctx, zoneReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading hosting.de DNS zone",
@@ -157,11 +478,18 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	checkAccountMove(&resp.Diagnostics, "zone", state.ID.ValueString(), state.AccountID.ValueString(), zone.Response.Data[0].ZoneConfig.AccountID)
+
 	// Overwrite items with refreshed state
 	state.ID = types.StringValue(zone.Response.Data[0].ZoneConfig.ID)
 	state.Name = types.StringValue(zone.Response.Data[0].ZoneConfig.Name)
 	state.Type = types.StringValue(zone.Response.Data[0].ZoneConfig.Type)
-	state.EMailAddress = types.StringValue(zone.Response.Data[0].ZoneConfig.EMailAddress)
+	state.EMailAddress = stateEmailAddress(state.EMailAddress, zone.Response.Data[0].ZoneConfig.EMailAddress)
+	state.AccountID = types.StringValue(zone.Response.Data[0].ZoneConfig.AccountID)
+	state.DNSServerGroupID = types.StringValue(zone.Response.Data[0].ZoneConfig.DNSServerGroupID)
+	resp.Diagnostics.Append(state.setTransferSettings(ctx, zone.Response.Data[0].ZoneConfig)...)
+	state.DNSSecMode = stateDNSSecMode(zone.Response.Data[0].ZoneConfig.DNSSecMode)
+	resp.Diagnostics.Append(state.setSOAValues(ctx, zone.Response.Data[0].ZoneConfig)...)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -173,6 +501,9 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan zoneResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -180,9 +511,10 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withAccountID(ctx, plan.AccountID)
 
 	zoneFindReq := ZonesFindRequest{
-		BaseRequest: &BaseRequest{},
+		BaseRequest: r.client.newBaseRequest(ctx),
 		Filter: FilterOrChain{Filter: Filter{
 			Field: "ZoneConfigId",
 			Value: plan.ID.ValueString(),
@@ -192,7 +524,7 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Get refreshed zone value from hosting.de
-	zoneFindResp, err := r.client.listZones(zoneFindReq)
+	zoneFindResp, err := r.client.listZones(ctx, zoneFindReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading hosting.de DNS zone",
@@ -206,13 +538,36 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	zoneConfig.Name = plan.Name.ValueString()
 	zoneConfig.Type = plan.Type.ValueString()
 	zoneConfig.EMailAddress = plan.EMailAddress.ValueString()
+	if !plan.MasterIP.IsUnknown() {
+		zoneConfig.MasterIP = plan.MasterIP.ValueString()
+	}
+	if !plan.ZoneTransferWhitelist.IsUnknown() {
+		whitelist, diags := plan.transferWhitelist(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		zoneConfig.ZoneTransferWhitelist = whitelist
+	}
+	if !plan.DNSSecMode.IsUnknown() && !plan.DNSSecMode.IsNull() {
+		zoneConfig.DNSSecMode = plan.DNSSecMode.ValueString()
+	}
+	soaValues, diags := plan.soaValues(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if soaValues != nil {
+		zoneConfig.SOAValues = soaValues
+	}
 
 	// Generate API request body from plan
 	zoneReq := ZoneUpdateRequest{
-		BaseRequest: &BaseRequest{},
+		BaseRequest: r.client.newBaseRequest(ctx),
 		ZoneConfig:  zoneConfig,
 	}
-	zone, err := r.client.updateZone(zoneReq)
+	r.client.logZoneMutation(ctx, "Updating DNS zone", zoneReq.ZoneConfig)
+	zone, err := r.client.updateZone(ctx, zoneReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating zone",
@@ -225,6 +580,11 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.ID = types.StringValue(zone.Response.ZoneConfig.ID)
 	plan.Name = types.StringValue(zone.Response.ZoneConfig.Name)
 	plan.Type = types.StringValue(zone.Response.ZoneConfig.Type)
+	plan.AccountID = types.StringValue(zone.Response.ZoneConfig.AccountID)
+	plan.DNSServerGroupID = types.StringValue(zone.Response.ZoneConfig.DNSServerGroupID)
+	resp.Diagnostics.Append(plan.setTransferSettings(ctx, zone.Response.ZoneConfig)...)
+	resp.Diagnostics.Append(plan.setSOAValues(ctx, zone.Response.ZoneConfig)...)
+	plan.setDNSSecMode(&resp.Diagnostics, zone.Response.ZoneConfig)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -235,6 +595,9 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
 	// Retrieve values from state
 	var state zoneResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -242,14 +605,24 @@ func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withAccountID(ctx, state.AccountID)
+
+	if err := r.client.waitDeleteGracePeriod(ctx, "zone "+state.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting hosting.de Zone",
+			"Zone deletion was cancelled during the delete grace period: "+err.Error(),
+		)
+		return
+	}
 
 	zoneReq := ZoneDeleteRequest{
-		BaseRequest:  &BaseRequest{},
+		BaseRequest:  r.client.newBaseRequest(ctx),
 		ZoneConfigId: state.ID.ValueString(),
 	}
 
 	// Delete existing zone
-	_, err := r.client.deleteZone(zoneReq)
+	r.client.logZoneMutation(ctx, "Deleting DNS zone", ZoneConfig{ID: state.ID.ValueString(), Name: state.Name.ValueString()})
+	_, err := r.client.deleteZone(ctx, zoneReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting hosting.de Zone",
@@ -259,11 +632,11 @@ func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	// Purge restorable zone
-	_, purgeErr := r.client.purgeZone(zoneReq)
+	_, purgeErr := r.client.purgeZone(ctx, zoneReq)
 	if purgeErr != nil {
 		resp.Diagnostics.AddError(
 			"Error Purging hosting.de Zone",
-			"Could not purge zone, unexpected error: "+err.Error(),
+			"Could not purge zone, unexpected error: "+purgeErr.Error(),
 		)
 		return
 	}
@@ -282,3 +655,19 @@ func (r *zoneResource) ImportState(ctx context.Context, req resource.ImportState
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// checkAccountMove adds a warning if the account owning a zone or record
+// differs from the one stored in state, e.g. because the zone was moved to
+// another account outside of Terraform.
+func checkAccountMove(diags *diag.Diagnostics, object, id, storedAccountID, returnedAccountID string) {
+	if storedAccountID == "" || returnedAccountID == "" || storedAccountID == returnedAccountID {
+		return
+	}
+
+	diags.AddWarning(
+		"hosting.de "+object+" moved to another account",
+		"The "+object+" "+id+" belonged to account "+storedAccountID+" but is now owned by account "+returnedAccountID+". "+
+			"It was probably moved outside of Terraform. Subsequent operations may fail if the configured "+
+			"credentials have no access to the new account.",
+	)
+}
@@ -1,8 +1,17 @@
 package hostingde
 
 import (
+	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -54,3 +63,669 @@ resource "hostingde_zone" "test" {
 		},
 	})
 }
+
+func TestCheckAccountMove(t *testing.T) {
+	var diags diag.Diagnostics
+
+	checkAccountMove(&diags, "zone", "zone-id", "account-a", "account-a")
+	checkAccountMove(&diags, "zone", "zone-id", "", "account-a")
+	if len(diags) != 0 {
+		t.Fatalf("expected no warnings, got %v", diags)
+	}
+
+	checkAccountMove(&diags, "zone", "zone-id", "account-a", "account-b")
+	if len(diags) != 1 || diags.WarningsCount() != 1 {
+		t.Fatalf("expected one warning, got %v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "account-a") || !strings.Contains(detail, "account-b") {
+		t.Errorf("expected both accounts in warning, got %q", detail)
+	}
+}
+
+func TestZoneResourceReadDNSServerGroup(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zonesFind": func(_ []byte) any {
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []Zone{{ZoneConfig: ZoneConfig{
+				ID:               "zone-id",
+				AccountID:        "account-id",
+				Name:             "example.test",
+				Type:             "NATIVE",
+				DNSServerGroupID: "server-group-id",
+			}}}
+			return findResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":         "zone-id",
+		"account_id": "account-id",
+	})
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+	r.Read(ctx, fwresource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var model zoneResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &model)...)
+	if model.DNSServerGroupID.ValueString() != "server-group-id" {
+		t.Errorf("expected dns_server_group_id to be read, got %q", model.DNSServerGroupID.ValueString())
+	}
+}
+
+func TestGetZoneConfig(t *testing.T) {
+	var requests int
+	serverGroupID := "server-group-id"
+	slowStarted, slow := make(chan struct{}), make(chan struct{})
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneConfigsFind": func(body []byte) any {
+			var findRequest ZoneConfigsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Error(err)
+			}
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			if findRequest.Filter.Filter.Value == "slow-zone-id" {
+				close(slowStarted)
+				<-slow
+				findResponse.Response.Data = []ZoneConfig{{ID: "slow-zone-id", Name: "slow.test"}}
+				return findResponse
+			}
+
+			requests++
+			findResponse.Response.Data = []ZoneConfig{{
+				ID:               "zone-id",
+				Name:             "example.test",
+				Type:             "SLAVE",
+				Status:           "active",
+				DNSServerGroupID: serverGroupID,
+			}}
+			return findResponse
+		},
+		"zoneUpdate": func(_ []byte) any {
+			serverGroupID = "updated-server-group-id"
+			return ZoneUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+
+	for range 2 {
+		zoneConfig, err := client.getZoneConfig(context.Background(), "zone-id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if zoneConfig.DNSServerGroupID != "server-group-id" {
+			t.Errorf("unexpected DNS server group ID %q", zoneConfig.DNSServerGroupID)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected zone config to be cached, got %d requests", requests)
+	}
+
+	zoneConfig, _ := client.getZoneConfig(context.Background(), "zone-id")
+	if warning := zoneWriteWarning(zoneConfig); !strings.Contains(warning, "server-group-id") {
+		t.Errorf("expected warning with DNS server group ID for SLAVE zone, got %q", warning)
+	}
+	if warning := zoneWriteWarning(&ZoneConfig{Type: "NATIVE", Status: "active"}); warning != "" {
+		t.Errorf("expected no warning for active NATIVE zone, got %q", warning)
+	}
+	if warning := zoneWriteWarning(&ZoneConfig{Type: "NATIVE", Status: "blocked"}); !strings.Contains(warning, "blocked") {
+		t.Errorf("expected warning for blocked zone, got %q", warning)
+	}
+
+	// Updating the zone drops the cached config.
+	if _, err := client.updateZone(context.Background(), ZoneUpdateRequest{BaseRequest: &BaseRequest{}, ZoneConfig: ZoneConfig{ID: "zone-id"}}); err != nil {
+		t.Fatal(err)
+	}
+	zoneConfig, err := client.getZoneConfig(context.Background(), "zone-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 || zoneConfig.DNSServerGroupID != "updated-server-group-id" {
+		t.Errorf("expected the updated zone config to be fetched, got %+v after %d requests", zoneConfig, requests)
+	}
+
+	// A slow lookup does not block lookups of other zones.
+	slowDone := make(chan error, 1)
+	go func() {
+		_, err := client.getZoneConfig(context.Background(), "slow-zone-id")
+		slowDone <- err
+	}()
+	<-slowStarted
+	client.invalidateZoneConfig("zone-id")
+	if _, err := client.getZoneConfig(context.Background(), "zone-id"); err != nil {
+		t.Fatal(err)
+	}
+	close(slow)
+	if err := <-slowDone; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestZoneResourceEmailDrift(t *testing.T) {
+	for rname, email := range map[string]string{
+		"hostmaster.example.test.":  "hostmaster@example.test",
+		"first\\.last.example.test": "first.last@example.test",
+		"hostmaster@example.test":   "hostmaster@example.test",
+		"":                          "",
+	} {
+		if got := emailFromSOARName(rname); got != email {
+			t.Errorf("emailFromSOARName(%q) = %q, want %q", rname, got, email)
+		}
+	}
+	if !sameEmailAddress("hostmaster@Example.TEST", "hostmaster.example.test.") || sameEmailAddress("Hostmaster@example.test", "hostmaster@example.test") {
+		t.Errorf("expected only the domain to be compared case-insensitively")
+	}
+
+	apiEmail := "hostmaster.example.test."
+	var updated []ZoneConfig
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zonesFind": func(_ []byte) any {
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []Zone{{ZoneConfig: ZoneConfig{
+				ID:           "zone-id",
+				Name:         "example.test",
+				Type:         "NATIVE",
+				EMailAddress: apiEmail,
+			}}}
+			return findResponse
+		},
+		"zoneUpdate": func(body []byte) any {
+			var updateRequest ZoneUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updated = append(updated, updateRequest.ZoneConfig)
+
+			updateResponse := ZoneUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = updateRequest.ZoneConfig
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	configured := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":    "zone-id",
+		"name":  "example.test",
+		"type":  "NATIVE",
+		"email": "hostmaster@example.test",
+	})
+	read := func() string {
+		state := tfsdk.State{Schema: configured.Schema, Raw: configured.Raw}
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		var model zoneResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &model)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		return model.EMailAddress.ValueString()
+	}
+
+	// The rname form returned by the API is no drift.
+	if email := read(); email != "hostmaster@example.test" {
+		t.Errorf("expected configured email to be kept, got %q", email)
+	}
+
+	// An email changed out of band shows as drift, which the plan corrects.
+	apiEmail = "dns.example.test"
+	if email := read(); email != "dns@example.test" {
+		t.Errorf("expected changed email to be read, got %q", email)
+	}
+	plan := tfsdk.Plan{Schema: configured.Schema, Raw: configured.Raw}
+	resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if len(updated) != 1 || updated[0].EMailAddress != "hostmaster@example.test" {
+		t.Errorf("expected update to restore the configured email, got %+v", updated)
+	}
+}
+
+func TestZoneResourceAccountPrecedence(t *testing.T) {
+	for name, test := range map[string]struct {
+		providerAccount, resourceAccount string
+		expectedAccount                  string
+		expectWarning                    bool
+	}{
+		"provider only": {providerAccount: "provider-account", expectedAccount: "provider-account"},
+		"resource only": {resourceAccount: "resource-account", expectedAccount: "resource-account"},
+		"both set":      {providerAccount: "provider-account", resourceAccount: "resource-account", expectedAccount: "resource-account"},
+		"both equal":    {providerAccount: "provider-account", resourceAccount: "provider-account", expectedAccount: "provider-account", expectWarning: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var createRequest ZoneCreateRequest
+			var findAccounts []string
+			client := newTestClient(t, map[string]func(body []byte) any{
+				"zoneCreate": func(body []byte) any {
+					if err := json.Unmarshal(body, &createRequest); err != nil {
+						t.Fatal(err)
+					}
+					createResponse := ZoneCreateResponse{BaseResponse: BaseResponse{Status: "success"}}
+					createResponse.Response.ZoneConfig = createRequest.ZoneConfig
+					createResponse.Response.ZoneConfig.ID = "zone-id"
+					createResponse.Response.ZoneConfig.AccountID = createRequest.AccountId
+					return createResponse
+				},
+				"zonesFind": func(body []byte) any {
+					var findRequest ZonesFindRequest
+					if err := json.Unmarshal(body, &findRequest); err != nil {
+						t.Fatal(err)
+					}
+					findAccounts = append(findAccounts, findRequest.AccountId)
+					findResponse := ZonesFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+					// Zones of other accounts are not found.
+					if findRequest.AccountId == test.expectedAccount {
+						findResponse.Response.Data = []Zone{{ZoneConfig: ZoneConfig{
+							ID:        "zone-id",
+							Name:      "example.test",
+							Type:      "NATIVE",
+							AccountID: test.expectedAccount,
+						}}}
+					}
+					return findResponse
+				},
+				"zoneUpdate": func(body []byte) any {
+					var updateRequest ZoneUpdateRequest
+					if err := json.Unmarshal(body, &updateRequest); err != nil {
+						t.Fatal(err)
+					}
+					updateResponse := ZoneUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+					updateResponse.Response.ZoneConfig = updateRequest.ZoneConfig
+					return updateResponse
+				},
+			})
+			client.accountId = test.providerAccount
+
+			ctx := context.Background()
+			r := &zoneResource{client: client}
+			schemaResp := &fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+			values := map[string]any{"name": "example.test", "type": "NATIVE"}
+			if test.resourceAccount != "" {
+				values["account_id"] = test.resourceAccount
+			}
+			config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values)
+			plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+
+			planResp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+				Config: config,
+				Plan:   plan,
+				State:  tfsdk.State{Schema: config.Schema, Raw: tftypes.NewValue(config.Raw.Type(), nil)},
+			}, planResp)
+			if planResp.Diagnostics.HasError() {
+				t.Fatal(planResp.Diagnostics)
+			}
+			if warned := planResp.Diagnostics.WarningsCount() > 0; warned != test.expectWarning {
+				t.Errorf("expected warning %t, got %v", test.expectWarning, planResp.Diagnostics)
+			}
+
+			createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			r.Create(ctx, fwresource.CreateRequest{Config: config, Plan: plan}, createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatal(createResp.Diagnostics)
+			}
+			if createRequest.AccountId != test.expectedAccount {
+				t.Errorf("expected zone to be created on behalf of %q, got %q", test.expectedAccount, createRequest.AccountId)
+			}
+
+			var model zoneResourceModel
+			createResp.Diagnostics.Append(createResp.State.Get(ctx, &model)...)
+			if model.AccountID.ValueString() != test.expectedAccount {
+				t.Errorf("expected account_id %q in state, got %q", test.expectedAccount, model.AccountID.ValueString())
+			}
+
+			// The zone is read on behalf of the account owning it.
+			readResp := &fwresource.ReadResponse{State: createResp.State}
+			r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatal(readResp.Diagnostics)
+			}
+			updateResp := &fwresource.UpdateResponse{State: readResp.State}
+			r.Update(ctx, fwresource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: readResp.State.Schema, Raw: readResp.State.Raw},
+				State: readResp.State,
+			}, updateResp)
+			if updateResp.Diagnostics.HasError() {
+				t.Fatal(updateResp.Diagnostics)
+			}
+			if len(findAccounts) != 2 || findAccounts[0] != test.expectedAccount || findAccounts[1] != test.expectedAccount {
+				t.Errorf("expected zone to be read on behalf of %q, got %v", test.expectedAccount, findAccounts)
+			}
+		})
+	}
+}
+
+func TestZoneResourceTransferSettings(t *testing.T) {
+	var stored ZoneConfig
+	var createRequest ZoneCreateRequest
+	var updateRequest ZoneUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneCreate": func(body []byte) any {
+			if err := json.Unmarshal(body, &createRequest); err != nil {
+				t.Fatal(err)
+			}
+			stored = createRequest.ZoneConfig
+			stored.ID = "zone-id"
+			createResponse := ZoneCreateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			createResponse.Response.ZoneConfig = stored
+			return createResponse
+		},
+		"zonesFind": func(_ []byte) any {
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []Zone{{ZoneConfig: stored}}
+			return findResponse
+		},
+		"zoneUpdate": func(body []byte) any {
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			stored = updateRequest.ZoneConfig
+			updateResponse := ZoneUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = stored
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"name":                    "example.test",
+		"type":                    "MASTER",
+		"master_ip":               types.StringUnknown(),
+		"zone_transfer_whitelist": []string{"192.0.2.53", "2001:db8::53"},
+	})
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	if !createRequest.UseDefaultNameserverSet || strings.Join(createRequest.ZoneConfig.ZoneTransferWhitelist, " ") != "192.0.2.53 2001:db8::53" {
+		t.Errorf("expected the zone to be created with the whitelist, got %+v", createRequest)
+	}
+
+	var model zoneResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &model)...)
+	if model.ID.ValueString() != "zone-id" || model.MasterIP.ValueString() != "" || len(model.ZoneTransferWhitelist.Elements()) != 2 {
+		t.Errorf("expected the transfer settings to be stored, got %+v", model)
+	}
+
+	// Update sends the changed whitelist and keeps the other settings.
+	update := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":                      "zone-id",
+		"name":                    "example.test",
+		"type":                    "MASTER",
+		"master_ip":               "",
+		"zone_transfer_whitelist": []string{"192.0.2.54"},
+	})
+	updateResp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: update.Schema}}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: tfsdk.Plan{Schema: update.Schema, Raw: update.Raw}}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	if strings.Join(updateRequest.ZoneConfig.ZoneTransferWhitelist, " ") != "192.0.2.54" || updateRequest.ZoneConfig.Name != "example.test" {
+		t.Errorf("expected the zone to be updated with the new whitelist, got %+v", updateRequest)
+	}
+
+	// Read refreshes the settings of a secondary zone.
+	stored = ZoneConfig{ID: "zone-id", Name: "example.test", Type: "SLAVE", MasterIP: "192.0.2.1"}
+	state := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{"id": "zone-id"})
+	readResp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+	r.Read(ctx, fwresource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &model)...)
+	if model.MasterIP.ValueString() != "192.0.2.1" || model.ZoneTransferWhitelist.IsNull() || len(model.ZoneTransferWhitelist.Elements()) != 0 {
+		t.Errorf("expected master_ip and an empty whitelist, got %s %s", model.MasterIP, model.ZoneTransferWhitelist)
+	}
+}
+
+func TestZoneResourceDNSSecMode(t *testing.T) {
+	var stored ZoneConfig
+	var createRequest ZoneCreateRequest
+	var updateRequest ZoneUpdateRequest
+	// applyMode reports whether the API applies the requested DNSSEC mode.
+	applyMode := true
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneCreate": func(body []byte) any {
+			if err := json.Unmarshal(body, &createRequest); err != nil {
+				t.Fatal(err)
+			}
+			stored = createRequest.ZoneConfig
+			stored.ID = "zone-id"
+			createResponse := ZoneCreateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			createResponse.Response.ZoneConfig = stored
+			return createResponse
+		},
+		"zonesFind": func(_ []byte) any {
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []Zone{{ZoneConfig: stored}}
+			return findResponse
+		},
+		"zoneUpdate": func(body []byte) any {
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			mode := stored.DNSSecMode
+			stored = updateRequest.ZoneConfig
+			if !applyMode {
+				stored.DNSSecMode = mode
+			}
+			updateResponse := ZoneUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = stored
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"name":        "example.test",
+		"type":        "NATIVE",
+		"dnssec_mode": dnsSecModeAutomatic,
+	})
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	if createRequest.ZoneConfig.DNSSecMode != dnsSecModeAutomatic {
+		t.Errorf("expected the zone to be created with DNSSEC enabled, got %q", createRequest.ZoneConfig.DNSSecMode)
+	}
+
+	update := func(mode string) *fwresource.UpdateResponse {
+		t.Helper()
+		update := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+			"id":          "zone-id",
+			"name":        "example.test",
+			"type":        "NATIVE",
+			"dnssec_mode": mode,
+		})
+		updateResp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: update.Schema}}
+		r.Update(ctx, fwresource.UpdateRequest{Plan: tfsdk.Plan{Schema: update.Schema, Raw: update.Raw}}, updateResp)
+		return updateResp
+	}
+
+	// Disabling DNSSEC sends the new mode.
+	updateResp := update(dnsSecModeOff)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	var model zoneResourceModel
+	updateResp.Diagnostics.Append(updateResp.State.Get(ctx, &model)...)
+	if updateRequest.ZoneConfig.DNSSecMode != dnsSecModeOff || model.DNSSecMode.ValueString() != dnsSecModeOff {
+		t.Errorf("expected DNSSEC to be disabled, got %q in the request and %s in state", updateRequest.ZoneConfig.DNSSecMode, model.DNSSecMode)
+	}
+
+	// A mode the zone does not take is an error.
+	applyMode = false
+	updateResp = update(dnsSecModeAutomatic)
+	if !updateResp.Diagnostics.HasError() || updateResp.Diagnostics.Errors()[0].Summary() != "DNSSEC mode not applied" {
+		t.Errorf("expected an error for the mode not being applied, got %v", updateResp.Diagnostics)
+	}
+
+	// Zones without a reported mode are read as off.
+	stored = ZoneConfig{ID: "zone-id", Name: "example.test", Type: "NATIVE"}
+	state := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{"id": "zone-id"})
+	readResp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+	r.Read(ctx, fwresource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &model)...)
+	if model.DNSSecMode.ValueString() != dnsSecModeOff {
+		t.Errorf("expected DNSSEC mode off, got %s", model.DNSSecMode)
+	}
+}
+
+func TestZoneResourceSOAValues(t *testing.T) {
+	var stored ZoneConfig
+	var createRequest ZoneCreateRequest
+	var updateRequest ZoneUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneCreate": func(body []byte) any {
+			if err := json.Unmarshal(body, &createRequest); err != nil {
+				t.Fatal(err)
+			}
+			stored = createRequest.ZoneConfig
+			stored.ID = "zone-id"
+			createResponse := ZoneCreateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			createResponse.Response.ZoneConfig = stored
+			return createResponse
+		},
+		"zonesFind": func(_ []byte) any {
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []Zone{{ZoneConfig: stored}}
+			return findResponse
+		},
+		"zoneUpdate": func(body []byte) any {
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			stored = updateRequest.ZoneConfig
+			updateResponse := ZoneUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+			updateResponse.Response.ZoneConfig = stored
+			return updateResponse
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	soaValues := func(refresh, retry, expire, ttl, negativeTTL int64) types.Object {
+		return types.ObjectValueMust(soaValuesAttributeTypes, map[string]attr.Value{
+			"refresh":      types.Int64Value(refresh),
+			"retry":        types.Int64Value(retry),
+			"expire":       types.Int64Value(expire),
+			"ttl":          types.Int64Value(ttl),
+			"negative_ttl": types.Int64Value(negativeTTL),
+		})
+	}
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"name":       "example.test",
+		"type":       "NATIVE",
+		"soa_values": soaValues(43200, 3600, 1209600, 86400, 300),
+	})
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	expected := SOAValues{Refresh: 43200, Retry: 3600, Expire: 1209600, TTL: 86400, NegativeTTL: 300}
+	if createRequest.ZoneConfig.SOAValues == nil || *createRequest.ZoneConfig.SOAValues != expected {
+		t.Errorf("expected the zone to be created with the SOA values, got %+v", createRequest.ZoneConfig.SOAValues)
+	}
+
+	// Update sends the changed SOA values.
+	update := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":         "zone-id",
+		"name":       "example.test",
+		"type":       "NATIVE",
+		"soa_values": soaValues(43200, 3600, 1209600, 86400, 600),
+	})
+	updateResp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: update.Schema}}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: tfsdk.Plan{Schema: update.Schema, Raw: update.Raw}}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	if updateRequest.ZoneConfig.SOAValues == nil || updateRequest.ZoneConfig.SOAValues.NegativeTTL != 600 {
+		t.Errorf("expected the zone to be updated with the new negative TTL, got %+v", updateRequest.ZoneConfig.SOAValues)
+	}
+	var model zoneResourceModel
+	updateResp.Diagnostics.Append(updateResp.State.Get(ctx, &model)...)
+	if !model.SOAValues.Equal(soaValues(43200, 3600, 1209600, 86400, 600)) {
+		t.Errorf("expected the SOA values to be stored, got %s", model.SOAValues)
+	}
+
+	// Zones without SOA values, such as SLAVE zones, read as null.
+	stored = ZoneConfig{ID: "zone-id", Name: "example.test", Type: "SLAVE"}
+	state := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{"id": "zone-id"})
+	readResp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+	r.Read(ctx, fwresource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &model)...)
+	if !model.SOAValues.IsNull() {
+		t.Errorf("expected null SOA values, got %s", model.SOAValues)
+	}
+}
+
+func TestZoneResourceDeletePurgeError(t *testing.T) {
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zoneDelete": func(_ []byte) any {
+			return ZoneDeleteResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+		"zonePurgeRestorable": func(_ []byte) any {
+			return ZoneDeleteResponse{BaseResponse: BaseResponse{
+				Status: "error",
+				Errors: []APIError{{Code: 1, Text: "Purging failed"}},
+			}}
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	config := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":   "zone-id",
+		"name": "example.test",
+		"type": "NATIVE",
+	})
+	state := tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+
+	// The purge error is reported, not the successful deletion's.
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+	if len(resp.Diagnostics.Errors()) != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "Purging failed") {
+		t.Errorf("expected the purge error, got %v", resp.Diagnostics)
+	}
+}
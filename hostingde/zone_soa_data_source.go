@@ -0,0 +1,253 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &zoneSOADataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneSOADataSource{}
+)
+
+// NewZoneSOADataSource is a helper function to simplify the provider implementation.
+func NewZoneSOADataSource() datasource.DataSource {
+	return &zoneSOADataSource{}
+}
+
+// zoneSOADataSource is the data source implementation.
+type zoneSOADataSource struct {
+	client *Client
+}
+
+// zoneSOADataSourceModel maps the zone_soa data source schema data.
+type zoneSOADataSourceModel struct {
+	ZoneID      types.String `tfsdk:"zone_id"`
+	ZoneName    types.String `tfsdk:"zone_name"`
+	PrimaryNS   types.String `tfsdk:"primary_ns"`
+	RName       types.String `tfsdk:"rname"`
+	Serial      types.Int64  `tfsdk:"serial"`
+	Refresh     types.Int64  `tfsdk:"refresh"`
+	Retry       types.Int64  `tfsdk:"retry"`
+	Expire      types.Int64  `tfsdk:"expire"`
+	TTL         types.Int64  `tfsdk:"ttl"`
+	NegativeTTL types.Int64  `tfsdk:"negative_ttl"`
+	Record      types.String `tfsdk:"record"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneSOADataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_soa"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneSOADataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the SOA record of a zone as structured values and as a record in RFC 1035 presentation format. " +
+			"The values are derived from the SOA values of the zone config and the primary name server of the zone.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "ID of the DNS zone.",
+				Required:    true,
+			},
+			"zone_name": schema.StringAttribute{
+				Description: "Domain name of the DNS zone.",
+				Computed:    true,
+			},
+			"primary_ns": schema.StringAttribute{
+				Description: "Primary name server of the zone, the MNAME field, as a fully qualified name with a trailing dot. " +
+					"Taken from the SOA record of the zone, or the first NS record at the zone apex if the SOA record is not listed.",
+				Computed: true,
+			},
+			"rname": schema.StringAttribute{
+				Description: "Hostmaster email address of the zone in SOA form, e.g. hostmaster.example.test., the RNAME field.",
+				Computed:    true,
+			},
+			"serial": schema.Int64Attribute{
+				Description: "Serial of the zone. The serial is managed by hosting.de and increases with every change of the zone, " +
+					"so it is only known if the SOA record is listed with the records of the zone. " +
+					"It is null otherwise, and 0 is used in record.",
+				Computed: true,
+			},
+			"refresh": schema.Int64Attribute{
+				Description: "Refresh interval of secondary name servers in seconds.",
+				Computed:    true,
+			},
+			"retry": schema.Int64Attribute{
+				Description: "Retry interval of secondary name servers after a failed refresh in seconds.",
+				Computed:    true,
+			},
+			"expire": schema.Int64Attribute{
+				Description: "Time in seconds after which secondary name servers stop answering for the zone without a refresh.",
+				Computed:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the SOA record in seconds.",
+				Computed:    true,
+			},
+			"negative_ttl": schema.Int64Attribute{
+				Description: "TTL of negative answers in seconds, the MINIMUM field.",
+				Computed:    true,
+			},
+			"record": schema.StringAttribute{
+				Description: "SOA record in RFC 1035 presentation format, e.g. " +
+					"example.test. 86400 IN SOA ns1.hosting.de. hostmaster.example.test. 2024010101 86400 7200 3600000 900.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneSOADataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer d.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var config zoneSOADataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := config.ZoneID.ValueString()
+	zone, err := d.client.getZone(ctx, zoneId, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone "+zoneId+": "+err.Error(),
+		)
+		return
+	}
+
+	state, err := newZoneSOADataSourceModel(zone)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de SOA record",
+			"Could not read the SOA record of hosting.de DNS zone "+zoneId+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// newZoneSOADataSourceModel maps the SOA values of a zone to the data source
+// model and renders the SOA record.
+func newZoneSOADataSourceModel(zone *Zone) (zoneSOADataSourceModel, error) {
+	zoneConfig := zone.ZoneConfig
+	if zoneConfig.SOAValues == nil {
+		return zoneSOADataSourceModel{}, fmt.Errorf("the zone has no SOA values")
+	}
+
+	primaryNS, serial := zoneSOAFromRecords(zoneConfig.Name, zone.Records)
+	if primaryNS == "" {
+		return zoneSOADataSourceModel{}, fmt.Errorf("the zone has neither a SOA record nor NS records at the zone apex")
+	}
+
+	model := zoneSOADataSourceModel{
+		ZoneID:      types.StringValue(zoneConfig.ID),
+		ZoneName:    types.StringValue(zoneConfig.Name),
+		PrimaryNS:   types.StringValue(fqdn(primaryNS)),
+		RName:       types.StringValue(fqdn(soaRNameFromEmail(zoneConfig.EMailAddress))),
+		Serial:      types.Int64Null(),
+		Refresh:     types.Int64Value(int64(zoneConfig.SOAValues.Refresh)),
+		Retry:       types.Int64Value(int64(zoneConfig.SOAValues.Retry)),
+		Expire:      types.Int64Value(int64(zoneConfig.SOAValues.Expire)),
+		TTL:         types.Int64Value(int64(zoneConfig.SOAValues.TTL)),
+		NegativeTTL: types.Int64Value(int64(zoneConfig.SOAValues.NegativeTTL)),
+	}
+	if serial != nil {
+		model.Serial = types.Int64Value(*serial)
+	}
+
+	model.Record = types.StringValue(fmt.Sprintf("%s %d IN SOA %s %s %d %d %d %d %d",
+		fqdn(zoneConfig.Name),
+		model.TTL.ValueInt64(),
+		model.PrimaryNS.ValueString(),
+		model.RName.ValueString(),
+		model.Serial.ValueInt64(),
+		model.Refresh.ValueInt64(),
+		model.Retry.ValueInt64(),
+		model.Expire.ValueInt64(),
+		model.NegativeTTL.ValueInt64(),
+	))
+
+	return model, nil
+}
+
+// zoneSOAFromRecords returns the primary name server and the serial of a
+// zone from its SOA record. Without a SOA record, the serial is nil and the
+// alphabetically first NS record at the zone apex is used as primary name
+// server.
+func zoneSOAFromRecords(zoneName string, records []DNSRecord) (string, *int64) {
+	var nameServers []string
+	for _, record := range records {
+		if !strings.EqualFold(strings.TrimSuffix(record.Name, "."), strings.TrimSuffix(zoneName, ".")) {
+			continue
+		}
+		switch record.Type {
+		case "SOA":
+			// MNAME RNAME SERIAL REFRESH RETRY EXPIRE MINIMUM
+			fields := strings.Fields(record.Content)
+			if len(fields) == 0 {
+				continue
+			}
+			var serial *int64
+			if len(fields) > 2 {
+				if value, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+					serial = &value
+				}
+			}
+			return fields[0], serial
+		case "NS":
+			nameServers = append(nameServers, record.Content)
+		}
+	}
+
+	if len(nameServers) == 0 {
+		return "", nil
+	}
+	sort.Strings(nameServers)
+	return nameServers[0], nil
+}
+
+// soaRNameFromEmail converts an email address, e.g. first.last@example.test,
+// to the SOA rname form, e.g. first\.last.example.test. It is the inverse of
+// emailFromSOARName; addresses already in rname form are returned unchanged.
+func soaRNameFromEmail(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found {
+		return email
+	}
+	return strings.ReplaceAll(local, ".", "\\.") + "." + domain
+}
+
+// fqdn returns a domain name with a trailing dot.
+func fqdn(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneSOADataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,127 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readZoneSOADataSource reads the zone_soa data source with the given config values.
+func readZoneSOADataSource(t *testing.T, client *Client, values map[string]any) (zoneSOADataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := &zoneSOADataSource{client: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	req := datasource.ReadRequest{
+		Config: newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, values),
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+
+	var state zoneSOADataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	}
+
+	return state, resp.Diagnostics
+}
+
+func TestZoneSOADataSource(t *testing.T) {
+	zoneConfig := ZoneConfig{
+		ID:           "zone-id",
+		Name:         "example.test",
+		EMailAddress: "host.master@example.test",
+		SOAValues: &SOAValues{
+			Refresh:     86400,
+			Retry:       7200,
+			Expire:      3600000,
+			TTL:         3600,
+			NegativeTTL: 900,
+		},
+	}
+
+	var records []DNSRecord
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"zonesFind": func(_ []byte) any {
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []Zone{{ZoneConfig: zoneConfig, Records: records}}
+			return findResponse
+		},
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = records
+			findResponse.Response.TotalEntries = len(records)
+			return findResponse
+		},
+	})
+
+	// The serial is only known from the SOA record listed with the zone.
+	records = []DNSRecord{
+		{ID: "1", Name: "example.test", Type: "SOA", Content: "ns1.hosting.de. host\\.master.example.test. 2024010101 86400 7200 3600000 900"},
+		{ID: "2", Name: "example.test", Type: "NS", Content: "ns2.hosting.de"},
+	}
+	state, diags := readZoneSOADataSource(t, client, map[string]any{"zone_id": "zone-id"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.PrimaryNS.ValueString() != "ns1.hosting.de." || state.RName.ValueString() != "host\\.master.example.test." || state.Serial.ValueInt64() != 2024010101 {
+		t.Errorf("expected the values of the SOA record, got %s %s %s", state.PrimaryNS, state.RName, state.Serial)
+	}
+	expected := fmt.Sprintf("%s. %d IN SOA %s %s %d %d %d %d %d",
+		state.ZoneName.ValueString(),
+		state.TTL.ValueInt64(),
+		state.PrimaryNS.ValueString(),
+		state.RName.ValueString(),
+		state.Serial.ValueInt64(),
+		state.Refresh.ValueInt64(),
+		state.Retry.ValueInt64(),
+		state.Expire.ValueInt64(),
+		state.NegativeTTL.ValueInt64(),
+	)
+	if state.Record.ValueString() != expected {
+		t.Errorf("expected rendered SOA record %q, got %q", expected, state.Record.ValueString())
+	}
+	if state.Record.ValueString() != "example.test. 3600 IN SOA ns1.hosting.de. host\\.master.example.test. 2024010101 86400 7200 3600000 900" {
+		t.Errorf("unexpected rendered SOA record %q", state.Record.ValueString())
+	}
+
+	// Without a SOA record, the primary name server is taken from the NS
+	// records and the serial is unknown.
+	records = []DNSRecord{
+		{ID: "2", Name: "example.test", Type: "NS", Content: "ns2.hosting.de"},
+		{ID: "3", Name: "example.test", Type: "NS", Content: "ns1.hosting.de"},
+		{ID: "4", Name: "sub.example.test", Type: "NS", Content: "a.example.test"},
+	}
+	state, diags = readZoneSOADataSource(t, client, map[string]any{"zone_id": "zone-id"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.PrimaryNS.ValueString() != "ns1.hosting.de." || !state.Serial.IsNull() {
+		t.Errorf("expected primary name server from NS records and no serial, got %s %s", state.PrimaryNS, state.Serial)
+	}
+	if !strings.HasPrefix(state.Record.ValueString(), "example.test. 3600 IN SOA ns1.hosting.de. host\\.master.example.test. 0 86400 ") {
+		t.Errorf("unexpected rendered SOA record %q", state.Record.ValueString())
+	}
+
+	records = nil
+	if _, diags = readZoneSOADataSource(t, client, map[string]any{"zone_id": "zone-id"}); !diags.HasError() {
+		t.Error("expected error for a zone without SOA and NS records")
+	}
+}
@@ -0,0 +1,297 @@
+package hostingde
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &zoneTTLResource{}
+	_ resource.ResourceWithConfigure = &zoneTTLResource{}
+)
+
+// NewZoneTTLResource is a helper function to simplify the provider implementation.
+func NewZoneTTLResource() resource.Resource {
+	return &zoneTTLResource{}
+}
+
+// zoneTTLResource sets a uniform TTL on all records of a zone.
+type zoneTTLResource struct {
+	client *Client
+}
+
+// zoneTTLResourceModel maps the zone_ttl resource schema data.
+type zoneTTLResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ZoneID       types.String `tfsdk:"zone_id"`
+	TTL          types.Int64  `tfsdk:"ttl"`
+	OriginalTTLs types.Map    `tfsdk:"original_ttls"`
+}
+
+// Metadata returns the resource type name.
+func (r *zoneTTLResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_ttl"
+}
+
+// Schema defines the schema for the resource.
+func (r *zoneTTLResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sets a uniform TTL on all records of a zone, e.g. to lower TTLs before a migration. " +
+			"SOA records, NS records at the zone apex and records created from a template are left untouched. " +
+			"All records are modified in a single batch. Destroying this resource restores the original TTLs " +
+			"of all records whose TTL was not changed since.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the DNS zone.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone to set the TTL of its records.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL in seconds to set on all records of the zone.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(minRecordTTL, maxRecordTTL),
+				},
+			},
+			"original_ttls": schema.MapAttribute{
+				Description: "TTLs of the modified records before the change, keyed by record ID. Used to restore the TTLs on destroy.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create sets the TTL on all records of the zone.
+func (r *zoneTTLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Retrieve values from plan
+	var plan zoneTTLResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setTTL(ctx, &plan, map[string]int{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.ZoneID
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read detects records whose TTL differs from the configured one.
+func (r *zoneTTLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Get current state
+	var state zoneTTLResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := r.client.listUserRecords(ctx, state.ZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records of zone ID "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Report a deviating TTL, so the next apply sets the TTL again.
+	for _, record := range records {
+		if int64(record.TTL) != state.TTL.ValueInt64() {
+			tflog.Info(ctx, "Record TTL changed outside of Terraform", map[string]any{
+				"id":  record.ID,
+				"ttl": record.TTL,
+			})
+			state.TTL = types.Int64Value(int64(record.TTL))
+			break
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update sets the new TTL on all records of the zone, keeping the TTLs
+// recorded before the first change.
+func (r *zoneTTLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	var plan, state zoneTTLResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	originalTTLs, diags := originalTTLsFromState(ctx, state.OriginalTTLs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setTTL(ctx, &plan, originalTTLs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete restores the original TTLs of all records whose TTL is still the one
+// set by this resource.
+func (r *zoneTTLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, events := withOperationEvents(ctx)
+	defer r.client.addOperationDiagnostics(events, &resp.Diagnostics)
+
+	// Retrieve values from state
+	var state zoneTTLResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	originalTTLs, diags := originalTTLsFromState(ctx, state.OriginalTTLs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock, err := r.client.lockZone(ctx, state.ZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Locking hosting.de DNS zone", err.Error())
+		return
+	}
+	defer unlock()
+
+	records, err := r.client.listUserRecords(ctx, state.ZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records of zone ID "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	_, err = r.client.modifyRecordsTTL(ctx, state.ZoneID.ValueString(), records, func(record DNSRecord) (int, bool) {
+		ttl, ok := originalTTLs[record.ID]
+		return ttl, ok && int64(record.TTL) == state.TTL.ValueInt64()
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Restoring Record TTLs",
+			"Could not restore the TTLs of hosting.de DNS records, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// setTTL sets the planned TTL on all records of the zone in one batch and
+// adds the previous TTLs of modified records to originalTTLs, unless a TTL
+// was already recorded for the record.
+func (r *zoneTTLResource) setTTL(ctx context.Context, plan *zoneTTLResourceModel, originalTTLs map[string]int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	unlock, err := r.client.lockZone(ctx, plan.ZoneID.ValueString())
+	if err != nil {
+		diags.AddError("Error Locking hosting.de DNS zone", err.Error())
+		return diags
+	}
+	defer unlock()
+
+	records, err := r.client.listUserRecords(ctx, plan.ZoneID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS records",
+			"Could not read hosting.de DNS records of zone ID "+plan.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return diags
+	}
+
+	ttl := int(plan.TTL.ValueInt64())
+	previous, err := r.client.modifyRecordsTTL(ctx, plan.ZoneID.ValueString(), records, func(DNSRecord) (int, bool) {
+		return ttl, true
+	})
+	if err != nil {
+		diags.AddError(
+			"Error updating records",
+			"Could not update records, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	for id, previousTTL := range previous {
+		if _, ok := originalTTLs[id]; !ok {
+			originalTTLs[id] = previousTTL
+		}
+	}
+	tflog.Info(ctx, "Set TTL of zone records", map[string]any{
+		"zone_id":  plan.ZoneID.ValueString(),
+		"ttl":      ttl,
+		"modified": len(previous),
+	})
+
+	plan.OriginalTTLs, diags = types.MapValueFrom(ctx, types.Int64Type, originalTTLs)
+	return diags
+}
+
+// originalTTLsFromState converts the original_ttls attribute to a map.
+func originalTTLsFromState(ctx context.Context, value types.Map) (map[string]int, diag.Diagnostics) {
+	originalTTLs := map[string]int{}
+	if value.IsNull() || value.IsUnknown() {
+		return originalTTLs, nil
+	}
+
+	diags := value.ElementsAs(ctx, &originalTTLs, false)
+	return originalTTLs, diags
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *zoneTTLResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
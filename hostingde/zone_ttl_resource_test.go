@@ -0,0 +1,145 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestZoneTTLResource(t *testing.T) {
+	stored := []DNSRecord{
+		{ID: "soa", Name: "example.test", Type: "SOA", Content: "ns1.example.test. hostmaster.example.test. 1 86400 7200 3600000 3600", TTL: 86400},
+		{ID: "ns", Name: "example.test", Type: "NS", Content: "ns1.example.test", TTL: 86400},
+		{ID: "template", Name: "example.test", Type: "MX", Content: "mx.example.test", TTL: 3600, RecordTemplateID: "template-id"},
+		{ID: "delegation", Name: "sub.example.test", Type: "NS", Content: "ns.other.test", TTL: 86400},
+		{ID: "www", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "low", Name: "low.example.test", Type: "A", Content: "192.0.2.2", TTL: 300},
+	}
+	ttls := func() map[string]int {
+		ttls := map[string]int{}
+		for _, record := range stored {
+			ttls[record.ID] = record.TTL
+		}
+		return ttls
+	}
+
+	var updates []RecordsUpdateRequest
+	client := newTestClient(t, map[string]func(body []byte) any{
+		"recordsFind": func(_ []byte) any {
+			findResponse := RecordsFindResponse{BaseResponse: BaseResponse{Status: "success"}}
+			findResponse.Response.Data = stored
+			findResponse.Response.TotalEntries = len(stored)
+			return findResponse
+		},
+		"recordsUpdate": func(body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatal(err)
+			}
+			updates = append(updates, updateRequest)
+
+			for _, modified := range updateRequest.RecordsToModify {
+				for i := range stored {
+					if stored[i].ID == modified.ID {
+						stored[i].TTL = modified.TTL
+					}
+				}
+			}
+			return RecordsUpdateResponse{BaseResponse: BaseResponse{Status: "success"}}
+		},
+	})
+
+	ctx := context.Background()
+	r := &zoneTTLResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := newTestConfig(t, tfsdk.State{Schema: schemaResp.Schema}, map[string]any{
+		"id":            types.StringUnknown(),
+		"zone_id":       "zone-id",
+		"ttl":           300,
+		"original_ttls": types.MapUnknown(types.Int64Type),
+	})
+
+	// All records not managed by the zone are modified in a single batch.
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected a single batch update, got %d", len(updates))
+	}
+	modified := updates[0].RecordsToModify
+	if len(modified) != 2 || modified[0].ID != "delegation" || modified[1].ID != "www" {
+		t.Fatalf("expected delegation and www records to be modified, got %v", modified)
+	}
+	current := ttls()
+	if current["delegation"] != 300 || current["www"] != 300 || current["soa"] != 86400 || current["ns"] != 86400 || current["template"] != 3600 {
+		t.Errorf("expected the TTL of all non-managed records to be set, got %v", current)
+	}
+
+	var state zoneTTLResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	originalTTLs, diags := originalTTLsFromState(ctx, state.OriginalTTLs)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(originalTTLs) != 2 || originalTTLs["delegation"] != 86400 || originalTTLs["www"] != 3600 {
+		t.Errorf("expected the original TTLs of the modified records, got %v", originalTTLs)
+	}
+
+	// A second apply finds nothing to change and keeps the original TTLs.
+	readResp := &fwresource.ReadResponse{State: resp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: resp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	state = zoneTTLResourceModel{}
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &state)...)
+	if state.TTL.ValueInt64() != 300 {
+		t.Errorf("expected the TTL to be unchanged on refresh, got %s", state.TTL)
+	}
+	updates = nil
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: readResp.State.Schema, Raw: readResp.State.Raw},
+		State: readResp.State,
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	if len(updates) != 0 {
+		t.Errorf("expected no update on a second apply, got %v", updates)
+	}
+	state = zoneTTLResourceModel{}
+	updateResp.Diagnostics.Append(updateResp.State.Get(ctx, &state)...)
+	if keptTTLs, _ := originalTTLsFromState(ctx, state.OriginalTTLs); len(keptTTLs) != 2 || keptTTLs["www"] != 3600 {
+		t.Errorf("expected the original TTLs to be kept, got %v", keptTTLs)
+	}
+
+	// Destroying restores the original TTLs, except for records whose TTL
+	// was changed since.
+	for i := range stored {
+		if stored[i].ID == "delegation" {
+			stored[i].TTL = 600
+		}
+	}
+	updates = nil
+	deleteResp := &fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected a single batch update, got %d", len(updates))
+	}
+	current = ttls()
+	if current["www"] != 3600 || current["delegation"] != 600 || current["low"] != 300 {
+		t.Errorf("expected only the TTL of the www record to be restored, got %v", current)
+	}
+}
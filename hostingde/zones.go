@@ -1,101 +1,457 @@
 package hostingde
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // https://www.hosting.de/api/?json#listing-zones
-func (c *Client) listZones(findRequest ZonesFindRequest) (*ZonesFindResponse, error) {
-	uri := c.baseURL + "/zonesFind"
+func (c *Client) listZones(ctx context.Context, findRequest ZonesFindRequest) (*ZonesFindResponse, error) {
+	uri := c.endpoint(serviceDNS, "zonesFind")
 
 	findResponse := &ZonesFindResponse{}
 
-	rawResp, err := c.doRequest(http.MethodPost, uri, findRequest, findResponse)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest, findResponse)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(findResponse.Response.Data) == 0 {
-		return nil, fmt.Errorf("%v: uri: %s %s", err, uri, rawResp)
+		return nil, notFoundError(uri, rawResp)
+	}
+
+	if findResponse.Status != "success" && findResponse.Status != "pending" {
+		return findResponse, responseError(uri, rawResp, findResponse.BaseResponse)
+	}
+
+	return findResponse, nil
+}
+
+// https://www.hosting.de/api/?json#list-zoneconfigs
+func (c *Client) listZoneConfigs(ctx context.Context, findRequest ZoneConfigsFindRequest) (*ZoneConfigsFindResponse, error) {
+	uri := c.endpoint(serviceDNS, "zoneConfigsFind")
+
+	findResponse := &ZoneConfigsFindResponse{}
+
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest, findResponse)
+	if err != nil {
+		return nil, err
 	}
 
 	if findResponse.Status != "success" && findResponse.Status != "pending" {
-		return findResponse, errors.New(toErrorWithNewlines(uri, rawResp))
+		return findResponse, responseError(uri, rawResp, findResponse.BaseResponse)
 	}
 
 	return findResponse, nil
 }
 
+// findZoneConfigsByName returns the configs of all zones with the given
+// name, restricted to an account if accountId is not empty. Reseller accounts
+// may see zones with the same name in several sub-accounts. It returns an
+// error wrapping ErrNotFound if no zone matches.
+func (c *Client) findZoneConfigsByName(ctx context.Context, name, accountId string) ([]ZoneConfig, error) {
+	return c.findZoneConfigsByNameField(ctx, "ZoneName", name, accountId)
+}
+
+// findZoneConfigsByNameField is findZoneConfigsByName for the given name
+// filter field, ZoneName or ZoneNameUnicode.
+func (c *Client) findZoneConfigsByNameField(ctx context.Context, field, name, accountId string) ([]ZoneConfig, error) {
+	filter := FilterOrChain{Filter: Filter{Field: field, Value: name}}
+	if accountId != "" {
+		filter = FilterOrChain{
+			SubFilterConnective: "AND",
+			SubFilter: []Filter{
+				{Field: field, Value: name},
+				{Field: "AccountId", Value: accountId},
+			},
+		}
+	}
+
+	findResponse, err := c.listZoneConfigs(ctx, ZoneConfigsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter:      filter,
+		Limit:       100,
+		Page:        1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findResponse.Response.Data, nil
+}
+
+// resolveZone returns the config of the zone with the given name. If the zone
+// does not exist and auto_create_zone is enabled, it is created as a native
+// zone using the default name server set, which is reported as created.
+func (c *Client) resolveZone(ctx context.Context, zoneName string) (*ZoneConfig, bool, error) {
+	// Records in the same new zone must not create it twice.
+	unlock, err := c.lockZone(ctx, "name:"+zoneName)
+	if err != nil {
+		return nil, false, err
+	}
+	defer unlock()
+
+	matches, err := c.findZoneConfigsByName(ctx, zoneName, "")
+	switch {
+	case err == nil && len(matches) == 1:
+		return &matches[0], false, nil
+	case err == nil:
+		return nil, false, fmt.Errorf("found %d zones named %s, set zone_id to select one of them", len(matches), zoneName)
+	case !errors.Is(err, ErrNotFound) || !c.autoCreateZone:
+		return nil, false, err
+	}
+
+	createRequest := ZoneCreateRequest{
+		BaseRequest:             c.newBaseRequest(ctx),
+		UseDefaultNameserverSet: true,
+		ZoneConfig: ZoneConfig{
+			Name:         zoneName,
+			Type:         "NATIVE",
+			EMailAddress: "hostmaster@" + zoneName,
+		},
+		Records: []DNSRecord{},
+	}
+	c.logZoneMutation(ctx, "Creating DNS zone", createRequest.ZoneConfig)
+	createResponse, err := c.createZone(ctx, createRequest)
+	if err != nil {
+		return nil, false, fmt.Errorf("creating zone %s: %w", zoneName, err)
+	}
+	return &createResponse.Response.ZoneConfig, true, nil
+}
+
+// zoneConfigsCacheEntry holds the config of a zone once loaded. Its mutex
+// serializes loading, so concurrent lookups of a zone cause a single request
+// without blocking lookups of other zones.
+type zoneConfigsCacheEntry struct {
+	mu         sync.Mutex
+	zoneConfig *ZoneConfig
+}
+
+// getZoneConfig returns the config of a zone. Zone configs are cached for the
+// lifetime of the client, so repeated lookups during a plan or apply only
+// cause a single request per zone.
+func (c *Client) getZoneConfig(ctx context.Context, zoneId string) (*ZoneConfig, error) {
+	c.zoneConfigsMu.Lock()
+	if c.zoneConfigs == nil {
+		c.zoneConfigs = map[string]*zoneConfigsCacheEntry{}
+	}
+	entry, ok := c.zoneConfigs[zoneId]
+	if !ok {
+		entry = &zoneConfigsCacheEntry{}
+		c.zoneConfigs[zoneId] = entry
+	}
+	c.zoneConfigsMu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.zoneConfig != nil {
+		return entry.zoneConfig, nil
+	}
+
+	findResponse, err := c.listZoneConfigs(ctx, ZoneConfigsFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "ZoneConfigId",
+			Value: zoneId,
+		}},
+		Limit: 1,
+		Page:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(findResponse.Response.Data) == 0 {
+		return nil, fmt.Errorf("zone ID %s: %w", zoneId, ErrNotFound)
+	}
+
+	zoneConfig := findResponse.Response.Data[0]
+	entry.zoneConfig = &zoneConfig
+
+	return &zoneConfig, nil
+}
+
+// cacheZoneConfig replaces the cached config of a zone, e.g. with the config
+// returned after a change.
+func (c *Client) cacheZoneConfig(zoneId string, zoneConfig ZoneConfig) {
+	c.zoneConfigsMu.Lock()
+	defer c.zoneConfigsMu.Unlock()
+
+	if c.zoneConfigs == nil {
+		c.zoneConfigs = map[string]*zoneConfigsCacheEntry{}
+	}
+	c.zoneConfigs[zoneId] = &zoneConfigsCacheEntry{zoneConfig: &zoneConfig}
+}
+
+// invalidateZoneConfig drops the cached config of a zone after it was changed
+// or deleted.
+func (c *Client) invalidateZoneConfig(zoneId string) {
+	c.zoneConfigsMu.Lock()
+	defer c.zoneConfigsMu.Unlock()
+
+	delete(c.zoneConfigs, zoneId)
+}
+
+// defaultRecordTTL returns the TTL of records without a configured TTL in the
+// given zone: the provider's default_ttl if set, otherwise the TTL of the
+// zone's SOA record, and 3600 seconds if the zone has no SOA values. The zone
+// config is cached, so the lookup causes at most one request per zone. It
+// returns false if the default depends on a zone that is not known yet.
+func (c *Client) defaultRecordTTL(ctx context.Context, zoneId string) (int64, bool) {
+	if c == nil {
+		return fallbackRecordTTL, true
+	}
+	if c.defaultTTL > 0 {
+		return c.defaultTTL, true
+	}
+	if zoneId == "" {
+		return 0, false
+	}
+
+	zoneConfig, err := c.getZoneConfig(ctx, zoneId)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read zone config for the default TTL", map[string]any{"zone_id": zoneId, "error": err.Error()})
+		return fallbackRecordTTL, true
+	}
+	if zoneConfig.SOAValues != nil && zoneConfig.SOAValues.TTL >= minRecordTTL && zoneConfig.SOAValues.TTL <= maxRecordTTL {
+		return int64(zoneConfig.SOAValues.TTL), true
+	}
+	return fallbackRecordTTL, true
+}
+
+// DNSSEC modes of zones.
+const (
+	// dnsSecModeOff serves the zone unsigned.
+	dnsSecModeOff = "off"
+	// dnsSecModeAutomatic signs the zone and manages its keys.
+	dnsSecModeAutomatic = "automatic"
+)
+
+// zoneStatusRestorable is the status of zones that were deleted but can still
+// be restored.
+const zoneStatusRestorable = "restorable"
+
+// zoneRestorableDetail describes why the records of a deleted zone that can
+// still be restored cannot be changed, telling it apart from a zone that does
+// not exist. It returns an empty string for zones in any other status.
+func zoneRestorableDetail(zoneConfig *ZoneConfig) string {
+	if zoneConfig.Status != zoneStatusRestorable {
+		return ""
+	}
+	return fmt.Sprintf("Zone %s (ID %s) was deleted and has status %s, so its records cannot be changed. "+
+		"Restore the zone first, e.g. in the hosting.de control panel or with the zoneRestore API method, "+
+		"or remove its records from the configuration if the zone is no longer needed.",
+		zoneConfig.Name, zoneConfig.ID, zoneConfig.Status)
+}
+
+// zoneRestorableErrorDetail describes an error changing the records of a zone
+// that was deleted but can still be restored. It reports false for other
+// errors, and if the zone cannot be read.
+func (c *Client) zoneRestorableErrorDetail(ctx context.Context, zoneId string, err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	zoneConfig, lookupErr := c.getZoneConfig(ctx, zoneId)
+	if lookupErr != nil {
+		return "", false
+	}
+	detail := zoneRestorableDetail(zoneConfig)
+	if detail == "" {
+		return "", false
+	}
+	return detail + "\n\n" + err.Error(), true
+}
+
+// zoneChangeDetail tells a record whose zone was renamed or deleted apart
+// from a record that is gone itself. zoneName is the name the zone had when
+// the record was last read. The zone config is cached, so the check causes at
+// most one request per zone. It reports false if the zone still has that
+// name, or cannot be read.
+func (c *Client) zoneChangeDetail(ctx context.Context, zoneId, zoneName string) (string, bool) {
+	if zoneId == "" {
+		return "", false
+	}
+	zoneConfig, err := c.getZoneConfig(ctx, zoneId)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return fmt.Sprintf("Zone %s (ID %s) no longer exists, so its records are gone as well.", zoneName, zoneId), true
+	case err != nil:
+		tflog.Debug(ctx, "Could not read zone config to check for a renamed zone", map[string]any{"zone_id": zoneId, "error": err.Error()})
+		return "", false
+	case zoneName == "" || canonicalHostName(zoneConfig.Name) == canonicalHostName(zoneName):
+		return "", false
+	}
+	return fmt.Sprintf("Zone ID %s was renamed from %s to %s. Records are kept by zone ID: "+
+		"set zone_name to %s, or configure the record by zone_id.",
+		zoneId, zoneName, zoneConfig.Name, zoneConfig.Name), true
+}
+
+// zoneWriteWarning describes why records of a zone may not be writable, e.g.
+// because the zone is a SLAVE zone or not active. It returns an empty string
+// for writable zones.
+func zoneWriteWarning(zoneConfig *ZoneConfig) string {
+	var reason string
+	switch {
+	case zoneConfig.Type == "SLAVE":
+		reason = "is a SLAVE zone, whose records are transferred from its master name server"
+	case zoneConfig.Status != "" && zoneConfig.Status != "active":
+		reason = "has status " + zoneConfig.Status
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf("Zone %s (ID %s, DNS server group ID %s) %s, so records may not be writable.",
+		zoneConfig.Name, zoneConfig.ID, zoneConfig.DNSServerGroupID, reason)
+}
+
 // https://www.hosting.de/api/?json#creating-new-zones
-func (c *Client) createZone(createRequest ZoneCreateRequest) (*ZoneCreateResponse, error) {
-	uri := c.baseURL + "/zoneCreate"
+func (c *Client) createZone(ctx context.Context, createRequest ZoneCreateRequest) (*ZoneCreateResponse, error) {
+	uri := c.endpoint(serviceDNS, "zoneCreate")
 
 	createResponse := &ZoneCreateResponse{}
 
-	rawResp, err := c.doRequest(http.MethodPost, uri, createRequest, createResponse)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, createRequest, createResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	if createResponse.Status != "success" && createResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	if checkResponseErrors(createResponse.BaseResponse) != nil {
+		return nil, responseError(uri, rawResp, createResponse.BaseResponse)
+	}
+
+	if createResponse.Status == "pending" {
+		zoneConfig, err := c.waitZoneNotPending(ctx, createResponse.Response.ZoneConfig.ID)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for zone creation: %w", err)
+		}
+		if zoneConfig != nil {
+			createResponse.Response.ZoneConfig = *zoneConfig
+		}
 	}
 
 	return createResponse, nil
 }
 
 // https://www.hosting.de/api/?json#updating-zones
-func (c *Client) updateZone(updateRequest ZoneUpdateRequest) (*ZoneUpdateResponse, error) {
-	uri := c.baseURL + "/zoneUpdate"
+func (c *Client) updateZone(ctx context.Context, updateRequest ZoneUpdateRequest) (*ZoneUpdateResponse, error) {
+	uri := c.endpoint(serviceDNS, "zoneUpdate")
 
 	updateResponse := &ZoneUpdateResponse{}
 
-	rawResp, err := c.doRequest(http.MethodPost, uri, updateRequest, updateResponse)
+	c.invalidateZoneRecords(updateRequest.ZoneConfig.ID)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, updateRequest, updateResponse)
+	// The change may be applied even if the request failed.
+	c.invalidateZoneConfig(updateRequest.ZoneConfig.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if updateResponse.Status != "success" && updateResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	if checkResponseErrors(updateResponse.BaseResponse) != nil {
+		return nil, responseError(uri, rawResp, updateResponse.BaseResponse)
+	}
+
+	if updateResponse.Status == "pending" {
+		zoneConfig, err := c.waitZoneNotPending(ctx, updateRequest.ZoneConfig.ID)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for zone update: %w", err)
+		}
+		if zoneConfig != nil {
+			updateResponse.Response.ZoneConfig = *zoneConfig
+		}
+	} else if updateResponse.Response.ZoneConfig.ID != "" {
+		c.cacheZoneConfig(updateRequest.ZoneConfig.ID, updateResponse.Response.ZoneConfig)
 	}
 
 	return updateResponse, nil
 }
 
 // https://www.hosting.de/api/?json#deleting-zones
-func (c *Client) deleteZone(deleteRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
-	uri := c.baseURL + "/zoneDelete"
+func (c *Client) deleteZone(ctx context.Context, deleteRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
+	uri := c.endpoint(serviceDNS, "zoneDelete")
 
 	deleteResponse := &ZoneDeleteResponse{}
 
-	rawResp, err := c.doRequest(http.MethodPost, uri, deleteRequest, deleteResponse)
+	c.invalidateZoneRecords(deleteRequest.ZoneConfigId)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, deleteRequest, deleteResponse)
+	c.invalidateZoneConfig(deleteRequest.ZoneConfigId)
 	if err != nil {
 		return nil, err
 	}
 
-	if deleteResponse.Status != "success" && deleteResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	if checkResponseErrors(deleteResponse.BaseResponse) != nil {
+		return nil, responseError(uri, rawResp, deleteResponse.BaseResponse)
+	}
+
+	// The deletion is complete once the zone is gone or no longer pending.
+	if deleteResponse.Status == "pending" {
+		if _, err := c.waitZoneNotPending(ctx, deleteRequest.ZoneConfigId); err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("waiting for zone deletion: %w", err)
+		}
 	}
 
 	return deleteResponse, nil
 }
 
 // https://www.hosting.de/api/?json#purging-zones
-func (c *Client) purgeZone(purgeRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
-	uri := c.baseURL + "/zonePurgeRestorable"
+func (c *Client) purgeZone(ctx context.Context, purgeRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
+	uri := c.endpoint(serviceDNS, "zonePurgeRestorable")
 
 	purgeResponse := &ZoneDeleteResponse{}
 
-	rawResp, err := c.doRequest(http.MethodPost, uri, purgeRequest, purgeResponse)
+	c.invalidateZoneRecords(purgeRequest.ZoneConfigId)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, purgeRequest, purgeResponse)
+	c.invalidateZoneConfig(purgeRequest.ZoneConfigId)
 	if err != nil {
 		return nil, err
 	}
 
-	if purgeResponse.Status != "success" && purgeResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	if checkResponseErrors(purgeResponse.BaseResponse) != nil {
+		return nil, responseError(uri, rawResp, purgeResponse.BaseResponse)
 	}
 
 	return purgeResponse, nil
 }
+
+// getZone returns the zone with the given ID or, if zoneId is empty, the
+// given name, including all of its records. Records are fetched separately
+// if the zone object does not contain all of them.
+func (c *Client) getZone(ctx context.Context, zoneId, zoneName string) (*Zone, error) {
+	filter := Filter{Field: "ZoneConfigId", Value: zoneId}
+	if zoneId == "" {
+		filter = Filter{Field: "ZoneName", Value: zoneName}
+	}
+
+	zones, err := c.listZones(ctx, ZonesFindRequest{
+		BaseRequest: c.newBaseRequest(ctx),
+		Filter:      FilterOrChain{Filter: filter},
+		Limit:       1,
+		Page:        1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	zone := zones.Response.Data[0]
+
+	// Only the number of records is needed to know whether the zone object
+	// is complete.
+	count, err := c.countRecords(ctx, zone.ZoneConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > len(zone.Records) {
+		zone.Records, err = c.listAllRecords(ctx, RecordsFindRequest{
+			BaseRequest: c.newBaseRequest(ctx),
+			Filter:      recordsFilter(zone.ZoneConfig.ID, "", ""),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &zone, nil
+}